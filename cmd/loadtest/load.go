@@ -0,0 +1,449 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/centny/flame_network/lib/src/network"
+	ggrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/stats"
+)
+
+// CallPattern is the NetworkCall which is sent by each client, it is parsed from cid.name(arg)@interval, the arg is json
+// and {client} in arg is replaced by client name, the call without interval is sent once before join
+type CallPattern struct {
+	CID      string
+	Name     string
+	Arg      string
+	Interval time.Duration
+}
+
+// ParseCallPattern will parse the call pattern from cid.name(arg)@interval
+func ParseCallPattern(s string) (pattern *CallPattern, err error) {
+	pattern = &CallPattern{Arg: "null"}
+	if i := strings.LastIndex(s, "@"); i > strings.LastIndex(s, ")") {
+		pattern.Interval, err = time.ParseDuration(s[i+1:])
+		if err != nil {
+			return
+		}
+		s = s[:i]
+	}
+	if i := strings.Index(s, "("); i > 0 {
+		if !strings.HasSuffix(s, ")") {
+			err = fmt.Errorf("call pattern %v is not closed", s)
+			return
+		}
+		pattern.Arg = s[i+1 : len(s)-1]
+		s = s[:i]
+	}
+	i := strings.LastIndex(s, ".")
+	if i < 1 || i >= len(s)-1 {
+		err = fmt.Errorf("call pattern %v is not cid.name", s)
+		return
+	}
+	pattern.CID, pattern.Name = s[:i], s[i+1:]
+	return
+}
+
+func (c *CallPattern) String() string {
+	return fmt.Sprintf("%v.%v", c.CID, c.Name)
+}
+
+// LoadOption is the option of load tester
+type LoadOption struct {
+	Address   string         // the server address, it is websocket when scheme is ws or wss
+	Clients   int            // the number of concurrent clients
+	Ramp      time.Duration  // the delay between spawning two clients
+	Duration  time.Duration  // the duration to keep all clients running after spawned
+	Prefix    string         // the prefix of client name, the name is used as session key and user
+	Group     string         // the group to join after once calls
+	Keepalive time.Duration  // the ping interval of client, the server connection count is updated by ping
+	Calls     []*CallPattern // the calls sent by each client
+}
+
+// LoadTester will spawn the concurrent clients to server and collect the metrics
+type LoadTester struct {
+	Option    *LoadOption
+	clientAll []*loadClient
+	syncBytes int64
+	syncCount int64
+	dropped   int64
+	failed    int64
+	callAll   map[string]*loadCallStats
+	callLck   sync.Mutex
+	running   int32
+}
+
+func NewLoadTester(option *LoadOption) (tester *LoadTester) {
+	tester = &LoadTester{
+		Option:  option,
+		callAll: map[string]*loadCallStats{},
+	}
+	return
+}
+
+type loadCallStats struct {
+	Name    string
+	Latency []time.Duration
+	Errors  int64
+}
+
+// Run will spawn the clients by ramp and keep them running by duration, the report is called by interval if it is not nil
+func (l *LoadTester) Run(ctx context.Context, interval time.Duration, report func(r *LoadReport)) (result *LoadReport, err error) {
+	atomic.StoreInt32(&l.running, 1)
+	waiter := sync.WaitGroup{}
+	runCtx, cancel := context.WithCancel(ctx)
+	defer func() {
+		cancel()
+		waiter.Wait()
+		atomic.StoreInt32(&l.running, 0)
+		for _, client := range l.clientAll {
+			client.Close()
+		}
+	}()
+	startTime := time.Now()
+	lastTime, lastBytes, lastCount := startTime, int64(0), int64(0)
+	var ticker <-chan time.Time
+	if interval > 0 && report != nil {
+		t := time.NewTicker(interval)
+		defer t.Stop()
+		ticker = t.C
+	}
+	doReport := func() {
+		now := time.Now()
+		r := l.Report(now.Sub(lastTime), atomic.LoadInt64(&l.syncBytes)-lastBytes, atomic.LoadInt64(&l.syncCount)-lastCount)
+		lastTime, lastBytes, lastCount = now, atomic.LoadInt64(&l.syncBytes), atomic.LoadInt64(&l.syncCount)
+		report(r)
+	}
+	for i := 0; i < l.Option.Clients; i++ {
+		client := newLoadClient(l, fmt.Sprintf("%v-%v", l.Option.Prefix, i))
+		if xerr := client.Start(); xerr != nil {
+			network.Warnf("LoadTester client %v start error %v", client.Name, xerr)
+			client.Close()
+			atomic.AddInt64(&l.failed, 1)
+		} else {
+			l.clientAll = append(l.clientAll, client)
+			waiter.Add(1)
+			go func() {
+				defer waiter.Done()
+				client.Loop(runCtx)
+			}()
+		}
+		if i < l.Option.Clients-1 && l.Option.Ramp > 0 {
+			ramp := time.After(l.Option.Ramp)
+			for waiting := true; waiting; {
+				select {
+				case <-ramp:
+					waiting = false
+				case <-ticker:
+					doReport()
+				case <-ctx.Done():
+					err = ctx.Err()
+					return
+				}
+			}
+		}
+	}
+	timer := time.NewTimer(l.Option.Duration)
+	defer timer.Stop()
+	for running := true; running; {
+		select {
+		case <-timer.C:
+			running = false
+		case <-ticker:
+			doReport()
+		case <-ctx.Done():
+			running = false
+		}
+	}
+	for _, client := range l.clientAll {
+		client.Ping()
+	}
+	result = l.Report(time.Since(startTime), atomic.LoadInt64(&l.syncBytes), atomic.LoadInt64(&l.syncCount))
+	return
+}
+
+func (l *LoadTester) addCall(name string, latency time.Duration, err error) {
+	l.callLck.Lock()
+	defer l.callLck.Unlock()
+	call := l.callAll[name]
+	if call == nil {
+		call = &loadCallStats{Name: name}
+		l.callAll[name] = call
+	}
+	if err != nil {
+		call.Errors++
+	} else {
+		call.Latency = append(call.Latency, latency)
+	}
+}
+
+// TagRPC implements stats.Handler to mark the sync rpc, so the received bytes on it is counted, the call and ping result
+// multiplexed on stream is also counted when StreamOn
+func (l *LoadTester) TagRPC(ctx context.Context, info *stats.RPCTagInfo) context.Context {
+	method := strings.ToLower(info.FullMethodName)
+	if strings.HasSuffix(method, "/remotesync") || strings.HasSuffix(method, "/remotestream") {
+		ctx = context.WithValue(ctx, loadSyncKey{}, true)
+	}
+	return ctx
+}
+
+// HandleRPC implements stats.Handler to count the received bytes on sync rpc
+func (l *LoadTester) HandleRPC(ctx context.Context, s stats.RPCStats) {
+	if payload, ok := s.(*stats.InPayload); ok && ctx.Value(loadSyncKey{}) != nil {
+		atomic.AddInt64(&l.syncBytes, int64(payload.WireLength))
+	}
+}
+
+func (l *LoadTester) TagConn(ctx context.Context, info *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+func (l *LoadTester) HandleConn(ctx context.Context, s stats.ConnStats) {
+}
+
+type loadSyncKey struct{}
+
+// LoadReport is the metrics collected by load tester
+type LoadReport struct {
+	Elapsed       time.Duration
+	Clients       int
+	SyncBytes     int64
+	SyncCount     int64
+	SyncBytesRate float64                  // the received sync bytes per second
+	SyncRate      float64                  // the received sync data per second
+	SyncLatency   map[string]time.Duration // the mean sync latency by client name, it is server send to client received
+	Dropped       int64                    // the number of sync stream which is broken when running
+	Failed        int64                    // the number of client which is failed to start
+	Connected     int                      // the sum of session connection count on server by last ping result
+	Calls         []*LoadCallReport
+}
+
+// LoadCallReport is the call latency percentiles by call pattern
+type LoadCallReport struct {
+	Name   string
+	Count  int
+	Errors int64
+	P50    time.Duration
+	P90    time.Duration
+	P99    time.Duration
+	Max    time.Duration
+}
+
+// Report will return the metrics by elapsed and the sync bytes and count received in elapsed
+func (l *LoadTester) Report(elapsed time.Duration, syncBytes, syncCount int64) (r *LoadReport) {
+	r = &LoadReport{
+		Elapsed:     elapsed,
+		Clients:     len(l.clientAll),
+		SyncBytes:   syncBytes,
+		SyncCount:   syncCount,
+		SyncLatency: map[string]time.Duration{},
+		Dropped:     atomic.LoadInt64(&l.dropped),
+		Failed:      atomic.LoadInt64(&l.failed),
+	}
+	if seconds := elapsed.Seconds(); seconds > 0 {
+		r.SyncBytesRate = float64(syncBytes) / seconds
+		r.SyncRate = float64(syncCount) / seconds
+	}
+	for _, client := range l.clientAll {
+		if latency, ok := client.SyncLatency(); ok {
+			r.SyncLatency[client.Name] = latency
+		}
+		r.Connected += client.Connected()
+	}
+	l.callLck.Lock()
+	for _, call := range l.callAll {
+		latency := append([]time.Duration{}, call.Latency...)
+		sort.Slice(latency, func(i, j int) bool { return latency[i] < latency[j] })
+		r.Calls = append(r.Calls, &LoadCallReport{
+			Name:   call.Name,
+			Count:  len(latency),
+			Errors: call.Errors,
+			P50:    Percentile(latency, 0.5),
+			P90:    Percentile(latency, 0.9),
+			P99:    Percentile(latency, 0.99),
+			Max:    Percentile(latency, 1),
+		})
+	}
+	l.callLck.Unlock()
+	sort.Slice(r.Calls, func(i, j int) bool { return r.Calls[i].Name < r.Calls[j].Name })
+	return
+}
+
+// Percentile will return the p percentile of sorted values by nearest rank
+func Percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) < 1 {
+		return 0
+	}
+	i := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if i < 0 {
+		i = 0
+	}
+	if i >= len(sorted) {
+		i = len(sorted) - 1
+	}
+	return sorted[i]
+}
+
+func (r *LoadReport) String() string {
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "elapsed:%v clients:%v failed:%v connected:%v dropped:%v\n", r.Elapsed.Round(time.Millisecond), r.Clients, r.Failed, r.Connected, r.Dropped)
+	fmt.Fprintf(b, "sync: %.0f bytes/s, %.1f syncs/s, %v bytes, %v syncs\n", r.SyncBytesRate, r.SyncRate, r.SyncBytes, r.SyncCount)
+	latency := make([]time.Duration, 0, len(r.SyncLatency))
+	for _, v := range r.SyncLatency {
+		latency = append(latency, v)
+	}
+	sort.Slice(latency, func(i, j int) bool { return latency[i] < latency[j] })
+	fmt.Fprintf(b, "sync latency by client: min:%v p50:%v p99:%v max:%v\n", Percentile(latency, 0), Percentile(latency, 0.5), Percentile(latency, 0.99), Percentile(latency, 1))
+	for _, call := range r.Calls {
+		fmt.Fprintf(b, "call %v: count:%v errors:%v p50:%v p90:%v p99:%v max:%v\n", call.Name, call.Count, call.Errors, call.P50, call.P90, call.P99, call.Max)
+	}
+	return b.String()
+}
+
+// loadClient is one simulated client which send the calls and consume the sync stream
+type loadClient struct {
+	*network.NetworkClient
+	Name         string
+	tester       *LoadTester
+	latencySum   int64
+	latencyCount int64
+	closing      int32
+}
+
+func newLoadClient(tester *LoadTester, name string) (client *loadClient) {
+	client = &loadClient{
+		NetworkClient: network.NewNetworkClient(name),
+		Name:          name,
+		tester:        tester,
+	}
+	option := tester.Option
+	if option.Keepalive > 0 {
+		client.Context.Network.Keepalive = option.Keepalive
+	}
+	if transport, ok := client.Transport.(*network.NetworkTransportGRPC); ok { //the sync bytes is counted on grpc only
+		transport.GrpcOpts = append(transport.GrpcOpts, ggrpc.WithStatsHandler(tester))
+	}
+	client.Context.EventHub.RegisterNetworkEvent("*", client)
+	return
+}
+
+// Start will connect to server, send the once calls and join the group
+func (l *loadClient) Start() (err error) {
+	err = l.Connect(l.tester.Option.Address)
+	if err != nil {
+		return
+	}
+	l.Ping()
+	for _, pattern := range l.tester.Option.Calls {
+		if pattern.Interval > 0 {
+			continue
+		}
+		if err = l.call(pattern); err != nil {
+			return
+		}
+	}
+	err = l.Join(l.tester.Option.Group, l.Name)
+	return
+}
+
+// Loop will send the interval calls until ctx is done
+func (l *loadClient) Loop(ctx context.Context) {
+	waiter := sync.WaitGroup{}
+	for _, pattern := range l.tester.Option.Calls {
+		if pattern.Interval <= 0 {
+			continue
+		}
+		waiter.Add(1)
+		go func(pattern *CallPattern) {
+			defer waiter.Done()
+			ticker := time.NewTicker(pattern.Interval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ticker.C:
+					l.call(pattern)
+				case <-ctx.Done():
+					return
+				}
+			}
+		}(pattern)
+	}
+	waiter.Wait()
+}
+
+func (l *loadClient) call(pattern *CallPattern) (err error) {
+	startTime := time.Now()
+	_, err = l.Context.Network.NetworkCall(&network.NetworkCallArg{
+		UUID: fmt.Sprintf("%v-%v", l.Name, startTime.UnixNano()),
+		CID:  pattern.CID,
+		Name: pattern.Name,
+		Arg:  strings.ReplaceAll(pattern.Arg, "{client}", l.Name),
+	})
+	l.tester.addCall(pattern.String(), time.Since(startTime), err)
+	return
+}
+
+// Ping will ping server to update the server time and connection count
+func (l *loadClient) Ping() {
+	client := l.Transport.ClientConn()
+	if client == nil {
+		return
+	}
+	speed, serverTime, err := client.Ping()
+	if err != nil {
+		network.Warnf("LoadTester client %v ping error %v", l.Name, err)
+		return
+	}
+	l.Context.Network.SetServerTime(serverTime, speed)
+}
+
+func (l *loadClient) Connected() (connected int) {
+	if client := l.Transport.ClientConn(); client != nil {
+		connected = client.Connected()
+	}
+	return
+}
+
+// SyncLatency will return the mean latency from server send to client received
+func (l *loadClient) SyncLatency() (latency time.Duration, ok bool) {
+	count := atomic.LoadInt64(&l.latencyCount)
+	if count > 0 {
+		latency, ok = time.Duration(atomic.LoadInt64(&l.latencySum)/count), true
+	}
+	return
+}
+
+func (l *loadClient) Close() (err error) {
+	atomic.StoreInt32(&l.closing, 1)
+	err = l.NetworkClient.Close()
+	return
+}
+
+func (l *loadClient) OnNetworkState(all network.NetworkConnectionSet, conn network.NetworkConnection, state network.NetworkState, info interface{}) {
+	if state == network.NetworkStateError && atomic.LoadInt32(&l.closing) == 0 && atomic.LoadInt32(&l.tester.running) == 1 {
+		atomic.AddInt64(&l.tester.dropped, 1)
+	}
+}
+
+func (l *loadClient) OnNetworkPing(conn network.NetworkConnection, ping time.Duration) {
+}
+
+func (l *loadClient) OnNetworkDataSynced(conn network.NetworkConnection, data *network.NetworkSyncData) {
+	atomic.AddInt64(&l.tester.syncCount, 1)
+	if data.Time.IsZero() {
+		return
+	}
+	latency := l.Context.Network.ServerTime().Sub(data.Time)
+	if latency < 0 {
+		latency = 0
+	}
+	atomic.AddInt64(&l.latencySum, int64(latency))
+	atomic.AddInt64(&l.latencyCount, 1)
+}
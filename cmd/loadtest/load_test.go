@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/centny/flame_network/lib/src/network"
+)
+
+func TestParseCallPattern(t *testing.T) {
+	if pattern, err := ParseCallPattern(`game-0.join("{client}")`); err != nil || pattern.CID != "game-0" || pattern.Name != "join" || pattern.Arg != `"{client}"` || pattern.Interval != 0 {
+		t.Errorf("%v,%v", err, pattern)
+		return
+	}
+	if pattern, err := ParseCallPattern(`a.b.fire([1,2])@100ms`); err != nil || pattern.CID != "a.b" || pattern.Name != "fire" || pattern.Arg != "[1,2]" || pattern.Interval != 100*time.Millisecond {
+		t.Errorf("%v,%v", err, pattern)
+		return
+	}
+	if pattern, err := ParseCallPattern(`c1.switch@1s`); err != nil || pattern.Arg != "null" || pattern.Interval != time.Second || pattern.String() != "c1.switch" {
+		t.Errorf("%v,%v", err, pattern)
+		return
+	}
+	for _, s := range []string{"c1", "c1.", ".name", "c1.add(1", "c1.add@x"} {
+		if _, err := ParseCallPattern(s); err == nil {
+			t.Error(s)
+			return
+		}
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	values := []time.Duration{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	if Percentile(nil, 0.5) != 0 || Percentile(values, 0) != 1 || Percentile(values, 0.5) != 5 || Percentile(values, 0.99) != 10 || Percentile(values, 1) != 10 {
+		t.Error("error")
+		return
+	}
+}
+
+func TestLoadTester(t *testing.T) {
+	server := network.NewNetworkContext()
+	server.Network.IsServer = true
+	server.Network.MinSync = 0
+	transport := network.NewNetworkTransportGRPCByContext(server)
+	transport.GrpcAddress, _ = url.Parse("grpc://127.0.0.1:50095")
+	transport.WebAddress, _ = url.Parse("ws://127.0.0.1:50096")
+	server.SetTransport(transport)
+	game := network.NewNetworkComponentByContext(server, "game", "g1", "", "game")
+	game.SetValue("x", 0)
+	game.RegisterNetworkProp()
+	network.RegisterCall(game, "join", func(ctx network.NetworkSession, uuid string, name string) (result string, err error) {
+		ctx.SetGroup("g1")
+		ctx.SetUser(name)
+		result = "OK"
+		return
+	})
+	network.RegisterCall(game, "add", func(ctx network.NetworkSession, uuid string, v int) (result int, err error) {
+		result = v + 1
+		return
+	})
+	if err := server.Network.Start(); err != nil {
+		t.Error(err)
+		return
+	}
+	defer server.Network.Stop()
+	exiter := make(chan int)
+	defer close(exiter)
+	go func() {
+		ticker := time.NewTicker(20 * time.Millisecond)
+		defer ticker.Stop()
+		for x := 1; ; x++ {
+			select {
+			case <-ticker.C:
+				game.SetValue("x", x)
+				server.Network.Sync("g1", nil)
+			case <-exiter:
+				return
+			}
+		}
+	}()
+
+	join, _ := ParseCallPattern(`game.join("{client}")`)
+	add, _ := ParseCallPattern(`game.add(1)@20ms`)
+	none, _ := ParseCallPattern(`game.none@50ms`)
+	tester := NewLoadTester(&LoadOption{
+		Address:   "grpc://127.0.0.1:50095",
+		Clients:   3,
+		Ramp:      10 * time.Millisecond,
+		Duration:  300 * time.Millisecond,
+		Prefix:    "load",
+		Group:     "g1",
+		Keepalive: 100 * time.Millisecond,
+		Calls:     []*CallPattern{join, add, none},
+	})
+	reported := 0
+	result, err := tester.Run(context.Background(), 100*time.Millisecond, func(r *LoadReport) { reported++ })
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	t.Logf("\n%v", result)
+	if result.Clients != 3 || result.Failed != 0 || result.Connected != 3 || result.Dropped != 0 || reported < 1 {
+		t.Errorf("%v", result)
+		return
+	}
+	if result.SyncBytes < 1 || result.SyncCount < 3 || result.SyncBytesRate <= 0 || len(result.SyncLatency) != 3 {
+		t.Errorf("%v", result)
+		return
+	}
+	if len(result.Calls) != 3 || result.Calls[0].Name != "game.add" || result.Calls[0].Count < 3 || result.Calls[0].P50 <= 0 || result.Calls[0].Max < result.Calls[0].P99 {
+		t.Errorf("%v", result)
+		return
+	}
+	if result.Calls[1].Name != "game.join" || result.Calls[1].Count != 3 || result.Calls[2].Errors < 1 || result.Calls[2].Count != 0 {
+		t.Errorf("%v", result)
+		return
+	}
+
+	//start fail
+	tester = NewLoadTester(&LoadOption{Address: "none://127.0.0.1:50095", Clients: 2, Ramp: time.Millisecond, Prefix: "fail"})
+	if result, err := tester.Run(context.Background(), 0, nil); err != nil || result.Failed != 2 || result.Clients != 0 {
+		t.Errorf("%v,%v", err, result)
+		return
+	}
+
+	//canceled
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	tester = NewLoadTester(&LoadOption{Address: "none://127.0.0.1:50095", Clients: 2, Ramp: time.Second, Prefix: "cancel"})
+	if _, err := tester.Run(ctx, 0, nil); err == nil {
+		t.Error("error")
+		return
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+)
+
+type callFlags []*CallPattern
+
+func (c *callFlags) String() string {
+	names := []string{}
+	for _, pattern := range *c {
+		names = append(names, pattern.String())
+	}
+	return strings.Join(names, ",")
+}
+
+func (c *callFlags) Set(value string) (err error) {
+	pattern, err := ParseCallPattern(value)
+	if err == nil {
+		*c = append(*c, pattern)
+	}
+	return
+}
+
+func main() {
+	var calls callFlags
+	option := &LoadOption{}
+	var interval time.Duration
+	flag.StringVar(&option.Address, "addr", "grpc://127.0.0.1:50051", "the server address, grpc://host:port or ws://host:port/path")
+	flag.IntVar(&option.Clients, "n", 10, "the number of concurrent clients")
+	flag.DurationVar(&option.Ramp, "ramp", 10*time.Millisecond, "the delay between spawning two clients")
+	flag.DurationVar(&option.Duration, "d", 30*time.Second, "the duration to keep all clients running after spawned")
+	flag.StringVar(&option.Prefix, "prefix", "load", "the prefix of client name which is used as session key and user")
+	flag.StringVar(&option.Group, "group", "", "the group to join after once calls")
+	flag.DurationVar(&option.Keepalive, "keepalive", time.Second, "the ping interval of client")
+	flag.DurationVar(&interval, "report", 5*time.Second, "the interval to print report, zero is only print on done")
+	flag.Var(&calls, "call", "the call sent by each client as cid.name(arg)@interval, arg is json and {client} is replaced by client name, it is sent once before join when interval is not set, can be repeated")
+	flag.Parse()
+	option.Calls = calls
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+	tester := NewLoadTester(option)
+	result, err := tester.Run(ctx, interval, func(r *LoadReport) {
+		fmt.Printf("------ report ------\n%v", r)
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "load test error %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("------ total ------\n%v", result)
+}
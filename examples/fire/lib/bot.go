@@ -0,0 +1,133 @@
+package lib
+
+import (
+	"fmt"
+	"os"
+	"sync/atomic"
+
+	"github.com/centny/flame_network/lib/src/component"
+	"github.com/centny/flame_network/lib/src/network"
+)
+
+// BossMirror is the boss mirrored on bot
+type BossMirror struct {
+	*network.NetworkComponent
+	Position *network.NetworkProp[Vec]
+	Healthy  *network.NetworkProp[int]
+}
+
+// PlayerMirror is the player mirrored on bot
+type PlayerMirror struct {
+	*network.NetworkComponent
+	Name        *network.NetworkProp[string]
+	Seat        *network.NetworkProp[int]
+	WeaponUsing *network.NetworkProp[int]
+}
+
+// FireBot is the sample bot which join the game by name, fire to the boss on interval and switch weapon when it is rewarded
+type FireBot struct {
+	*component.NetworkBot
+	Interval float64 // the seconds between two fire
+	Fired    int64
+	Rewarded int64
+	elapsed  float64
+}
+
+func NewFireBot(name string) (bot *FireBot) {
+	bot = &FireBot{
+		NetworkBot: component.NewNetworkBot(name),
+		Interval:   0.5,
+	}
+	network.Mirror(bot.NetworkClient, FactoryTypeBoss, func(c *network.NetworkComponent) (boss *BossMirror, err error) {
+		boss = &BossMirror{NetworkComponent: c}
+		boss.Position = network.NewNetworkProp(c, "position", Vec{0, 0})
+		boss.Healthy = network.NewNetworkProp(c, "healthy", 0)
+		return
+	})
+	network.Mirror(bot.NetworkClient, FactoryTypePlayer, func(c *network.NetworkComponent) (player *PlayerMirror, err error) {
+		player = &PlayerMirror{NetworkComponent: c}
+		player.Name = network.NewNetworkProp(c, "name", "")
+		player.Seat = network.NewNetworkProp(c, "seat", 0)
+		player.WeaponUsing = network.NewNetworkProp(c, "weapon.using", 0)
+		return
+	})
+	bot.OnTrigger(FactoryTypePlayer, "reward", bot.onReward)
+	bot.AddBehavior(component.NetworkBehaviorF(bot.fire))
+	return
+}
+
+// Enter will connect to server by address and join the game as player by bot name
+func (b *FireBot) Enter(address string) (err error) {
+	err = b.Connect(address)
+	if err != nil {
+		return
+	}
+	result, err := network.Call[string, string](b.Remote("group-0"), "join", b.Name)
+	if err == nil && result != "OK" {
+		err = fmt.Errorf("join fail with %v", result)
+	}
+	if err == nil {
+		err = b.Join("group-0", b.Name)
+	}
+	return
+}
+
+// Player will return the player mirror owned by bot
+func (b *FireBot) Player() *PlayerMirror {
+	for _, player := range network.Mirrored[*PlayerMirror](b.NetworkClient) {
+		if player.Owner == b.Name {
+			return player
+		}
+	}
+	return nil
+}
+
+func (b *FireBot) fire(bot *component.NetworkBot, tick int64, delta float64) (err error) {
+	b.elapsed += delta
+	if b.elapsed < b.Interval {
+		return
+	}
+	b.elapsed = 0
+	player := b.Player()
+	bosses := network.Mirrored[*BossMirror](b.NetworkClient)
+	if player == nil || len(bosses) < 1 {
+		return
+	}
+	target := bosses[0].Position.Get()
+	if xerr := player.NetworkCall("fire", target, nil); xerr != nil {
+		network.Warnf("Bot(%v) fire to %v error %v", b.Name, target, xerr)
+		return
+	}
+	atomic.AddInt64(&b.Fired, 1)
+	return
+}
+
+func (b *FireBot) onReward(bot *component.NetworkBot, c *network.NetworkComponent, value interface{}) {
+	if c.Owner != b.Name {
+		return
+	}
+	atomic.AddInt64(&b.Rewarded, 1)
+	network.Infof("Bot(%v) is rewarded by %v, switch weapon", b.Name, value)
+	if err := c.NetworkCall("switch", nil, nil); err != nil {
+		network.Warnf("Bot(%v) switch weapon error %v", b.Name, err)
+	}
+}
+
+func MainBot() {
+	addr := os.Getenv("BOT_ADDR")
+	if len(addr) < 1 {
+		addr = "grpc://127.0.0.1:50051"
+	}
+	name := os.Getenv("BOT_NAME")
+	if len(name) < 1 {
+		name = "bot"
+	}
+	network.Infof("bot %v is starting by %v", name, addr)
+	bot := NewFireBot(name)
+	err := bot.Enter(addr)
+	if err != nil {
+		panic(err)
+	}
+	err = bot.Run()
+	network.Infof("bot %v is stopped by %v", name, err)
+}
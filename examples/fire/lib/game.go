@@ -92,6 +92,10 @@ func NewGame() (game *FireGame) {
 		lock:             sync.RWMutex{},
 	}
 	game.initSeat()
+	network.ComponentHub.RegisterQuantum(FactoryTypeBullet, "position", 0.1)
+	network.ComponentHub.RegisterQuantum(FactoryTypeBullet, "direct", 1.0/256)
+	network.ComponentHub.RegisterQuantum(FactoryTypePlayer, "weapon.angle", 1.0/256)
+	network.ComponentHub.RegisterQuantum(FactoryTypePlayer, "weapon.direct", 1.0/256)
 	game.boss = NewBoss(game, uuid.New())
 	game.RegisterNetworkCall("join", game.onPlayerJoin)
 	game.RegisterNetworkEvent(game.Group, game)
@@ -149,8 +153,8 @@ func (g *FireGame) onPlayerJoin(ctx network.NetworkSession, _ string, name strin
 	ctx.SetUser(name)
 	player := NewPlayer(g, owner, uuid.New())
 	player.Position = g.seatPosition[seat]
-	player.SetName(name)
-	player.SetSeat(seat)
+	player.Name.Set(name)
+	player.Seat.Set(seat)
 	g.playerAll[owner] = player
 	network.Infof("Game(%v) player %v/%v join game on %v", player.Group, owner, name, g.Group)
 	result = "OK"
@@ -229,15 +233,34 @@ func NewBoss(game *FireGame, cid string) (boss *Boss) {
 	boss = &Boss{
 		NetworkComponent: network.NewNetworkComponent(FactoryTypeBoss, game.Group, "", cid),
 		Game:             game,
-		Position:         Vec{0, 0},
 		Radius:           160,
 	}
+	boss.History = network.NewNetworkHistory(time.Second)
+	boss.History.Register("position", network.InterpolateVector)
+	boss.SetPosition(Vec{0, 0})
 	boss.SetHealthy(100)
 	boss.RegisterNetworkProp()
 	boss.NetworkComponent.OnNetworkRemove = boss.Remove
 	return
 }
 
+func (b *Boss) SetPosition(v Vec) {
+	b.Position = v
+	b.SetValue("position", v)
+}
+
+// RewoundPosition will return the position at the time which shooter saw, it is current position when at is zero
+func (b *Boss) RewoundPosition(at time.Time) Vec {
+	if at.IsZero() {
+		return b.Position
+	}
+	value, ok := b.RewoundValueAt(at, "position")
+	if position, ok2 := value.([]float64); ok && ok2 && len(position) == 2 {
+		return Vec{position[0], position[1]}
+	}
+	return b.Position
+}
+
 func (b *Boss) SetHealthy(v int) {
 	b.Healthy = v
 	b.SetValue("healthy", v)
@@ -270,6 +293,7 @@ type Bullet struct {
 	*network.NetworkComponent
 	Game      *FireGame
 	PlayerID  string
+	RewindAt  time.Time // the time which shooter saw the boss when fire, it is captured once so the rewind is not changed by ping on flying
 	Position  Vec
 	Radius    float64
 	Direct    Vec
@@ -345,7 +369,11 @@ func (b *Bullet) collision(delta float64) {
 	}
 
 	//boss
-	if !b.Game.boss.Removed && p.Sub(b.Game.boss.Position).Length() <= b.Game.boss.Radius {
+	var rewindAt time.Time
+	if !b.RewindAt.IsZero() {
+		rewindAt = b.RewindAt.Add(time.Since(b.startTime))
+	}
+	if !b.Game.boss.Removed && p.Sub(b.Game.boss.RewoundPosition(rewindAt)).Length() <= b.Game.boss.Radius {
 		b.Game.boss.Hurt(b.PlayerID, b.Power)
 		b.Remove()
 	}
@@ -367,8 +395,11 @@ type Player struct {
 	*network.NetworkComponent
 	Game         *FireGame
 	Position     Vec
-	WeaponUsing  int
-	WeaponDirect Vec
+	Name         *network.NetworkProp[string]
+	Seat         *network.NetworkProp[int]
+	WeaponUsing  *network.NetworkProp[int]
+	WeaponAngle  *network.NetworkProp[float64]
+	WeaponDirect *network.NetworkProp[Vec]
 }
 
 func NewPlayer(game *FireGame, owner, cid string) (player *Player) {
@@ -377,11 +408,11 @@ func NewPlayer(game *FireGame, owner, cid string) (player *Player) {
 		Game:             game,
 	}
 	player.Refer = player
-	player.SetName("")
-	player.SetSeat(0)
-	player.SetWeaponUsing(0)
-	player.SetWeaponAngle(0)
-	player.SetWeaponDirect(Vec{0, 1})
+	player.Name = network.NewNetworkProp(player.NetworkComponent, "name", "")
+	player.Seat = network.NewNetworkProp(player.NetworkComponent, "seat", 0)
+	player.WeaponUsing = network.NewNetworkProp(player.NetworkComponent, "weapon.using", 0)
+	player.WeaponAngle = network.NewNetworkProp(player.NetworkComponent, "weapon.angle", 0.0)
+	player.WeaponDirect = network.NewNetworkProp(player.NetworkComponent, "weapon.direct", Vec{0, 1})
 	player.RegisterNetworkProp()
 	player.RegisterNetworkTrigger("reward", player.OnReward)
 	player.RegisterNetworkCall("switch", player.OnSwitchWeapon)
@@ -391,28 +422,6 @@ func NewPlayer(game *FireGame, owner, cid string) (player *Player) {
 	return
 }
 
-func (p *Player) SetName(v string) {
-	p.SetValue("name", v)
-}
-
-func (p *Player) SetSeat(v int) {
-	p.SetValue("seat", v)
-}
-
-func (p *Player) SetWeaponUsing(v int) {
-	p.WeaponUsing = v
-	p.SetValue("weapon.using", v)
-}
-
-func (p *Player) SetWeaponAngle(v float64) {
-	p.SetValue("weapon.angle", v)
-}
-
-func (p *Player) SetWeaponDirect(v Vec) {
-	p.WeaponDirect = v
-	p.SetValue("weapon.direct", v)
-}
-
 func (p *Player) Update(delta float64) {
 
 }
@@ -421,22 +430,25 @@ func (p *Player) turnTo(arg Vec) {
 	var direct = arg.Sub(p.Position).Normalized()
 	var r = direct.AngleTo(Vec{0, 1})
 	var angle = math.Pi - r
-	p.SetWeaponAngle(angle)
-	p.SetWeaponDirect(direct)
+	p.WeaponAngle.Set(angle)
+	p.WeaponDirect.Set(direct)
 }
 
-func (p *Player) createBullet() *Bullet {
-	var b = NewBullet(p.Game, p.CID, uuid.New(), p.WeaponUsing+1)
-	var pos = p.Position.Add(p.WeaponDirect.Mul(50))
+func (p *Player) createBullet(shooter network.NetworkConnection) *Bullet {
+	var b = NewBullet(p.Game, p.CID, uuid.New(), p.WeaponUsing.Get()+1)
+	if shooter != nil {
+		b.RewindAt = network.Network.RewindTime(shooter)
+	}
+	var pos = p.Position.Add(p.WeaponDirect.Get().Mul(50))
 	b.SetPosition(pos)
-	b.SetDirect(p.WeaponDirect)
-	b.SetColor(p.Game.weaponColors[p.WeaponUsing])
+	b.SetDirect(p.WeaponDirect.Get())
+	b.SetColor(p.Game.weaponColors[p.WeaponUsing.Get()])
 	return b
 }
 
-func (p *Player) fireTo(arg Vec) {
+func (p *Player) fireTo(shooter network.NetworkConnection, arg Vec) {
 	p.turnTo(arg)
-	p.Game.AddBulllet(p.createBullet())
+	p.Game.AddBulllet(p.createBullet(shooter))
 }
 
 func (p *Player) SendReward(v float64) {
@@ -452,7 +464,7 @@ func (p *Player) OnReward(v float64) {
 }
 
 func (p *Player) OnSwitchWeapon(ctx network.NetworkSession, uuid string) (err error) {
-	p.SetWeaponUsing((p.WeaponUsing + 1) % len(p.Game.weaponColors))
+	p.WeaponUsing.Set((p.WeaponUsing.Get() + 1) % len(p.Game.weaponColors))
 	return
 }
 
@@ -462,7 +474,7 @@ func (p *Player) OnTurnTo(ctx network.NetworkSession, uuid string, arg Vec) (err
 }
 
 func (p *Player) OnFireTo(ctx network.NetworkSession, uuid string, arg Vec) (err error) {
-	p.fireTo(arg)
+	p.fireTo(network.SessionConnection(ctx), arg)
 	return
 }
 
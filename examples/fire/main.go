@@ -1,9 +1,15 @@
 package main
 
 import (
+	"os"
+
 	"github.com/centny/flame_network/examples/fire/lib"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "bot" {
+		lib.MainBot()
+		return
+	}
 	lib.Main()
 }
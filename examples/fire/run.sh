@@ -4,6 +4,9 @@ set -e
 if [ "$1" == "go" ];then
     go build -v .
     ./fire
+elif [ "$1" == "bot" ];then
+    go build -v .
+    ./fire bot
 else
     ./build-dart.sh
     ./build/server/fire.sh
@@ -3,6 +3,7 @@ library flame_network;
 import 'package:flame_network/src/common/log.dart';
 import 'package:logger/logger.dart';
 
+export 'src/network/binary.dart';
 export 'src/network/grpc.dart';
 export 'src/network/network.dart';
 export 'src/component/base.dart';
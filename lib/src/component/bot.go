@@ -0,0 +1,126 @@
+package component
+
+import (
+	"sync"
+
+	"github.com/centny/flame_network/lib/src/network"
+)
+
+// NetworkBehavior is the scripted behavior of bot which is called on each tick, the bot is stopped when it return error
+type NetworkBehavior interface {
+	UpdateBot(bot *NetworkBot, tick int64, delta float64) (err error)
+}
+
+type NetworkBehaviorF func(bot *NetworkBot, tick int64, delta float64) (err error)
+
+func (f NetworkBehaviorF) UpdateBot(bot *NetworkBot, tick int64, delta float64) (err error) {
+	return f(bot, tick, delta)
+}
+
+// NetworkReaction is called on bot tick when the trigger is received on mirrored component
+type NetworkReaction func(bot *NetworkBot, c *network.NetworkComponent, value interface{})
+
+type networkReactionItem struct {
+	Reaction  NetworkReaction
+	Component *network.NetworkComponent
+	Value     interface{}
+}
+
+// NetworkBot is the headless client which run the scripted behaviors on game loop tick, the received triggers is reacted on tick
+// before behaviors, and the authority props and triggers on owned components is published after behaviors
+type NetworkBot struct {
+	*network.NetworkClient
+	Name        string
+	Loop        *GameLoop
+	behaviorAll []NetworkBehavior
+	reactionAll map[string]map[string]NetworkReaction
+	received    []*networkReactionItem
+	lock        sync.Mutex
+}
+
+func NewNetworkBot(name string) (bot *NetworkBot) {
+	bot = NewNetworkBotByClient(name, network.NewNetworkClient(name))
+	return
+}
+
+// NewNetworkBotByClient will create the bot on client, the OnMirror of client is used by bot to register the trigger reactions
+func NewNetworkBotByClient(name string, client *network.NetworkClient) (bot *NetworkBot) {
+	bot = &NetworkBot{
+		NetworkClient: client,
+		Name:          name,
+		reactionAll:   map[string]map[string]NetworkReaction{},
+	}
+	bot.Loop = NewGameLoop(bot)
+	client.OnMirror = bot.onMirror
+	return
+}
+
+// AddBehavior will add the behavior which is called on each tick by added order
+func (b *NetworkBot) AddBehavior(behavior NetworkBehavior) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.behaviorAll = append(b.behaviorAll, behavior)
+}
+
+// OnTrigger will add the reaction to trigger on component created by factory, it must be added before component is mirrored
+func (b *NetworkBot) OnTrigger(factory, name string, reaction NetworkReaction) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	reactionAll := b.reactionAll[factory]
+	if reactionAll == nil {
+		reactionAll = map[string]NetworkReaction{}
+		b.reactionAll[factory] = reactionAll
+	}
+	reactionAll[name] = reaction
+}
+
+func (b *NetworkBot) onMirror(c *network.NetworkComponent) {
+	b.lock.Lock()
+	reactionAll := b.reactionAll[c.Factory]
+	b.lock.Unlock()
+	for name, reaction := range reactionAll {
+		reaction := reaction
+		err := c.RegisterNetworkTrigger(name, func(value interface{}) {
+			b.lock.Lock()
+			defer b.lock.Unlock()
+			b.received = append(b.received, &networkReactionItem{Reaction: reaction, Component: c, Value: value})
+		})
+		if err != nil {
+			network.Warnf("NetworkBot(%v) register trigger %v on %v error %v", b.Name, name, c.CID, err)
+		}
+	}
+}
+
+func (b *NetworkBot) Update(delta float64) {
+}
+
+// UpdateTick will react the received triggers, call behaviors and publish the owned components
+func (b *NetworkBot) UpdateTick(tick int64, delta float64) (err error) {
+	b.lock.Lock()
+	received, behaviorAll := b.received, b.behaviorAll
+	b.received = nil
+	b.lock.Unlock()
+	for _, item := range received {
+		item.Reaction(b, item.Component, item.Value)
+	}
+	for _, behavior := range behaviorAll {
+		if err = behavior.UpdateBot(b, tick, delta); err != nil {
+			return
+		}
+	}
+	b.Sync()
+	return
+}
+
+// Run will run the loop until Stop is called or behavior return error
+func (b *NetworkBot) Run() (err error) {
+	err = b.Loop.Loop()
+	return
+}
+
+// Stop will stop the loop and close the client
+func (b *NetworkBot) Stop() (err error) {
+	b.Loop.Close()
+	err = b.Close()
+	return
+}
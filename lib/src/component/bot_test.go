@@ -0,0 +1,95 @@
+package component
+
+import (
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/centny/flame_network/lib/src/network"
+)
+
+func TestNetworkBot(t *testing.T) {
+	server := network.NewNetworkContext()
+	server.Network.IsServer = true
+	server.Network.MinSync = 0
+	transport := network.NewNetworkTransportGRPCByContext(server)
+	transport.GrpcAddress, _ = url.Parse("grpc://127.0.0.1:50091")
+	transport.WebAddress, _ = url.Parse("ws://127.0.0.1:50092")
+	server.SetTransport(transport)
+	sc := network.NewNetworkComponentByContext(server, "test", "g1", "", "c1")
+	sc.SetValue("x", 0)
+	sc.RegisterNetworkProp()
+	sc.RegisterNetworkTrigger("hit", func(v int) {})
+	network.RegisterCall(sc, "join", func(ctx network.NetworkSession, uuid string, name string) (result string, err error) {
+		ctx.SetGroup("g1")
+		ctx.SetUser(name)
+		result = "OK"
+		return
+	})
+	network.RegisterCall(sc, "add", func(ctx network.NetworkSession, uuid string, v int) (r int, err error) {
+		r = sc.IntDef(0, "x") + v
+		sc.SetValue("x", r)
+		sc.NetworkTrigger("hit", r)
+		server.Network.Sync("g1", nil)
+		return
+	})
+	if err := server.Network.Start(); err != nil {
+		t.Error(err)
+		return
+	}
+	defer server.Network.Stop()
+
+	bot := NewNetworkBot("bot")
+	bot.Loop.FPS = 100
+	hit := make(chan int, 8)
+	bot.OnTrigger("test", "hit", func(bot *NetworkBot, c *network.NetworkComponent, value interface{}) {
+		hit <- int(value.(float64))
+	})
+	bot.OnTrigger("test", "hit", func(bot *NetworkBot, c *network.NetworkComponent, value interface{}) {
+		hit <- int(value.(float64))
+	})
+	bot.AddBehavior(NetworkBehaviorF(func(bot *NetworkBot, tick int64, delta float64) (err error) {
+		if tick%10 != 1 {
+			return
+		}
+		_, err = network.Call[int, int](bot.Remote("c1"), "add", 1)
+		return
+	}))
+	bot.AddBehavior(NetworkBehaviorF(func(bot *NetworkBot, tick int64, delta float64) (err error) {
+		if x := bot.Remote("c1").IntDef(0, "x"); x >= 3 {
+			err = fmt.Errorf("done")
+		}
+		return
+	}))
+	if err := bot.Connect("grpc://127.0.0.1:50091"); err != nil {
+		t.Error(err)
+		return
+	}
+	if _, err := network.Call[string, string](bot.Remote("c1"), "join", "bot"); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := bot.Join("g1", "bot"); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := bot.Run(); err == nil || err.Error() != "done" {
+		t.Error(err)
+		return
+	}
+	if len(hit) < 2 {
+		t.Errorf("%v", len(hit))
+		return
+	}
+	if v := <-hit; v != 1 {
+		t.Errorf("%v", v)
+		return
+	}
+	bot.Update(0)
+	bot.Stop()
+
+	sc.RegisterNetworkTrigger("dup", func(v int) {})
+	bot = NewNetworkBotByClient("dup", network.NewNetworkClient("dup"))
+	bot.OnTrigger("test", "dup", nil)
+	bot.onMirror(sc)
+}
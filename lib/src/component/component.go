@@ -1,11 +1,14 @@
 package component
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/centny/flame_network/lib/src/network"
 )
 
+// NetworkSequencedValue is the prop value with sequence, the stale value received from remote is skipped, it must be declared on
+// component before the prop is received, otherwise the received value is not decoded to it
 type NetworkSequencedValue struct {
 	Component *network.NetworkComponent
 	Name      string
@@ -38,8 +41,26 @@ func (n *NetworkSequencedValue) MarshalJSON() (data []byte, err error) {
 	return
 }
 
+// UnmarshalJSON will decode the [sequence,value] received from remote, the value is decoded to type of current value
+// and the stale value which sequence is not greater than current is skipped
 func (n *NetworkSequencedValue) UnmarshalJSON(data []byte) (err error) {
-	err = fmt.Errorf("NetworkSequencedValue UnmarshalJSON not supported(only work for server mode)")
+	var raw []json.RawMessage
+	if err = json.Unmarshal(data, &raw); err != nil {
+		return
+	}
+	if len(raw) != 2 {
+		err = fmt.Errorf("NetworkSequencedValue UnmarshalJSON invalid data %v", string(data))
+		return
+	}
+	var sequence int64
+	if err = json.Unmarshal(raw[0], &sequence); err != nil {
+		return
+	}
+	if sequence <= n.sequence {
+		return
+	}
+	n.sequence = sequence
+	n.value = network.DecodePropValue(n.value, string(raw[1]))
 	return
 }
 
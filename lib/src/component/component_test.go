@@ -40,6 +40,35 @@ func TestNetworkSequencedValue(t *testing.T) {
 		return
 	}
 
+	err = val.UnmarshalJSON([]byte("[1]"))
+	if err == nil {
+		t.Error(err)
+		return
+	}
+	err = val.UnmarshalJSON([]byte("[5,10]"))
+	if err != nil || val.Sequence() != 5 || val.Get() != 10 {
+		t.Errorf("%v,%v", err, val)
+		return
+	}
+	err = val.UnmarshalJSON([]byte("[3,20]"))
+	if err != nil || val.Sequence() != 5 || val.Get() != 10 {
+		t.Errorf("%v,%v", err, val)
+		return
+	}
+
+	//received by component is decoded on copy
+	c.RecvNetworkProp(map[string]interface{}{"a": "[7,30]"})
+	received, ok := c.Value("a").(*NetworkSequencedValue)
+	if !ok || received == val || received.Sequence() != 7 || received.Get() != 30 || val.Sequence() != 5 {
+		t.Errorf("%v", c.Value("a"))
+		return
+	}
+	c.RecvNetworkProp(map[string]interface{}{"a": "[6,40]"})
+	if received, ok = c.Value("a").(*NetworkSequencedValue); !ok || received.Sequence() != 7 || received.Get() != 30 {
+		t.Errorf("%v", c.Value("a"))
+		return
+	}
+
 	val2 := NewNetworkSequencedValue(c, "b", TestNetworkValueNotAccess(0))
 	if val2.Access(network.NewDefaultNetworkSessionBySafeM()) {
 		t.Error("error")
@@ -1,7 +1,15 @@
 package component
 
 import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"strconv"
+	"sync"
+	"sync/atomic"
 	"time"
+
+	"github.com/codingeasygo/util/xdebug"
 )
 
 type LoopUpdater interface {
@@ -14,40 +22,152 @@ func (f LoopUpdaterF) Update(delta float64) {
 	f(delta)
 }
 
+// LoopTickUpdater is the updater which receive the tick number, the loop is stopped and the error is returned by Loop when update return error
+type LoopTickUpdater interface {
+	UpdateTick(tick int64, delta float64) (err error)
+}
+
+type LoopTickUpdaterF func(tick int64, delta float64) (err error)
+
+func (f LoopTickUpdaterF) Update(delta float64) {
+}
+
+func (f LoopTickUpdaterF) UpdateTick(tick int64, delta float64) (err error) {
+	return f(tick, delta)
+}
+
 type GameLoop struct {
-	FPS     int
-	Updater LoopUpdater
-	exiter  chan int
+	FPS        int
+	Fixed      bool // if update by fixed delta 1/FPS, the elapsed time is accumulated and consumed by fixed steps
+	MaxCatchUp int  // the max fixed steps on one frame, the remaining elapsed time is dropped when it is reached
+	Updater    LoopUpdater
+	tick       int64
+	running    bool
+	routine    uint64 // the goroutine id which running loop, the close on it is called in update and is not waited
+	exiter     chan int
+	done       chan int
+	lock       sync.Mutex
 }
 
 func NewGameLoop(updater LoopUpdater) (loop *GameLoop) {
 	loop = &GameLoop{
-		FPS:     30,
-		Updater: updater,
-		exiter:  make(chan int, 8),
+		FPS:        30,
+		MaxCatchUp: 5,
+		Updater:    updater,
+		exiter:     make(chan int, 8),
 	}
 	return
 }
 
+// Tick will return the number of last update, it is start by 1
+func (p *GameLoop) Tick() int64 {
+	return atomic.LoadInt64(&p.tick)
+}
+
+func (p *GameLoop) update(delta float64) (err error) {
+	defer func() {
+		if perr := recover(); perr != nil {
+			err = fmt.Errorf("update panic with %v, callstack is \n%v", perr, xdebug.CallStack())
+		}
+	}()
+	tick := atomic.AddInt64(&p.tick, 1)
+	if updater, ok := p.Updater.(LoopTickUpdater); ok {
+		err = updater.UpdateTick(tick, delta)
+	} else {
+		p.Updater.Update(delta)
+	}
+	return
+}
+
+// Loop will update by FPS until Close is called or update return error
 func (p *GameLoop) Loop() (err error) {
+	if p.FPS < 1 {
+		err = fmt.Errorf("fps %v is invalid", p.FPS)
+		return
+	}
+	p.lock.Lock()
+	if p.running {
+		p.lock.Unlock()
+		err = fmt.Errorf("loop is running")
+		return
+	}
+	for len(p.exiter) > 0 { //the stale close signal
+		<-p.exiter
+	}
+	p.running = true
+	p.routine = goroutineID()
+	p.done = make(chan int)
+	p.lock.Unlock()
+	defer func() {
+		p.lock.Lock()
+		p.running = false
+		close(p.done)
+		p.lock.Unlock()
+	}()
 	interval := time.Second / time.Duration(p.FPS)
-	timeStart := time.Now().UnixNano()
+	step := interval.Seconds()
+	timeStart := time.Now()
+	accumulator := 0.0
 	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
 	for {
 		select {
 		case <-ticker.C:
-			now := time.Now().UnixNano()
-			// DT in ms
-			delta := float64(now-timeStart) / 1000000000
+			now := time.Now()
+			// DT in seconds
+			delta := now.Sub(timeStart).Seconds()
 			timeStart = now
-			p.Updater.Update(delta)
+			if !p.Fixed {
+				err = p.update(delta)
+				if err != nil {
+					return
+				}
+				continue
+			}
+			accumulator += delta
+			for steps := 0; accumulator >= step; steps++ {
+				if p.MaxCatchUp > 0 && steps >= p.MaxCatchUp {
+					accumulator = 0
+					break
+				}
+				err = p.update(step)
+				if err != nil {
+					return
+				}
+				accumulator -= step
+			}
 		case <-p.exiter:
-			ticker.Stop()
+			return
 		}
 	}
 }
 
+// Close will stop the loop and wait it is returned, it is nothing to do when loop is not started, and it is not waited
+// when called in update on loop goroutine, like the bot is stopped by behavior
 func (p *GameLoop) Close() (err error) {
-	p.exiter <- 1
+	p.lock.Lock()
+	running, done, routine := p.running, p.done, p.routine
+	p.lock.Unlock()
+	if !running {
+		return
+	}
+	select {
+	case p.exiter <- 1:
+	default:
+	}
+	if routine != goroutineID() {
+		<-done
+	}
+	return
+}
+
+// goroutineID will return the id of current goroutine by parsing the stack header "goroutine N [...]"
+func goroutineID() (id uint64) {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+	buf = bytes.TrimPrefix(buf, []byte("goroutine "))
+	if i := bytes.IndexByte(buf, ' '); i > 0 {
+		id, _ = strconv.ParseUint(string(buf[:i]), 10, 64)
+	}
 	return
 }
@@ -1,13 +1,117 @@
 package component
 
-import "testing"
+import (
+	"fmt"
+	"testing"
+	"time"
+)
 
 func TestGameLoop(t *testing.T) {
 	waiter := make(chan int, 8)
 	loop := NewGameLoop(LoopUpdaterF(func(delta float64) {
 		waiter <- 1
 	}))
-	go loop.Loop()
+	exited := make(chan error, 1)
+	go func() {
+		exited <- loop.Loop()
+	}()
 	<-waiter
-	loop.Close()
+	if err := loop.Close(); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := <-exited; err != nil {
+		t.Error(err)
+		return
+	}
+	//close before loop is started is not kept
+	if err := loop.Close(); err != nil {
+		t.Error(err)
+		return
+	}
+	//close from other goroutine in update is waited
+	updating, updated := make(chan int), make(chan int)
+	loop.Updater = LoopUpdaterF(func(delta float64) {
+		select {
+		case updating <- 1:
+			<-updated
+		default:
+		}
+	})
+	go func() {
+		exited <- loop.Loop()
+	}()
+	<-updating
+	closed := make(chan error, 1)
+	go func() {
+		closed <- loop.Close()
+	}()
+	select {
+	case <-closed:
+		t.Error("not waited")
+		return
+	case <-time.After(50 * time.Millisecond):
+	}
+	updated <- 1
+	if err := <-closed; err != nil || len(exited) != 1 {
+		t.Error(err)
+		return
+	}
+	if err := <-exited; err != nil {
+		t.Error(err)
+		return
+	}
+	//close in update
+	loop.Updater = LoopUpdaterF(func(delta float64) {
+		loop.Close()
+	})
+	if err := loop.Loop(); err != nil {
+		t.Error(err)
+		return
+	}
+	loop.FPS = 0
+	if err := loop.Loop(); err == nil {
+		t.Error("error")
+		return
+	}
+}
+
+func TestGameLoopFixed(t *testing.T) {
+	ticks := make(chan int64, 1024)
+	loop := NewGameLoop(LoopTickUpdaterF(func(tick int64, delta float64) (err error) {
+		if delta != 0.01 {
+			err = fmt.Errorf("delta %v", delta)
+			return
+		}
+		if tick == 1 {
+			time.Sleep(100 * time.Millisecond) //block to catch up
+		}
+		ticks <- tick
+		if tick == 20 {
+			err = fmt.Errorf("done")
+		}
+		return
+	}))
+	loop.FPS = 100
+	loop.Fixed = true
+	loop.MaxCatchUp = 3
+	err := loop.Loop()
+	if err == nil || err.Error() != "done" || loop.Tick() != 20 {
+		t.Error(err)
+		return
+	}
+	for i := int64(1); i <= 20; i++ {
+		if tick := <-ticks; tick != i {
+			t.Errorf("tick %v", tick)
+			return
+		}
+	}
+	//panic
+	loop.Updater = LoopUpdaterF(func(delta float64) {
+		panic("error")
+	})
+	if err := loop.Loop(); err == nil {
+		t.Error("error")
+		return
+	}
 }
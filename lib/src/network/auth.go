@@ -0,0 +1,146 @@
+package network
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/codingeasygo/util/xmap"
+)
+
+// NetworkTokenMeta is the session meta key of token which is sent to server by grpc metadata
+const NetworkTokenMeta = "token"
+
+// NetworkIdentity is the authoritative identity of session returned by Authenticator
+type NetworkIdentity struct {
+	Key    string // the session key, empty is client key scoped by user
+	User   string
+	Group  string // empty is not changed
+	Claims xmap.M
+}
+
+// SessionKey return the authoritative session key, the client key is scoped by user so client can't claim session of other user
+func (n *NetworkIdentity) SessionKey(clientKey string) string {
+	if len(n.Key) > 0 {
+		return n.Key
+	}
+	if len(n.User) > 0 && !strings.HasPrefix(clientKey, n.User+"/") {
+		return n.User + "/" + clientKey
+	}
+	return clientKey
+}
+
+// Apply will update the session user, group and claims by identity
+func (n *NetworkIdentity) Apply(session NetworkSession) {
+	session.SetUser(n.User)
+	if len(n.Group) > 0 {
+		session.SetGroup(n.Group)
+	}
+	if n.Claims != nil {
+		session.SetValue("claims", n.Claims)
+	}
+}
+
+// Authenticator will validate the token in session meta on server and return the authoritative identity, it is called on ping, sync and call.
+// the error is returned to client as grpc status, codes.Unauthenticated is used when error is not grpc status
+type Authenticator interface {
+	Authenticate(session NetworkSession) (identity *NetworkIdentity, err error)
+}
+
+// NetworkTokenAuthenticator is the Authenticator by JWT style token signed by HMAC-SHA256,
+// the user, group, session key and expire is passed by sub, grp, key and exp claims
+type NetworkTokenAuthenticator struct {
+	Secret []byte
+	Now    func() time.Time
+}
+
+func NewNetworkTokenAuthenticator(secret []byte) (auth *NetworkTokenAuthenticator) {
+	auth = &NetworkTokenAuthenticator{
+		Secret: secret,
+		Now:    time.Now,
+	}
+	return
+}
+
+func (n *NetworkTokenAuthenticator) sign(content string) string {
+	mac := hmac.New(sha256.New, n.Secret)
+	mac.Write([]byte(content))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// Sign will create the token by claims
+func (n *NetworkTokenAuthenticator) Sign(claims xmap.M) (token string, err error) {
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return
+	}
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+	content := header + "." + base64.RawURLEncoding.EncodeToString(payload)
+	token = content + "." + n.sign(content)
+	return
+}
+
+// SignUser will create the token for user in group which is expired after expire, zero expire is never expired
+func (n *NetworkTokenAuthenticator) SignUser(user, group string, expire time.Duration) (token string, err error) {
+	claims := xmap.M{"sub": user}
+	if len(group) > 0 {
+		claims["grp"] = group
+	}
+	if expire > 0 {
+		claims["exp"] = n.Now().Add(expire).Unix()
+	}
+	token, err = n.Sign(claims)
+	return
+}
+
+// Verify will check the token signature and expire, and return the claims
+func (n *NetworkTokenAuthenticator) Verify(token string) (claims xmap.M, err error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		err = fmt.Errorf("token format is invalid")
+		return
+	}
+	if !hmac.Equal([]byte(parts[2]), []byte(n.sign(parts[0]+"."+parts[1]))) {
+		err = fmt.Errorf("token signature is invalid")
+		return
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		err = fmt.Errorf("token payload is invalid by %v", err)
+		return
+	}
+	claims = xmap.M{}
+	err = json.Unmarshal(payload, &claims)
+	if err != nil {
+		err = fmt.Errorf("token payload is invalid by %v", err)
+		return
+	}
+	if claims.Exist("exp") && n.Now().Unix() >= claims.Int64("exp") {
+		err = fmt.Errorf("token is expired")
+		return
+	}
+	return
+}
+
+func (n *NetworkTokenAuthenticator) Authenticate(session NetworkSession) (identity *NetworkIdentity, err error) {
+	token := session.Meta().StrDef("", NetworkTokenMeta)
+	if len(token) < 1 {
+		err = fmt.Errorf("token is required")
+		return
+	}
+	claims, err := n.Verify(token)
+	if err != nil {
+		return
+	}
+	identity = &NetworkIdentity{
+		Key:    claims.StrDef("", "key"),
+		User:   claims.StrDef("", "sub"),
+		Group:  claims.StrDef("", "grp"),
+		Claims: claims,
+	}
+	return
+}
@@ -0,0 +1,234 @@
+package network
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codingeasygo/util/xmap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestTokenAuthenticator(t *testing.T) {
+	auth := NewNetworkTokenAuthenticator([]byte("123"))
+	token, err := auth.SignUser("u1", "g1", time.Hour)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	session := NewDefaultNetworkSessionBySafeM()
+	session.SetKey("k1")
+	session.Meta().SetValue(NetworkTokenMeta, token)
+	identity, err := auth.Authenticate(session)
+	if err != nil || identity.User != "u1" || identity.Group != "g1" || identity.SessionKey("k1") != "u1/k1" {
+		t.Errorf("%v,%v", err, identity)
+		return
+	}
+	identity.Apply(session)
+	if session.User() != "u1" || session.Group() != "g1" || session.Value("claims") == nil {
+		t.Error("error")
+		return
+	}
+	//session key
+	token, _ = auth.Sign(xmap.M{"sub": "u1", "key": "s1"})
+	session.Meta().SetValue(NetworkTokenMeta, token)
+	if identity, err = auth.Authenticate(session); err != nil || identity.SessionKey("k1") != "s1" {
+		t.Errorf("%v,%v", err, identity)
+		return
+	}
+	if (&NetworkIdentity{}).SessionKey("k1") != "k1" {
+		t.Error("error")
+		return
+	}
+	//error
+	expired, _ := auth.Sign(xmap.M{"sub": "u1", "exp": time.Now().Add(-time.Second).Unix()})
+	never, _ := auth.SignUser("u1", "", 0)
+	other, _ := NewNetworkTokenAuthenticator([]byte("abc")).SignUser("u1", "", 0)
+	if _, err := auth.Verify(never); err != nil {
+		t.Error(err)
+		return
+	}
+	for _, token := range []string{"", "abc", "a.b.c", expired, other, token[:len(token)-2]} {
+		session.Meta().SetValue(NetworkTokenMeta, token)
+		if _, err := auth.Authenticate(session); err == nil {
+			t.Error(token)
+			return
+		}
+	}
+	bad := "a.b"
+	bad += "." + auth.sign(bad)
+	if _, err := auth.Verify(bad); err == nil {
+		t.Error("error")
+		return
+	}
+	bad = "a.YWJj"
+	bad += "." + auth.sign(bad)
+	if _, err := auth.Verify(bad); err == nil {
+		t.Error("error")
+		return
+	}
+	if _, err := auth.Sign(xmap.M{"x": func() {}}); err == nil {
+		t.Error("error")
+		return
+	}
+}
+
+func TestAuthenticatorGRPC(t *testing.T) {
+	auth := NewNetworkTokenAuthenticator([]byte("123"))
+	server := NewNetworkContext()
+	server.Network.IsServer = true
+	server.Network.Authenticator = auth
+	transport := NewNetworkTransportGRPCByContext(server)
+	transport.GrpcAddress, _ = url.Parse("grpc://127.0.0.1:50081")
+	transport.WebAddress, _ = url.Parse("ws://127.0.0.1:50082")
+	server.SetTransport(transport)
+	sc := NewNetworkComponentByContext(server, "test", "", "", "c1")
+	sc.SetValue("x", 1)
+	sc.RegisterNetworkProp()
+	sc.RegisterNetworkCall("whoami", func(ctx NetworkSession, uuid string, v int) (r string, err error) {
+		r = ctx.User() + "," + ctx.Key()
+		return
+	})
+	sc.RegisterNetworkCall("group", func(ctx NetworkSession, uuid string, group string) (r string, err error) {
+		if len(group) > 0 {
+			ctx.SetGroup(group)
+		}
+		r = ctx.Group()
+		return
+	})
+	if err := server.Network.Start(); err != nil {
+		t.Error(err)
+		return
+	}
+	defer server.Network.Stop()
+	for _, streamOn := range []bool{true, false} {
+		client := NewNetworkContext()
+		client.Network.IsClient = true
+		client.Network.SetKey("k1")
+		client.Network.SetUser("u2") //not trusted
+		clientTransport := NewNetworkTransportGRPCByContext(client)
+		clientTransport.StreamOn = streamOn
+		clientTransport.GrpcAddress, _ = url.Parse("grpc://127.0.0.1:50081")
+		client.SetTransport(clientTransport)
+		if err := client.Network.Start(); err != nil {
+			t.Error(err)
+			return
+		}
+		//unauthenticated
+		if _, _, err := clientTransport.Client.Ping(); status.Code(err) != codes.Unauthenticated {
+			t.Errorf("%v,%v", streamOn, err)
+			return
+		}
+		if _, err := client.Network.NetworkCall(&NetworkCallArg{CID: "c1", Name: "whoami", Arg: "1"}); status.Code(err) != codes.Unauthenticated {
+			t.Errorf("%v,%v", streamOn, err)
+			return
+		}
+		if err := client.Network.Ready(); status.Code(err) != codes.Unauthenticated {
+			t.Errorf("%v,%v", streamOn, err)
+			return
+		}
+		client.Network.Stop()
+
+		//authenticated
+		token, _ := auth.SignUser("u1", "", time.Hour)
+		client.Network.SetToken(token)
+		if err := client.Network.Start(); err != nil {
+			t.Error(err)
+			return
+		}
+		if _, _, err := clientTransport.Client.Ping(); err != nil {
+			t.Errorf("%v,%v", streamOn, err)
+			return
+		}
+		key := client.Network.Key()
+		if !strings.HasPrefix(key, "u1/") || key == "u1/k1" {
+			t.Errorf("%v,%v", streamOn, key)
+			return
+		}
+		ret, err := client.Network.NetworkCall(&NetworkCallArg{CID: "c1", Name: "whoami", Arg: "1"})
+		if err != nil || ret.Result != `"u1,`+key+`"` {
+			t.Errorf("%v,%v,%v", streamOn, err, ret)
+			return
+		}
+		if err := client.Network.Ready(); err != nil {
+			t.Errorf("%v,%v", streamOn, err)
+			return
+		}
+		ret, err = client.Network.NetworkCall(&NetworkCallArg{CID: "c1", Name: "whoami", Arg: "1"})
+		if err != nil || ret.Result != `"u1,`+key+`"` {
+			t.Errorf("%v,%v,%v", streamOn, err, ret)
+			return
+		}
+		client.Network.Stop()
+
+		//other user can't claim the session by key
+		token, _ = auth.SignUser("u2", "", time.Hour)
+		client.Network.SetToken(token)
+		client.Network.SetKey(key)
+		if err := client.Network.Start(); err != nil {
+			t.Error(err)
+			return
+		}
+		if having := client.Network.Key(); !strings.HasPrefix(having, "u2/") || strings.Contains(having, key) {
+			t.Errorf("%v,%v", streamOn, having)
+			return
+		}
+		client.Network.Stop()
+
+		//group claim is applied only when session is created
+		token, _ = auth.SignUser("u3", "g1", time.Hour)
+		client.Network.SetToken(token)
+		client.Network.SetKey("")
+		if err := client.Network.Start(); err != nil {
+			t.Error(err)
+			return
+		}
+		for _, group := range [][2]string{{"", "g1"}, {"room", "room"}, {"", "room"}} {
+			if _, _, err := clientTransport.Client.Ping(); err != nil {
+				t.Errorf("%v,%v", streamOn, err)
+				return
+			}
+			ret, err := client.Network.NetworkCall(&NetworkCallArg{CID: "c1", Name: "group", Arg: `"` + group[0] + `"`})
+			if err != nil || ret.Result != `"`+group[1]+`"` {
+				t.Errorf("%v,%v,%v", streamOn, err, ret)
+				return
+			}
+		}
+		client.Network.Stop()
+	}
+}
+
+func TestAuthenticatorLoopback(t *testing.T) {
+	auth := NewNetworkTokenAuthenticator([]byte("123"))
+	loopback := NewNetworkLoopback()
+	server := NewNetworkContext()
+	server.Network.IsServer = true
+	server.Network.Authenticator = auth
+	server.SetTransport(NewNetworkTransportLoopbackByContext(server, loopback))
+	if err := server.Network.Start(); err != nil {
+		t.Error(err)
+		return
+	}
+	defer server.Network.Stop()
+	client := NewNetworkContext()
+	client.Network.IsClient = true
+	client.SetTransport(NewNetworkTransportLoopbackByContext(client, loopback))
+	if err := client.Network.Start(); err == nil {
+		t.Error("error")
+		return
+	}
+	token, _ := auth.SignUser("u1", "g1", time.Hour)
+	client.Network.SetToken(token)
+	if err := client.Network.Start(); err != nil {
+		t.Error(err)
+		return
+	}
+	defer client.Network.Stop()
+	session := client.Transport().(*NetworkTransportLoopback).Conn().peer.Session()
+	if session.User() != "u1" || session.Group() != "g1" {
+		t.Error("error")
+		return
+	}
+}
@@ -0,0 +1,148 @@
+import 'dart:convert';
+import 'dart:typed_data';
+
+const String syncEncodingJSON = "json";
+const String syncEncodingBinary = "binary";
+
+const int _binaryTagNull = 0;
+const int _binaryTagFalse = 1;
+const int _binaryTagTrue = 2;
+const int _binaryTagInt = 3;
+const int _binaryTagFloat = 4;
+const int _binaryTagString = 5;
+const int _binaryTagVector = 6;
+const int _binaryTagList = 7;
+const int _binaryTagMap = 8;
+
+class _BinaryReader {
+  final Uint8List data;
+  final ByteData view;
+  int pos = 0;
+
+  _BinaryReader(this.data) : view = ByteData.sublistView(data);
+
+  int readByte() {
+    if (pos >= data.length) {
+      throw const FormatException("unexpected end of binary data");
+    }
+    return data[pos++];
+  }
+
+  int readUvarint() {
+    var value = 0;
+    var shift = 0;
+    while (true) {
+      var b = readByte();
+      value |= (b & 0x7f) << shift;
+      if (b < 0x80) {
+        return value;
+      }
+      shift += 7;
+      if (shift > 63) {
+        throw FormatException("invalid uvarint at $pos");
+      }
+    }
+  }
+
+  int readVarint() {
+    var value = readUvarint();
+    return (value >>> 1) ^ -(value & 1);
+  }
+
+  int readLength() {
+    var length = readUvarint();
+    if (length < 0 || length > data.length - pos) {
+      throw FormatException("invalid length $length at $pos");
+    }
+    return length;
+  }
+
+  double readFloat() {
+    if (pos + 4 > data.length) {
+      throw const FormatException("unexpected end of binary data");
+    }
+    var value = view.getFloat32(pos, Endian.little);
+    pos += 4;
+    return value;
+  }
+
+  String readString() {
+    var length = readLength();
+    var value = utf8.decode(data.sublist(pos, pos + length));
+    pos += length;
+    return value;
+  }
+
+  dynamic readValue() {
+    var tag = readByte();
+    switch (tag) {
+      case _binaryTagNull:
+        return null;
+      case _binaryTagFalse:
+        return false;
+      case _binaryTagTrue:
+        return true;
+      case _binaryTagInt:
+        return readVarint();
+      case _binaryTagFloat:
+        return readFloat();
+      case _binaryTagString:
+        return readString();
+      case _binaryTagVector:
+        var length = readLength();
+        return List<dynamic>.generate(length, (_) => readFloat());
+      case _binaryTagList:
+        var length = readLength();
+        return List<dynamic>.generate(length, (_) => readValue());
+      case _binaryTagMap:
+        var length = readLength();
+        Map<String, dynamic> value = {};
+        for (var i = 0; i < length; i++) {
+          var key = readString();
+          value[key] = readValue();
+        }
+        return value;
+      default:
+        throw FormatException("invalid tag $tag at ${pos - 1}");
+    }
+  }
+}
+
+/// NetworkBinaryDecoder is the binary decoder for one sync stream, the property key is interned per component factory,
+/// so it must receive all data from server by order
+class NetworkBinaryDecoder {
+  final Map<String, List<String>> _keyAll = {};
+
+  String _readKey(_BinaryReader reader, String factory) {
+    var idx = reader.readUvarint();
+    var keys = _keyAll.putIfAbsent(factory, () => []);
+    if (idx == 0) {
+      var key = reader.readString();
+      keys.add(key);
+      return key;
+    }
+    if (idx > keys.length) {
+      throw FormatException("key index $idx not exists on $factory");
+    }
+    return keys[idx - 1];
+  }
+
+  /// decode binary to props and triggers, the value is same as jsonDecode result
+  (Map<String, dynamic>, Map<String, List<dynamic>>) decodeComponent(String factory, List<int> data) {
+    var reader = _BinaryReader(data is Uint8List ? data : Uint8List.fromList(data));
+    Map<String, dynamic> props = {};
+    Map<String, List<dynamic>> triggers = {};
+    var propCount = reader.readLength();
+    for (var i = 0; i < propCount; i++) {
+      var key = _readKey(reader, factory);
+      props[key] = reader.readValue();
+    }
+    var triggerCount = reader.readLength();
+    for (var i = 0; i < triggerCount; i++) {
+      var key = _readKey(reader, factory);
+      var valCount = reader.readLength();
+      triggers[key] = List<dynamic>.generate(valCount, (_) => reader.readValue());
+    }
+    return (props, triggers);
+  }
+}
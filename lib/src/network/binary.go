@@ -0,0 +1,512 @@
+package network
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/codingeasygo/util/xmap"
+)
+
+const (
+	SyncEncodingJSON   = "json"
+	SyncEncodingBinary = "binary"
+)
+
+const (
+	binaryTagNull byte = iota
+	binaryTagFalse
+	binaryTagTrue
+	binaryTagInt
+	binaryTagFloat
+	binaryTagString
+	binaryTagVector
+	binaryTagList
+	binaryTagMap
+)
+
+// NormalizeBinaryValue will convert value to the basic type which can be encoded by binary, it is
+// nil/bool/int64/float64/string/[]float64/[]interface{}/map[string]interface{}
+func NormalizeBinaryValue(v interface{}) (value interface{}, err error) {
+	switch v := v.(type) {
+	case nil:
+		value = nil
+	case bool, string, int64, float64, []float64:
+		value = v
+	case int:
+		value = int64(v)
+	case int8:
+		value = int64(v)
+	case int16:
+		value = int64(v)
+	case int32:
+		value = int64(v)
+	case uint:
+		value = int64(v)
+	case uint8:
+		value = int64(v)
+	case uint16:
+		value = int64(v)
+	case uint32:
+		value = int64(v)
+	case uint64:
+		value = int64(v)
+	case float32:
+		value = float64(v)
+	case json.Number:
+		value, err = normalizeBinaryNumber(v)
+	case json.Marshaler:
+		if isFloatList(reflect.TypeOf(v)) {
+			value, err = normalizeBinaryReflect(reflect.ValueOf(v))
+		} else {
+			value, err = normalizeBinaryJSON(v)
+		}
+	default:
+		value, err = normalizeBinaryReflect(reflect.ValueOf(v))
+	}
+	return
+}
+
+func normalizeBinaryNumber(v json.Number) (value interface{}, err error) {
+	if strings.ContainsAny(string(v), ".eE") {
+		value, err = v.Float64()
+	} else {
+		value, err = v.Int64()
+	}
+	return
+}
+
+func normalizeBinaryJSON(v interface{}) (value interface{}, err error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+	decoder := json.NewDecoder(bytes.NewBuffer(data))
+	decoder.UseNumber()
+	var raw interface{}
+	err = decoder.Decode(&raw)
+	if err == nil {
+		value, err = normalizeBinaryGeneric(raw)
+	}
+	return
+}
+
+func normalizeBinaryGeneric(v interface{}) (value interface{}, err error) {
+	switch v := v.(type) {
+	case []interface{}:
+		vals := []interface{}{}
+		floats := []float64{}
+		for _, item := range v {
+			val, xerr := normalizeBinaryGeneric(item)
+			if xerr != nil {
+				err = xerr
+				return
+			}
+			if f, ok := val.(float64); ok && floats != nil {
+				floats = append(floats, f)
+			} else {
+				floats = nil
+			}
+			vals = append(vals, val)
+		}
+		if len(floats) > 0 {
+			value = floats
+		} else {
+			value = vals
+		}
+	case map[string]interface{}:
+		vals := map[string]interface{}{}
+		for k, item := range v {
+			vals[k], err = normalizeBinaryGeneric(item)
+			if err != nil {
+				return
+			}
+		}
+		value = vals
+	default:
+		value, err = NormalizeBinaryValue(v)
+	}
+	return
+}
+
+func isFloatList(t reflect.Type) bool {
+	if t.Kind() != reflect.Slice && t.Kind() != reflect.Array {
+		return false
+	}
+	k := t.Elem().Kind()
+	return k == reflect.Float32 || k == reflect.Float64
+}
+
+func normalizeBinaryReflect(v reflect.Value) (value interface{}, err error) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		value = v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		value = int64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		value = v.Float()
+	case reflect.Bool:
+		value = v.Bool()
+	case reflect.String:
+		value = v.String()
+	case reflect.Slice, reflect.Array:
+		if isFloatList(v.Type()) {
+			floats := []float64{}
+			for i := 0; i < v.Len(); i++ {
+				floats = append(floats, v.Index(i).Float())
+			}
+			value = floats
+		} else {
+			value, err = normalizeBinaryJSON(v.Interface())
+		}
+	default:
+		value, err = normalizeBinaryJSON(v.Interface())
+	}
+	return
+}
+
+func appendBinaryString(buf []byte, v string) []byte {
+	buf = binary.AppendUvarint(buf, uint64(len(v)))
+	return append(buf, v...)
+}
+
+func appendBinaryFloat(buf []byte, v float64) []byte {
+	return binary.LittleEndian.AppendUint32(buf, math.Float32bits(float32(v)))
+}
+
+// AppendBinaryValue will append the normalized value to buf
+func AppendBinaryValue(buf []byte, v interface{}) (res []byte, err error) {
+	value, err := NormalizeBinaryValue(v)
+	if err != nil {
+		return
+	}
+	switch value := value.(type) {
+	case nil:
+		buf = append(buf, binaryTagNull)
+	case bool:
+		if value {
+			buf = append(buf, binaryTagTrue)
+		} else {
+			buf = append(buf, binaryTagFalse)
+		}
+	case int64:
+		buf = append(buf, binaryTagInt)
+		buf = binary.AppendVarint(buf, value)
+	case float64:
+		buf = append(buf, binaryTagFloat)
+		buf = appendBinaryFloat(buf, value)
+	case string:
+		buf = append(buf, binaryTagString)
+		buf = appendBinaryString(buf, value)
+	case []float64:
+		buf = append(buf, binaryTagVector)
+		buf = binary.AppendUvarint(buf, uint64(len(value)))
+		for _, f := range value {
+			buf = appendBinaryFloat(buf, f)
+		}
+	case []interface{}:
+		buf = append(buf, binaryTagList)
+		buf = binary.AppendUvarint(buf, uint64(len(value)))
+		for _, item := range value {
+			buf, err = AppendBinaryValue(buf, item)
+			if err != nil {
+				return
+			}
+		}
+	case map[string]interface{}:
+		keys := []string{}
+		for k := range value {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		buf = append(buf, binaryTagMap)
+		buf = binary.AppendUvarint(buf, uint64(len(keys)))
+		for _, k := range keys {
+			buf = appendBinaryString(buf, k)
+			buf, err = AppendBinaryValue(buf, value[k])
+			if err != nil {
+				return
+			}
+		}
+	default:
+		err = fmt.Errorf("not supported type %v", reflect.TypeOf(value))
+		return
+	}
+	res = buf
+	return
+}
+
+type binaryReader struct {
+	data []byte
+	pos  int
+}
+
+func (r *binaryReader) readByte() (v byte, err error) {
+	if r.pos >= len(r.data) {
+		err = fmt.Errorf("unexpected end of binary data")
+		return
+	}
+	v = r.data[r.pos]
+	r.pos++
+	return
+}
+
+func (r *binaryReader) readUvarint() (v uint64, err error) {
+	v, n := binary.Uvarint(r.data[r.pos:])
+	if n <= 0 {
+		err = fmt.Errorf("invalid uvarint at %v", r.pos)
+		return
+	}
+	r.pos += n
+	return
+}
+
+func (r *binaryReader) readVarint() (v int64, err error) {
+	v, n := binary.Varint(r.data[r.pos:])
+	if n <= 0 {
+		err = fmt.Errorf("invalid varint at %v", r.pos)
+		return
+	}
+	r.pos += n
+	return
+}
+
+func (r *binaryReader) readLength() (v int, err error) {
+	l, err := r.readUvarint()
+	if err == nil && l > uint64(len(r.data)-r.pos) {
+		err = fmt.Errorf("invalid length %v at %v", l, r.pos)
+	}
+	v = int(l)
+	return
+}
+
+func (r *binaryReader) readFloat() (v float64, err error) {
+	if r.pos+4 > len(r.data) {
+		err = fmt.Errorf("unexpected end of binary data")
+		return
+	}
+	v = float64(math.Float32frombits(binary.LittleEndian.Uint32(r.data[r.pos:])))
+	r.pos += 4
+	return
+}
+
+func (r *binaryReader) readString() (v string, err error) {
+	l, err := r.readLength()
+	if err == nil {
+		v = string(r.data[r.pos : r.pos+l])
+		r.pos += l
+	}
+	return
+}
+
+func (r *binaryReader) readValue() (v interface{}, err error) {
+	tag, err := r.readByte()
+	if err != nil {
+		return
+	}
+	switch tag {
+	case binaryTagNull:
+		v = nil
+	case binaryTagFalse:
+		v = false
+	case binaryTagTrue:
+		v = true
+	case binaryTagInt:
+		v, err = r.readVarint()
+	case binaryTagFloat:
+		v, err = r.readFloat()
+	case binaryTagString:
+		v, err = r.readString()
+	case binaryTagVector:
+		l, xerr := r.readLength()
+		if xerr != nil {
+			err = xerr
+			return
+		}
+		vals := []interface{}{}
+		for i := 0; i < l && err == nil; i++ {
+			var f float64
+			f, err = r.readFloat()
+			vals = append(vals, f)
+		}
+		v = vals
+	case binaryTagList:
+		l, xerr := r.readLength()
+		if xerr != nil {
+			err = xerr
+			return
+		}
+		vals := []interface{}{}
+		for i := 0; i < l && err == nil; i++ {
+			var item interface{}
+			item, err = r.readValue()
+			vals = append(vals, item)
+		}
+		v = vals
+	case binaryTagMap:
+		l, xerr := r.readLength()
+		if xerr != nil {
+			err = xerr
+			return
+		}
+		vals := map[string]interface{}{}
+		for i := 0; i < l && err == nil; i++ {
+			var key string
+			key, err = r.readString()
+			if err == nil {
+				vals[key], err = r.readValue()
+			}
+		}
+		v = vals
+	default:
+		err = fmt.Errorf("invalid tag %v at %v", tag, r.pos-1)
+	}
+	return
+}
+
+// NetworkBinaryEncoder is the binary encoder for one sync stream, the property key is interned per component factory,
+// the first time key is sent by 0+string and next time only by index+1
+type NetworkBinaryEncoder struct {
+	keyAll map[string]map[string]uint64
+}
+
+func NewNetworkBinaryEncoder() (encoder *NetworkBinaryEncoder) {
+	encoder = &NetworkBinaryEncoder{
+		keyAll: map[string]map[string]uint64{},
+	}
+	return
+}
+
+func (n *NetworkBinaryEncoder) appendKey(buf []byte, keys map[string]uint64, pending map[string]uint64, key string) []byte {
+	if idx, ok := keys[key]; ok {
+		return binary.AppendUvarint(buf, idx+1)
+	}
+	if idx, ok := pending[key]; ok {
+		return binary.AppendUvarint(buf, idx+1)
+	}
+	pending[key] = uint64(len(keys) + len(pending))
+	buf = binary.AppendUvarint(buf, 0)
+	return appendBinaryString(buf, key)
+}
+
+// EncodeComponent will encode component props and triggers to binary, the key table is only updated when encode success
+func (n *NetworkBinaryEncoder) EncodeComponent(c *NetworkSyncDataComponent) (data []byte, err error) {
+	keys := n.keyAll[c.Factory]
+	if keys == nil {
+		keys = map[string]uint64{}
+	}
+	pending := map[string]uint64{}
+	propKeys := sortedKeys(c.Props)
+	data = binary.AppendUvarint(data, uint64(len(propKeys)))
+	for _, k := range propKeys {
+		data = n.appendKey(data, keys, pending, k)
+		data, err = AppendBinaryValue(data, c.Props[k])
+		if err != nil {
+			err = fmt.Errorf("encode prop %v error %v", k, err)
+			return
+		}
+	}
+	triggerKeys := sortedKeys(c.Triggers)
+	data = binary.AppendUvarint(data, uint64(len(triggerKeys)))
+	for _, k := range triggerKeys {
+		vals, ok := c.Triggers[k].([]interface{})
+		if !ok {
+			err = fmt.Errorf("encode trigger %v error not list", k)
+			return
+		}
+		data = n.appendKey(data, keys, pending, k)
+		data = binary.AppendUvarint(data, uint64(len(vals)))
+		for _, v := range vals {
+			data, err = AppendBinaryValue(data, v)
+			if err != nil {
+				err = fmt.Errorf("encode trigger %v error %v", k, err)
+				return
+			}
+		}
+	}
+	for k, idx := range pending {
+		keys[k] = idx
+	}
+	n.keyAll[c.Factory] = keys
+	return
+}
+
+func sortedKeys(m xmap.M) (keys []string) {
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return
+}
+
+// NetworkBinaryDecoder is the binary decoder for one sync stream, it must receive all data from NetworkBinaryEncoder by order
+type NetworkBinaryDecoder struct {
+	keyAll map[string][]string
+}
+
+func NewNetworkBinaryDecoder() (decoder *NetworkBinaryDecoder) {
+	decoder = &NetworkBinaryDecoder{
+		keyAll: map[string][]string{},
+	}
+	return
+}
+
+func (n *NetworkBinaryDecoder) readKey(r *binaryReader, factory string) (key string, err error) {
+	idx, err := r.readUvarint()
+	if err != nil {
+		return
+	}
+	if idx == 0 {
+		key, err = r.readString()
+		if err == nil {
+			n.keyAll[factory] = append(n.keyAll[factory], key)
+		}
+		return
+	}
+	keys := n.keyAll[factory]
+	if idx > uint64(len(keys)) {
+		err = fmt.Errorf("key index %v not exists on %v", idx, factory)
+		return
+	}
+	key = keys[idx-1]
+	return
+}
+
+// DecodeComponent will decode binary to props and triggers, the value is nil/bool/int64/float64/string/[]interface{}/map[string]interface{}
+func (n *NetworkBinaryDecoder) DecodeComponent(factory string, data []byte) (props, triggers xmap.M, err error) {
+	r := &binaryReader{data: data}
+	props, triggers = xmap.M{}, xmap.M{}
+	propCount, err := r.readLength()
+	for i := 0; i < propCount && err == nil; i++ {
+		var key string
+		key, err = n.readKey(r, factory)
+		if err == nil {
+			props[key], err = r.readValue()
+		}
+	}
+	if err != nil {
+		return
+	}
+	triggerCount, err := r.readLength()
+	for i := 0; i < triggerCount && err == nil; i++ {
+		var key string
+		var valCount int
+		key, err = n.readKey(r, factory)
+		if err == nil {
+			valCount, err = r.readLength()
+		}
+		vals := []interface{}{}
+		for j := 0; j < valCount && err == nil; j++ {
+			var val interface{}
+			val, err = r.readValue()
+			vals = append(vals, val)
+		}
+		triggers[key] = vals
+	}
+	return
+}
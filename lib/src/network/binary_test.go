@@ -0,0 +1,157 @@
+package network
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/codingeasygo/util/converter"
+	"github.com/codingeasygo/util/xdebug"
+	"github.com/codingeasygo/util/xmap"
+)
+
+type TestBinaryVec [2]float64
+
+func (v TestBinaryVec) MarshalJSON() (data []byte, err error) {
+	data = []byte(fmt.Sprintf("[%.02f,%.02f]", v[0], v[1]))
+	return
+}
+
+type TestBinaryStruct struct {
+	Name  string `json:"name"`
+	Value int    `json:"value"`
+}
+
+type TestBinaryErrValue struct {
+}
+
+func (t *TestBinaryErrValue) MarshalJSON() (data []byte, err error) {
+	err = fmt.Errorf("error")
+	return
+}
+
+func TestBinary(t *testing.T) {
+	tester := xdebug.CaseTester{
+		0: 1,
+	}
+	if tester.Run() { //value
+		values := []interface{}{
+			nil, true, false, 0, -1, 1, int8(-8), int16(-16), int32(-32), int64(-64),
+			uint(1), uint8(8), uint16(16), uint32(32), uint64(64), float32(1.5), 1.25, "abc",
+			[]float64{1, 2}, []float32{1, 2}, [2]float64{1, 2}, TestBinaryVec{1, 2}, []int{1, 2},
+			[]interface{}{1, "a", 1.5}, map[string]interface{}{"a": 1, "b": []interface{}{}},
+			&TestBinaryStruct{Name: "a", Value: 1}, json.Number("1"), json.Number("1.5"),
+		}
+		for _, v := range values {
+			data, err := AppendBinaryValue(nil, v)
+			if err != nil {
+				t.Errorf("%v,%v", v, err)
+				return
+			}
+			r := &binaryReader{data: data}
+			val, err := r.readValue()
+			if err != nil || r.pos != len(data) {
+				t.Errorf("%v,%v", v, err)
+				return
+			}
+			var except, having interface{}
+			json.Unmarshal([]byte(converter.JSON(v)), &except)
+			json.Unmarshal([]byte(converter.JSON(val)), &having)
+			if !reflect.DeepEqual(except, having) {
+				t.Errorf("%v,%v", converter.JSON(v), converter.JSON(val))
+				return
+			}
+		}
+		data, _ := AppendBinaryValue(nil, TestBinaryVec{1, 2})
+		if data[0] != binaryTagVector || len(data) != 10 {
+			t.Errorf("%v", data)
+			return
+		}
+		data, _ = AppendBinaryValue(nil, 300)
+		if data[0] != binaryTagInt || len(data) != 3 {
+			t.Errorf("%v", data)
+			return
+		}
+		if _, err := AppendBinaryValue(nil, &TestBinaryErrValue{}); err == nil {
+			t.Error("error")
+			return
+		}
+		if _, err := AppendBinaryValue(nil, func() {}); err == nil {
+			t.Error("error")
+			return
+		}
+		if _, err := AppendBinaryValue(nil, []interface{}{func() {}}); err == nil {
+			t.Error("error")
+			return
+		}
+		if _, err := AppendBinaryValue(nil, map[string]interface{}{"a": func() {}}); err == nil {
+			t.Error("error")
+			return
+		}
+		for _, data := range [][]byte{{}, {100}, {binaryTagInt}, {binaryTagFloat, 1}, {binaryTagString, 10}, {binaryTagVector, 1}, {binaryTagVector}, {binaryTagList, 1}, {binaryTagList}, {binaryTagMap, 1, 1, 'a'}, {binaryTagMap}} {
+			r := &binaryReader{data: data}
+			if _, err := r.readValue(); err == nil {
+				t.Errorf("%v", data)
+				return
+			}
+		}
+	}
+	if tester.Run() { //component
+		encoder := NewNetworkBinaryEncoder()
+		decoder := NewNetworkBinaryDecoder()
+		c0 := &NetworkSyncDataComponent{
+			Factory:  "test",
+			Props:    xmap.M{"p0": 123, "p1": "abc", "p2": TestBinaryVec{1, 2}},
+			Triggers: xmap.M{"t0": []interface{}{1.5, 2.5}},
+		}
+		data0, err := encoder.EncodeComponent(c0)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		props, triggers, err := decoder.DecodeComponent("test", data0)
+		if err != nil || props.Int64("p0") != 123 || props.Str("p1") != "abc" || len(props.ArrayFloat64Def(nil, "p2")) != 2 || len(triggers.ArrayFloat64Def(nil, "t0")) != 2 {
+			t.Errorf("%v,%v,%v", err, props, triggers)
+			return
+		}
+		data1, err := encoder.EncodeComponent(c0)
+		if err != nil || len(data1) >= len(data0) {
+			t.Errorf("%v,%v,%v", err, data0, data1)
+			return
+		}
+		props, triggers, err = decoder.DecodeComponent("test", data1)
+		if err != nil || len(props) != 3 || len(triggers) != 1 {
+			t.Errorf("%v,%v,%v", err, props, triggers)
+			return
+		}
+		//key table is not changed when encode fail
+		_, err = encoder.EncodeComponent(&NetworkSyncDataComponent{Factory: "test", Props: xmap.M{"p3": 1, "p4": func() {}}})
+		if err == nil || len(encoder.keyAll["test"]) != 4 {
+			t.Errorf("%v,%v", err, encoder.keyAll)
+			return
+		}
+		_, err = encoder.EncodeComponent(&NetworkSyncDataComponent{Factory: "test", Triggers: xmap.M{"t1": 1}})
+		if err == nil {
+			t.Error(err)
+			return
+		}
+		_, err = encoder.EncodeComponent(&NetworkSyncDataComponent{Factory: "test", Triggers: xmap.M{"t1": []interface{}{func() {}}}})
+		if err == nil {
+			t.Error(err)
+			return
+		}
+		//key index not exists
+		_, _, err = NewNetworkBinaryDecoder().DecodeComponent("test", data1)
+		if err == nil {
+			t.Error(err)
+			return
+		}
+		for _, data := range [][]byte{{}, {1}, {1, 0, 1}, {0}, {0, 1}, {0, 1, 1}, {0, 1, 1, 1}} {
+			if _, _, err := decoder.DecodeComponent("test", data); err == nil {
+				t.Errorf("%v", data)
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,13 @@
+package network
+
+// RegisterCall will register the typed NetworkCall on component, the arg is decoded from json to A and the result is encoded to json
+func RegisterCall[A, R any](c *NetworkComponent, name string, call func(ctx NetworkSession, uuid string, arg A) (R, error)) (err error) {
+	err = c.RegisterNetworkCall(name, call)
+	return
+}
+
+// Call will call the NetworkCall on component by typed arg and decode the result to R
+func Call[A, R any](c *NetworkComponent, name string, arg A) (ret R, err error) {
+	err = c.NetworkCall(name, arg, &ret)
+	return
+}
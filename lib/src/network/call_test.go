@@ -0,0 +1,113 @@
+package network
+
+import (
+	"fmt"
+	"testing"
+)
+
+type TestCallSum struct {
+	A int `json:"a"`
+	B int `json:"b"`
+}
+
+func TestValidNetworkCall(t *testing.T) {
+	valid := []NetworkCall{
+		func(ctx NetworkSession, uuid string) error { return nil },
+		func(ctx NetworkSession, uuid string, arg int) error { return nil },
+		func(ctx NetworkSession, uuid string, arg int) (string, error) { return "", nil },
+		func(ctx NetworkSession, uuid string) (*TestCallSum, error) { return nil, nil },
+	}
+	for i, call := range valid {
+		if err := ValidNetworkCall(call); err != nil {
+			t.Errorf("%v,%v", i, err)
+			return
+		}
+	}
+	invalid := []NetworkCall{
+		nil,
+		"call",
+		func() error { return nil },
+		func(ctx NetworkSession) error { return nil },
+		func(ctx NetworkSession, uuid string, a, b int) error { return nil },
+		func(ctx NetworkSession, uuid string, args ...int) error { return nil },
+		func(ctx interface{}, uuid string) error { return nil },
+		func(ctx NetworkSession, uuid int) error { return nil },
+		func(ctx NetworkSession, uuid string) {},
+		func(ctx NetworkSession, uuid string) int { return 0 },
+		func(ctx NetworkSession, uuid string) (int, int, error) { return 0, 0, nil },
+		func(ctx NetworkSession, uuid string) (error, int) { return nil, 0 },
+	}
+	for i, call := range invalid {
+		if err := ValidNetworkCall(call); err == nil {
+			t.Errorf("%v", i)
+			return
+		}
+	}
+	c := NewNetworkComponentByContext(NewNetworkContext(), "test", "", "", "c0")
+	if err := c.RegisterNetworkCall("bad", func(uuid string) error { return nil }); err == nil || c.findNetworkCall("bad") != nil {
+		t.Error(err)
+		return
+	}
+}
+
+func TestCall(t *testing.T) {
+	loopback := NewNetworkLoopback()
+	server := NewNetworkContext()
+	server.Network.IsServer = true
+	server.SetTransport(NewNetworkTransportLoopbackByContext(server, loopback))
+	sc := NewNetworkComponentByContext(server, "test", "", "", "c0")
+	err := RegisterCall(sc, "sum", func(ctx NetworkSession, uuid string, arg *TestCallSum) (ret int, err error) {
+		if arg.A < 0 {
+			err = fmt.Errorf("negative")
+			return
+		}
+		ret = arg.A + arg.B
+		return
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = RegisterCall(sc, "len", func(ctx NetworkSession, uuid string, arg string) (ret TestCallSum, err error) {
+		if ctx != nil {
+			ret.A = 1
+		}
+		ret.B = len(arg)
+		return
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if err := server.Network.Start(); err != nil {
+		t.Error(err)
+		return
+	}
+	defer server.Network.Stop()
+
+	client := NewNetworkContext()
+	client.Network.IsClient = true
+	client.SetTransport(NewNetworkTransportLoopbackByContext(client, loopback))
+	if err := client.Network.Start(); err != nil {
+		t.Error(err)
+		return
+	}
+	defer client.Network.Stop()
+	cc := NewNetworkComponentByContext(client, "test", "", "", "c0")
+	if sum, err := Call[*TestCallSum, int](cc, "sum", &TestCallSum{A: 1, B: 2}); err != nil || sum != 3 {
+		t.Errorf("%v,%v", err, sum)
+		return
+	}
+	if _, err := Call[*TestCallSum, int](cc, "sum", &TestCallSum{A: -1}); err == nil || err.Error() != "negative" {
+		t.Error(err)
+		return
+	}
+	if ret, err := Call[string, TestCallSum](cc, "len", "abc"); err != nil || ret.A != 1 || ret.B != 3 {
+		t.Errorf("%v,%v", err, ret)
+		return
+	}
+	if _, err := Call[int, int](cc, "none", 1); err == nil {
+		t.Error(err)
+		return
+	}
+}
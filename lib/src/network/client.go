@@ -0,0 +1,200 @@
+package network
+
+import (
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// NetworkMirrorFactory will create the local object which mirrors the component synced from server, the props and triggers
+// can be declared on c before the synced props is received
+type NetworkMirrorFactory func(c *NetworkComponent) (mirror interface{}, err error)
+
+// NetworkClientConn is the client connection on transport to ping server
+type NetworkClientConn interface {
+	NetworkConnection
+	NetworkPingConnection
+	Ping() (speed time.Duration, serverTime time.Time, err error)
+	Connected() int
+}
+
+// NetworkClientTransport is the transport used by NetworkClient, it is NetworkTransportGRPC on grpc and NetworkTransportWS on websocket
+type NetworkClientTransport interface {
+	NetworkTransport
+	ClientConn() NetworkClientConn // the current client connection, it is nil when transport is not started
+}
+
+// NetworkClient is the headless client to connect to server by grpc or websocket, join the group and mirror the synced
+// components into local objects by factory, it is used by bot, load tester and test client
+type NetworkClient struct {
+	Context   *NetworkContext
+	Transport NetworkClientTransport    // it is NetworkTransportGRPC by default and replaced by scheme of address on Connect
+	OnMirror  func(c *NetworkComponent) // called when component is mirrored and before synced props is received
+	mirrorAll map[string]NetworkMirrorFactory
+	mirrorLck sync.RWMutex
+}
+
+// NewNetworkClient will create the client on new context, so multiple clients can running in one process
+func NewNetworkClient(key string) (client *NetworkClient) {
+	client = NewNetworkClientByContext(NewNetworkContext(), key)
+	return
+}
+
+// NewNetworkClientByContext will create the client on context, the key is the session key to resume the session on server
+func NewNetworkClientByContext(ctx *NetworkContext, key string) (client *NetworkClient) {
+	client = &NetworkClient{
+		Context:   ctx,
+		Transport: NewNetworkTransportGRPCByContext(ctx),
+		mirrorAll: map[string]NetworkMirrorFactory{},
+		mirrorLck: sync.RWMutex{},
+	}
+	ctx.Network.IsClient = true
+	ctx.Network.SetKey(key)
+	ctx.SetTransport(client.Transport)
+	client.registerMirror()
+	return
+}
+
+// registerMirror will register the factory to mirror all component, it is registered again on Connect because it is removed on Close
+func (n *NetworkClient) registerMirror() {
+	hub := n.Context.ComponentHub
+	hub.UnregisterFactory("*", "")
+	hub.RegisterFactory("*", "", n.createMirror)
+}
+
+// Mirror will register the factory to mirror the component created by factory key on server into local object of T,
+// the object is set to component Refer, the component without registered factory is mirrored as NetworkComponent only
+func Mirror[T any](client *NetworkClient, factory string, creator func(c *NetworkComponent) (T, error)) {
+	client.RegisterMirror(factory, func(c *NetworkComponent) (mirror interface{}, err error) {
+		mirror, err = creator(c)
+		return
+	})
+}
+
+// Mirrored will return all local objects of T which is mirrored
+func Mirrored[T any](client *NetworkClient) (mirrored []T) {
+	for _, c := range client.Context.ComponentHub.ListGroupComponent("*") {
+		if mirror, ok := c.Refer.(T); ok && !c.Removed {
+			mirrored = append(mirrored, mirror)
+		}
+	}
+	return
+}
+
+// FindMirrored will return the local object of T by cid
+func FindMirrored[T any](client *NetworkClient, cid string) (mirror T, ok bool) {
+	if c := client.Context.ComponentHub.FindComponent(cid); c != nil {
+		mirror, ok = c.Refer.(T)
+	}
+	return
+}
+
+func (n *NetworkClient) RegisterMirror(factory string, creator NetworkMirrorFactory) {
+	n.mirrorLck.Lock()
+	defer n.mirrorLck.Unlock()
+	n.mirrorAll[factory] = creator
+}
+
+func (n *NetworkClient) UnregisterMirror(factory string) {
+	n.mirrorLck.Lock()
+	defer n.mirrorLck.Unlock()
+	delete(n.mirrorAll, factory)
+}
+
+func (n *NetworkClient) createMirror(key, group, owner, cid string) (c *NetworkComponent, err error) {
+	n.mirrorLck.RLock()
+	creator := n.mirrorAll[key]
+	n.mirrorLck.RUnlock()
+	c = NewNetworkComponentByContext(n.Context, key, group, owner, cid)
+	if creator != nil {
+		c.Refer, err = creator(c)
+		if err != nil {
+			return
+		}
+	}
+	if n.OnMirror != nil {
+		n.OnMirror(c)
+	}
+	return
+}
+
+// Connect will connect to server by address, it is NetworkTransportWS when scheme is ws or wss, otherwise is NetworkTransportGRPC,
+// the client can be connected again after Close
+func (n *NetworkClient) Connect(address string) (err error) {
+	u, err := url.Parse(address)
+	if err != nil {
+		return
+	}
+	switch u.Scheme {
+	case "ws", "wss":
+		transport, ok := n.Transport.(*NetworkTransportWS)
+		if !ok {
+			transport = NewNetworkTransportWSByContext(n.Context)
+			n.setTransport(transport)
+		}
+		transport.Address = u
+	case "grpc", "":
+		transport, ok := n.Transport.(*NetworkTransportGRPC)
+		if !ok {
+			transport = NewNetworkTransportGRPCByContext(n.Context)
+			n.setTransport(transport)
+		}
+		transport.GrpcOn = true
+		transport.GrpcAddress = u
+	default:
+		err = fmt.Errorf("scheme %v is not supported", u.Scheme)
+		return
+	}
+	n.registerMirror()
+	err = n.Context.Network.Start()
+	return
+}
+
+func (n *NetworkClient) setTransport(transport NetworkClientTransport) {
+	n.Transport = transport
+	n.Context.SetTransport(transport)
+}
+
+// Join will set the group and user to session and start sync, the components not in group is removed, the group of session
+// on server must be changed by server when sync is started, like the join call of room
+func (n *NetworkClient) Join(group, user string) (err error) {
+	n.Context.Network.SetGroup(group)
+	if len(user) > 0 {
+		n.Context.Network.SetUser(user)
+	}
+	if n.Context.Network.IsReady() {
+		n.Context.ComponentHub.Clear(group)
+		return
+	}
+	err = n.Context.Network.Ready()
+	return
+}
+
+// Sync will publish the updated authority props and triggers on owned components
+func (n *NetworkClient) Sync() bool {
+	return n.Context.Network.Sync(n.Context.Network.Group(), nil)
+}
+
+// Remote will return the component by cid to call NetworkCall, the not mirrored component is returned without adding to hub
+func (n *NetworkClient) Remote(cid string) (c *NetworkComponent) {
+	c = n.Context.ComponentHub.FindComponent(cid)
+	if c == nil {
+		c = NewNetworkComponentByContext(n.Context, "", n.Context.Network.Group(), "", cid)
+	}
+	return
+}
+
+// Ping will return the ping stats measured on connection
+func (n *NetworkClient) Ping() (stats NetworkPingStats) {
+	if conn := n.Transport.ClientConn(); conn != nil {
+		stats = conn.PingStats()
+	}
+	return
+}
+
+// Close will stop the client and remove all mirrored components and factories
+func (n *NetworkClient) Close() (err error) {
+	err = n.Context.Network.Stop()
+	return
+}
@@ -0,0 +1,200 @@
+package network
+
+import (
+	"net/url"
+	"testing"
+	"time"
+)
+
+type testClientMirror struct {
+	*NetworkComponent
+	X *NetworkProp[int]
+}
+
+func TestNetworkClient(t *testing.T) {
+	server := NewNetworkContext()
+	server.Network.IsServer = true
+	transport := NewNetworkTransportGRPCByContext(server)
+	transport.GrpcAddress, _ = url.Parse("grpc://127.0.0.1:50089")
+	transport.WebAddress, _ = url.Parse("ws://127.0.0.1:50090")
+	server.SetTransport(transport)
+	sc := NewNetworkComponentByContext(server, "test", "g1", "", "c1")
+	sc.SetValue("x", 1)
+	sc.RegisterNetworkProp()
+	sc.RegisterNetworkTrigger("hit", func(v int) {})
+	other := NewNetworkComponentByContext(server, "other", "g1", "", "c2")
+	other.SetValue("y", "abc")
+	other.RegisterNetworkProp()
+	game := NewNetworkComponentByContext(server, "", "", "", "game")
+	RegisterCall(game, "join", func(ctx NetworkSession, uuid string, name string) (result string, err error) {
+		ctx.SetGroup("g1")
+		ctx.SetUser(name)
+		result = "OK"
+		return
+	})
+	if err := server.Network.Start(); err != nil {
+		t.Error(err)
+		return
+	}
+	defer server.Network.Stop()
+
+	client := NewNetworkClient("client")
+	synced := make(chan int, 8)
+	hit := make(chan int, 8)
+	mirrored := make(chan string, 8)
+	client.OnMirror = func(c *NetworkComponent) { mirrored <- c.CID }
+	Mirror(client, "test", func(c *NetworkComponent) (mirror *testClientMirror, err error) {
+		mirror = &testClientMirror{NetworkComponent: c}
+		mirror.X = NewNetworkProp(c, "x", 0)
+		mirror.X.OnUpdate(func(value int) { synced <- value })
+		c.RegisterNetworkTrigger("hit", func(v int) { hit <- v })
+		return
+	})
+	if err := client.Connect("none://127.0.0.1:50089"); err == nil {
+		t.Error("error")
+		return
+	}
+	if err := client.Connect("grpc://127.0.0.1:50089"); err != nil {
+		t.Error(err)
+		return
+	}
+	defer client.Close()
+	if result, err := Call[string, string](client.Remote("game"), "join", "u1"); err != nil || result != "OK" {
+		t.Errorf("%v,%v", err, result)
+		return
+	}
+	if err := client.Join("g1", "u1"); err != nil {
+		t.Error(err)
+		return
+	}
+	for x := <-synced; x != 1; x = <-synced {
+	}
+	<-mirrored
+	<-mirrored
+	if mirror, ok := FindMirrored[*testClientMirror](client, "c1"); !ok || mirror.X.Get() != 1 || mirror.Owner != "" {
+		t.Errorf("%v,%v", mirror, ok)
+		return
+	}
+	if mirrors := Mirrored[*testClientMirror](client); len(mirrors) != 1 {
+		t.Errorf("%v", mirrors)
+		return
+	}
+	if c := client.Remote("c2"); c.Str("y") != "abc" || c.Refer != nil {
+		t.Errorf("%v", c)
+		return
+	}
+	if _, ok := FindMirrored[*testClientMirror](client, "c2"); ok {
+		t.Error("error")
+		return
+	}
+
+	time.Sleep(server.Network.MinSync)
+	sc.SetValue("x", 2)
+	sc.NetworkTrigger("hit", 3)
+	server.Network.Sync("g1", nil)
+	if x := <-synced; x != 2 {
+		t.Errorf("%v", x)
+		return
+	}
+	if v := <-hit; v != 3 {
+		t.Errorf("%v", v)
+		return
+	}
+	if err := client.Join("g1", ""); err != nil || client.Context.Network.User() != "u1" {
+		t.Error(err)
+		return
+	}
+	if client.Sync() {
+		t.Error("error")
+		return
+	}
+	client.UnregisterMirror("test")
+	client.Transport.ClientConn().Ping()
+	if stats := client.Ping(); stats.Samples < 1 {
+		t.Errorf("%v", stats)
+		return
+	}
+}
+
+func TestNetworkClientWS(t *testing.T) {
+	server := NewNetworkContext()
+	server.Network.IsServer = true
+	transport := NewNetworkTransportWSByContext(server)
+	transport.Address, _ = url.Parse("ws://127.0.0.1:50098/ws")
+	server.SetTransport(transport)
+	sc := NewNetworkComponentByContext(server, "test", "g1", "", "c1")
+	sc.SetValue("x", 1)
+	sc.RegisterNetworkProp()
+	game := NewNetworkComponentByContext(server, "", "", "", "game")
+	RegisterCall(game, "join", func(ctx NetworkSession, uuid string, name string) (result string, err error) {
+		ctx.SetGroup("g1")
+		ctx.SetUser(name)
+		result = "OK"
+		return
+	})
+	if err := server.Network.Start(); err != nil {
+		t.Error(err)
+		return
+	}
+	defer server.Network.Stop()
+
+	client := NewNetworkClient("")
+	synced := make(chan int, 8)
+	Mirror(client, "test", func(c *NetworkComponent) (mirror *testClientMirror, err error) {
+		mirror = &testClientMirror{NetworkComponent: c}
+		mirror.X = NewNetworkProp(c, "x", 0)
+		mirror.X.OnUpdate(func(value int) { synced <- value })
+		return
+	})
+	if err := client.Connect("ws://127.0.0.1:50098/ws"); err != nil {
+		t.Error(err)
+		return
+	}
+	defer client.Close()
+	if _, ok := client.Transport.(*NetworkTransportWS); !ok || client.Context.Transport() != client.Transport {
+		t.Errorf("%v", client.Transport)
+		return
+	}
+	if result, err := Call[string, string](client.Remote("game"), "join", "u1"); err != nil || result != "OK" {
+		t.Errorf("%v,%v", err, result)
+		return
+	}
+	if err := client.Join("g1", "u1"); err != nil {
+		t.Error(err)
+		return
+	}
+	for x := <-synced; x != 1; x = <-synced {
+	}
+	if _, _, err := client.Transport.ClientConn().Ping(); err != nil {
+		t.Error(err)
+		return
+	}
+	if stats := client.Ping(); stats.Samples < 1 {
+		t.Errorf("%v", stats)
+		return
+	}
+	//reuse after close
+	client.Close()
+	if mirrors := Mirrored[*testClientMirror](client); len(mirrors) != 0 {
+		t.Errorf("%v", mirrors)
+		return
+	}
+	if err := client.Connect("ws://127.0.0.1:50098/ws"); err != nil {
+		t.Error(err)
+		return
+	}
+	if result, err := Call[string, string](client.Remote("game"), "join", "u1"); err != nil || result != "OK" {
+		t.Errorf("%v,%v", err, result)
+		return
+	}
+	if err := client.Join("g1", "u1"); err != nil {
+		t.Error(err)
+		return
+	}
+	for x := <-synced; x != 1; x = <-synced {
+	}
+	if mirrors := Mirrored[*testClientMirror](client); len(mirrors) != 1 {
+		t.Errorf("%v", mirrors)
+		return
+	}
+}
@@ -51,9 +51,31 @@ extension on SyncData {
       uuid: id.uuid,
       group: group,
       whole: whole,
+      tick: tick.toInt(),
+      time: time > 0 ? DateTime.fromMillisecondsSinceEpoch(time.toInt()) : null,
       components: components.map((e) => e.wrap()).toList(),
     );
   }
+
+  NetworkSyncData decode(NetworkBinaryDecoder decoder) {
+    List<NetworkSyncDataComponent> decoded = [];
+    for (var c in components) {
+      if (c.binary.isEmpty) {
+        decoded.add(c.wrap().decode());
+        continue;
+      }
+      var (props, triggers) = decoder.decodeComponent(c.factoryType, c.binary);
+      decoded.add(NetworkSyncDataComponent(nFactory: c.factoryType, nCID: c.cid, nOwner: c.owner, nRemoved: c.removed, nProps: props, nTriggers: triggers));
+    }
+    return NetworkSyncData(
+      uuid: id.uuid,
+      group: group,
+      whole: whole,
+      tick: tick.toInt(),
+      time: time > 0 ? DateTime.fromMillisecondsSinceEpoch(time.toInt()) : null,
+      components: decoded,
+    );
+  }
 }
 
 extension on CallArg {
@@ -311,6 +333,25 @@ class NetworkServerGRPC extends ServerServiceBase {
       return CallResult(id: request.id, cid: request.cid, name: request.name, error: "$e");
     }
   }
+
+  @override
+  Future<AckResult> remoteAck(ServiceCall call, Stream<AckArg> request) async {
+    //reliable sync is not supported, the sync data is sent without sequence, so only drain the ack
+    await request.drain();
+    return AckResult();
+  }
+
+  @override
+  Stream<StreamData> remoteStream(ServiceCall call, Stream<StreamData> request) {
+    //multiplex stream is not supported, the client will fallback to unary call and remoteSync
+    throw GrpcError.unimplemented("method remoteStream not implemented");
+  }
+
+  @override
+  Future<UpdateResult> remoteUpdate(ServiceCall call, SyncData request) async {
+    //client publish is not supported, the sync data is only sent by server
+    throw GrpcError.unimplemented("method remoteUpdate not implemented");
+  }
 }
 
 class NetworkClientGRPC extends ServerClient with NetworkConnection {
@@ -318,6 +359,7 @@ class NetworkClientGRPC extends ServerClient with NetworkConnection {
   NetworkCallback mCallback;
   Duration mTimeout = const Duration(seconds: 10);
   ResponseStream<SyncData>? mMonitor;
+  NetworkBinaryDecoder mDecoder = NetworkBinaryDecoder();
   NetworkState mState = NetworkState.none;
 
   CallOptions get callOptions => CallOptions(metadata: session.meta, timeout: mTimeout);
@@ -367,6 +409,9 @@ class NetworkClientGRPC extends ServerClient with NetworkConnection {
 
   Future<int> ping(Duration timeout) async {
     var result = await super.remotePing(PingArg(id: newRequestID()), options: CallOptions(metadata: session.meta, timeout: timeout));
+    if (result.session.isNotEmpty) {
+      session.key = result.session; //the session key issued by server
+    }
     return result.connected;
   }
 
@@ -374,8 +419,7 @@ class NetworkClientGRPC extends ServerClient with NetworkConnection {
     if (NetworkManager.global.verbose) {
       L.d("[GRPC] network recv from ${conn.session.key} by\n${raw.toDebugString()}");
     }
-    var data = raw.wrap();
-    data.components = data.components.map((e) => e.decode()).toList();
+    var data = raw.decode(mDecoder);
     await onNetworkSync(conn, data);
   }
 
@@ -388,7 +432,8 @@ class NetworkClientGRPC extends ServerClient with NetworkConnection {
   }
 
   void startMonitorSync() async {
-    var request = SyncArg(id: newRequestID());
+    var request = SyncArg(id: newRequestID(), encoding: NetworkManager.global.syncEncoding);
+    mDecoder = NetworkBinaryDecoder();
     mMonitor = super.remoteSync(request, options: CallOptions(metadata: session.meta));
     mMonitor?.listen(
       (data) => _onNetworkSync(this, data),
@@ -816,3 +861,268 @@ class NetworkManagerGRPC extends NetworkManager {
     return client!.networkCall(arg);
   }
 }
+
+/// NetworkClientWS is the client of go NetworkTransportWS, the ping, call and sync is processed by StreamData frame on one websocket,
+/// the session meta is sent by first frame, so the token is not in url
+class NetworkClientWS with NetworkConnection {
+  Uri address;
+  NetworkCallback mCallback;
+  WebSocketChannel? mChannel;
+  NetworkBinaryDecoder mDecoder = NetworkBinaryDecoder();
+  NetworkState mState = NetworkState.none;
+  Duration mTimeout = const Duration(seconds: 10);
+  final Map<String, Completer<StreamData>> mWaiting = {};
+
+  @override
+  NetworkSession get session => NetworkManager.global.session;
+
+  @override
+  NetworkState get state => mState;
+
+  @override
+  bool get isClient => true;
+
+  @override
+  bool get isServer => false;
+
+  NetworkClientWS(this.address, this.mCallback);
+
+  void _setState(NetworkState state, {Object? info}) {
+    mState = state;
+    mCallback.onNetworkState(HashSet.from([this]), this, state, info: info);
+  }
+
+  Future<WebSocketChannel> connect() async {
+    if (mChannel != null) {
+      return mChannel!;
+    }
+    L.i("[WS] client start connect to $address");
+    _setState(NetworkState.connecting);
+    var channel = WebSocketChannel.connect(address);
+    mChannel = channel;
+    try {
+      await channel.ready.timeout(mTimeout);
+      var meta = session.meta.entries.map((e) => StreamMeta(key: e.key, value: e.value));
+      channel.sink.add(StreamData(meta: meta).writeToBuffer());
+    } catch (e) {
+      mChannel = null;
+      channel.sink.close();
+      _setState(NetworkState.error, info: e);
+      rethrow;
+    }
+    channel.stream.listen(
+      (frame) => _onFrame(frame),
+      onError: (e) => L.w("[WS] client connection throw error with $e"),
+      onDone: () => _onDone(channel),
+      cancelOnError: true,
+    );
+    L.i("[WS] client connection is ready");
+    _setState(NetworkState.ready);
+    return channel;
+  }
+
+  void _onFrame(dynamic frame) {
+    StreamData data;
+    if (frame is String) {
+      data = StreamData()..mergeFromProto3Json(jsonDecode(frame), ignoreUnknownFields: true);
+    } else {
+      data = StreamData.fromBuffer(frame as List<int>);
+    }
+    if (data.hasPingResult()) {
+      mWaiting.remove(data.pingResult.id.uuid)?.complete(data);
+    } else if (data.hasCallResult()) {
+      mWaiting.remove(data.callResult.id.uuid)?.complete(data);
+    } else if (data.hasSyncData()) {
+      _onNetworkSync(data.syncData);
+    }
+  }
+
+  void _onDone(WebSocketChannel channel) {
+    if (mChannel != channel) {
+      return;
+    }
+    mChannel = null;
+    for (var waiter in mWaiting.values) {
+      waiter.completeError(Exception("connection closed"));
+    }
+    mWaiting.clear();
+    L.i("[WS] client connection is closed");
+    _setState(NetworkState.closed);
+  }
+
+  Future<void> _onNetworkSync(SyncData raw) async {
+    if (NetworkManager.global.verbose) {
+      L.d("[WS] network recv from ${session.key} by\n${raw.toDebugString()}");
+    }
+    try {
+      await mCallback.onNetworkSync(this, raw.decode(mDecoder));
+    } catch (e, s) {
+      L.e("[WS] network sync throw error $e\n$s");
+    }
+  }
+
+  Future<StreamData> _request(String id, StreamData data, Duration timeout) async {
+    var channel = await connect();
+    var waiter = Completer<StreamData>();
+    mWaiting[id] = waiter;
+    try {
+      channel.sink.add(data.writeToBuffer());
+      return await waiter.future.timeout(timeout);
+    } finally {
+      mWaiting.remove(id);
+    }
+  }
+
+  Future<int> ping(Duration timeout) async {
+    var arg = PingArg(id: newRequestID(), sendTime: Int64(DateTime.now().microsecondsSinceEpoch * 1000));
+    var result = (await _request(arg.id.uuid, StreamData(ping: arg), timeout)).pingResult;
+    if (result.session.isNotEmpty) {
+      session.key = result.session; //the session key issued by server
+    }
+    return result.connected;
+  }
+
+  Future<NetworkCallResult> networkCall(NetworkCallArg arg) async {
+    var result = (await _request(arg.uuid, StreamData(call: arg.wrap()), mTimeout)).callResult;
+    if (result.error.isNotEmpty) {
+      throw Exception(result.error);
+    }
+    return result.wrap();
+  }
+
+  Future<void> startMonitorSync() async {
+    var channel = await connect();
+    mDecoder = NetworkBinaryDecoder();
+    channel.sink.add(StreamData(sync: SyncArg(id: newRequestID(), encoding: NetworkManager.global.syncEncoding)).writeToBuffer());
+  }
+
+  /// stopMonitorSync will close the connection, the sync is bound to connection on server
+  Future<void> stopMonitorSync() => shutdown();
+
+  Future<void> shutdown() async {
+    var channel = mChannel;
+    if (channel != null) {
+      await channel.sink.close();
+      _onDone(channel);
+    }
+  }
+}
+
+/// NetworkManagerWS is the client manager to go NetworkTransportWS by websocket frame without grpc, the server is not supported on dart
+class NetworkManagerWS extends NetworkManager {
+  static NetworkManagerWS? _instance;
+
+  static NetworkManagerWS get shared {
+    _instance ??= NetworkManagerWS();
+    return _instance!;
+  }
+
+  bool running = false;
+  Uri address = Uri(scheme: "ws", host: "127.0.0.1", port: 50053);
+  Timer? timer;
+  NetworkClientWS? client;
+  bool _keeping = false;
+  Duration _pingSpeed = const Duration();
+  @override
+  Duration get pingSpeed => _pingSpeed;
+
+  NetworkManagerWS();
+
+  @override
+  Future<void> onNetworkState(Set<NetworkConnection> all, NetworkConnection conn, NetworkState state, {Object? info}) async {
+    super.onNetworkState(all, conn, state);
+    L.i("[WS] connection status to $state, info is $info");
+  }
+
+  Future<void> reconnect() async {
+    if (!running) {
+      return;
+    }
+    await client?.shutdown();
+    client = NetworkClientWS(address, callback);
+    client!.mTimeout = timeout;
+    await client!.connect();
+    if (isReady) {
+      await client!.startMonitorSync();
+    }
+  }
+
+  Future<void> keep() async {
+    if (_keeping) {
+      return;
+    }
+    _keeping = true;
+    var pingOld = _pingSpeed;
+    try {
+      DateTime startTime = DateTime.now();
+      await client!.ping(keepalive);
+      _pingSpeed = DateTime.now().difference(startTime);
+    } catch (e) {
+      L.w("[WS] ping to $address throw error with $e");
+      _pingSpeed = const Duration(milliseconds: -1);
+      try {
+        await reconnect();
+      } catch (_) {}
+    }
+    try {
+      if (_pingSpeed != pingOld) {
+        onNetworkPing(client!, _pingSpeed);
+      }
+    } catch (_) {}
+    _keeping = false;
+  }
+
+  @override
+  Future<void> start() async {
+    await super.start();
+    if (running) {
+      return;
+    }
+    if (isServer) {
+      throw UnsupportedError("websocket server is not supported on dart");
+    }
+    L.i("[WS] network start by client:$isClient,address:$address");
+    running = true;
+    client = NetworkClientWS(address, callback);
+    client!.mTimeout = timeout;
+    timer = Timer.periodic(keepalive, (t) => keep());
+    await keep(); //the session key is issued by server on first ping
+  }
+
+  @override
+  Future<void> stop() async {
+    running = false;
+    isReady = false;
+    timer?.cancel();
+    L.i("[WS] connection is stopping");
+    await client?.shutdown();
+    client = null;
+    await super.stop();
+  }
+
+  @override
+  Future<void> ready() async {
+    await super.ready();
+    isReady = true;
+    await client?.startMonitorSync();
+  }
+
+  @override
+  Future<void> pause() async {
+    await super.pause();
+    isReady = false;
+    await client?.stopMonitorSync();
+  }
+
+  Future<int> ping(Duration timeout) async {
+    return client!.ping(timeout);
+  }
+
+  @override
+  Future<void> networkSync(NetworkSyncData data, {List<NetworkConnection>? excluded}) async {}
+
+  @override
+  Future<NetworkCallResult> networkCall(NetworkCallArg arg) {
+    return client!.networkCall(arg);
+  }
+}
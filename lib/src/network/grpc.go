@@ -3,14 +3,18 @@ package network
 import (
 	"bufio"
 	"context"
+	"crypto/rand"
 	"crypto/tls"
+	"encoding/hex"
 	"fmt"
+	"io"
 	"net"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/centny/flame_network/lib/src/network/grpc"
@@ -21,9 +25,11 @@ import (
 	"github.com/codingeasygo/util/xtime"
 	"golang.org/x/net/websocket"
 	ggrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 type NetworkSessionValueGRPC metadata.MD
@@ -87,41 +93,111 @@ func NewOutgoingContext(parent context.Context, session NetworkSession) (ctx con
 	return
 }
 
+func jsonEncodeProp(props xmap.M, encode func(v interface{}) string) xmap.M {
+	propAll := xmap.M{}
+	for k, v := range props {
+		propAll[k] = encode(v)
+	}
+	return propAll
+}
+
+func jsonEncodeTrigger(triggers xmap.M, encode func(v interface{}) string) xmap.M {
+	triggerAll := xmap.M{}
+	for k, vals := range triggers {
+		valAll := []interface{}{}
+		for _, v := range vals.([]interface{}) {
+			valAll = append(valAll, encode(v))
+		}
+		triggerAll[k] = valAll
+	}
+	return triggerAll
+}
+
 func ParseSyncDataGRPC(data *NetworkSyncData) (sd *grpc.SyncData) {
+	sd = ParseSyncDataByEncoderGRPC(data, nil)
+	return
+}
+
+// ParseSyncDataByEncoderGRPC will parse data to grpc.SyncData, data must be filtered when encoder is not nil, otherwise must be encoded.
+// the component is encoded to binary by encoder, and fallback to json if encoder is nil or encode fail
+func ParseSyncDataByEncoderGRPC(data *NetworkSyncData, encoder *NetworkBinaryEncoder) (sd *grpc.SyncData) {
 	sd = &grpc.SyncData{
-		Id:    &grpc.RequestID{Uuid: data.UUID},
-		Group: data.Group,
-		Whole: data.Whole,
+		Id:       &grpc.RequestID{Uuid: data.UUID},
+		Group:    data.Group,
+		Whole:    data.Whole,
+		Sequence: data.Sequence,
+		Tick:     data.Tick,
+	}
+	if !data.Time.IsZero() {
+		sd.Time = xtime.Timestamp(data.Time)
 	}
 	for _, c := range data.Components {
-		sd.Components = append(sd.Components, &grpc.SyncDataComponent{
+		component := &grpc.SyncDataComponent{
 			FactoryType: c.Factory,
 			Cid:         c.CID,
 			Owner:       c.Owner,
 			Removed:     c.Removed,
-			Props:       converter.JSON(c.Props),
-			Triggers:    converter.JSON(c.Triggers),
-		})
+		}
+		if encoder != nil {
+			binary, xerr := encoder.EncodeComponent(c)
+			if xerr == nil {
+				component.Binary = binary
+				sd.Components = append(sd.Components, component)
+				continue
+			}
+			Warnf("[GRPC] encode network component on %v/%v to binary error %v, fallback to json", c.Factory, c.CID, xerr)
+			c = &NetworkSyncDataComponent{Props: jsonEncodeProp(c.Props, JsonEncode), Triggers: jsonEncodeTrigger(c.Triggers, JsonEncode)}
+		}
+		component.Props = converter.JSON(c.Props)
+		component.Triggers = converter.JSON(c.Triggers)
+		sd.Components = append(sd.Components, component)
 	}
 	return
 }
 
 func ParseNetworkSyncDataGRPC(sd *grpc.SyncData) (data *NetworkSyncData) {
+	data = ParseNetworkSyncDataByDecoderGRPC(sd, nil)
+	return
+}
+
+// ParseNetworkSyncDataByDecoderGRPC will parse grpc.SyncData to data, the binary component is decoded by decoder
+// and the value is encoded to json string by JsonEncodeDecoded, so it is same as json component and the float precision is kept
+func ParseNetworkSyncDataByDecoderGRPC(sd *grpc.SyncData, decoder *NetworkBinaryDecoder) (data *NetworkSyncData) {
 	data = &NetworkSyncData{
-		UUID:  sd.Id.Uuid,
-		Group: sd.Group,
-		Whole: sd.Whole,
+		UUID:     sd.Id.Uuid,
+		Group:    sd.Group,
+		Whole:    sd.Whole,
+		Sequence: sd.Sequence,
+		Tick:     sd.Tick,
+	}
+	if sd.Time > 0 {
+		data.Time = xtime.TimeUnix(sd.Time)
 	}
 	for _, c := range sd.Components {
-		props, xerr := xmap.MapVal(c.Props)
-		if xerr != nil {
-			Warnf("[GRPC] parse network component props on %v/%v error %v", c.FactoryType, c.Cid, xerr)
-			continue
-		}
-		triggers, xerr := xmap.MapVal(c.Triggers)
-		if xerr != nil {
-			Warnf("[GRPC] parse network component trigger on %v/%v error %v", c.FactoryType, c.Cid, xerr)
-			continue
+		var props, triggers xmap.M
+		var xerr error
+		if len(c.Binary) > 0 {
+			if decoder == nil {
+				Warnf("[GRPC] parse network component binary on %v/%v error decoder is nil", c.FactoryType, c.Cid)
+				continue
+			}
+			props, triggers, xerr = decoder.DecodeComponent(c.FactoryType, c.Binary)
+			if xerr != nil {
+				Warnf("[GRPC] parse network component binary on %v/%v error %v", c.FactoryType, c.Cid, xerr)
+				continue
+			}
+			props, triggers = jsonEncodeProp(props, JsonEncodeDecoded), jsonEncodeTrigger(triggers, JsonEncodeDecoded)
+		} else {
+			props, xerr = xmap.MapVal(c.Props)
+			if xerr != nil {
+				Warnf("[GRPC] parse network component props on %v/%v error %v", c.FactoryType, c.Cid, xerr)
+				continue
+			}
+			triggers, xerr = xmap.MapVal(c.Triggers)
+			if xerr != nil {
+				Warnf("[GRPC] parse network component trigger on %v/%v error %v", c.FactoryType, c.Cid, xerr)
+				continue
+			}
 		}
 		data.Components = append(data.Components, &NetworkSyncDataComponent{
 			Factory:  c.FactoryType,
@@ -136,10 +212,12 @@ func ParseNetworkSyncDataGRPC(sd *grpc.SyncData) (data *NetworkSyncData) {
 }
 
 type NetworkBaseConnGRPC struct {
+	ctx      *NetworkContext
 	session  NetworkSession
 	state    NetworkState
 	isServer bool
 	isClient bool
+	ping     NetworkPingMeter
 }
 
 func (n *NetworkBaseConnGRPC) ID() string {
@@ -159,22 +237,65 @@ func (n *NetworkBaseConnGRPC) IsClient() bool {
 }
 func (n *NetworkBaseConnGRPC) NetworkSync(data *NetworkSyncData) {
 }
+func (n *NetworkBaseConnGRPC) PingStats() NetworkPingStats {
+	return n.ping.Stats()
+}
+
+// NetworkSyncSenderGRPC is the stream to send sync data, it is grpc.Server_RemoteSyncServer or sync data on grpc.Server_RemoteStreamServer
+type NetworkSyncSenderGRPC interface {
+	Send(data *grpc.SyncData) error
+	Context() context.Context
+}
 
 type NetworkSyncStreamGRPC struct {
 	*NetworkBaseConnGRPC
-	stream grpc.Server_RemoteSyncServer
-	closer chan string
+	syncID   string
+	group    string // the group which stream is added to
+	stream   NetworkSyncSenderGRPC
+	encoder  *NetworkBinaryEncoder
+	interest *NetworkInterestFilter
+	reliable *NetworkReliableTracker
+	closer   chan string
+	sending  sync.Mutex
 }
 
-func NewNetworkSyncStreamGRPC(conn *NetworkBaseConnGRPC, stream grpc.Server_RemoteSyncServer) (sync *NetworkSyncStreamGRPC) {
+func NewNetworkSyncStreamGRPC(conn *NetworkBaseConnGRPC, stream NetworkSyncSenderGRPC) (sync *NetworkSyncStreamGRPC) {
 	sync = &NetworkSyncStreamGRPC{
 		NetworkBaseConnGRPC: conn,
 		stream:              stream,
+		interest:            NewNetworkInterestFilter(),
 		closer:              make(chan string, 1),
 	}
 	return
 }
 
+func (n *NetworkSyncStreamGRPC) Encoding() string {
+	if n.encoder != nil {
+		return SyncEncodingBinary
+	}
+	return SyncEncodingJSON
+}
+
+func (n *NetworkSyncStreamGRPC) SetEncoding(encoding string) {
+	if encoding == SyncEncodingBinary {
+		n.encoder = NewNetworkBinaryEncoder()
+	} else {
+		n.encoder = nil
+	}
+}
+
+func (n *NetworkSyncStreamGRPC) Reliable() *NetworkReliableTracker {
+	return n.reliable
+}
+
+func (n *NetworkSyncStreamGRPC) SetReliable(reliable bool) {
+	if reliable {
+		n.reliable = NewNetworkReliableTracker()
+	} else {
+		n.reliable = nil
+	}
+}
+
 func (n *NetworkSyncStreamGRPC) Wait() (err error) {
 	select {
 	case <-n.stream.Context().Done():
@@ -192,12 +313,39 @@ func (n *NetworkSyncStreamGRPC) Send(data *grpc.SyncData) (err error) {
 	return
 }
 
-func (n *NetworkSyncStreamGRPC) NetworkSync(data *NetworkSyncData) {
-	sd := ParseSyncDataGRPC(data.Encode(n.session))
-	if Network.Verbose {
+// sendSyncData will encode and send data, the binary key table is depend on send order, so encode and send must be atomic
+func (n *NetworkSyncStreamGRPC) sendSyncData(data *NetworkSyncData, group string) (err error) {
+	n.sending.Lock()
+	defer n.sending.Unlock()
+	if !data.IsUpdated() && (n.reliable == nil || !n.reliable.Resendable(data.Group)) {
+		return //nothing is changed and no unacked state to resend
+	}
+	if n.reliable != nil {
+		data = n.reliable.Filter(n.ctx, n, data)
+	} else {
+		data = n.interest.Filter(n.ctx, n, data)
+	}
+	if data == nil {
+		return
+	}
+	var sd *grpc.SyncData
+	if n.encoder == nil {
+		sd = ParseSyncDataGRPC(data.Encode(n.session))
+	} else {
+		sd = ParseSyncDataByEncoderGRPC(data.Filter(n.session), n.encoder)
+	}
+	if len(group) > 0 {
+		sd.Group = group
+	}
+	if n.ctx.Network.Verbose {
 		Debugf("[GRPC] network send to %v by\n %v", n.session.Key(), converter.JSON(sd))
 	}
-	n.Send(sd)
+	err = n.Send(sd)
+	return
+}
+
+func (n *NetworkSyncStreamGRPC) NetworkSync(data *NetworkSyncData) {
+	n.sendSyncData(data, "")
 }
 
 func (n *NetworkSyncStreamGRPC) Close() (err error) {
@@ -208,40 +356,76 @@ func (n *NetworkSyncStreamGRPC) Close() (err error) {
 	return
 }
 
+// networkClosingGRPC is the session which last stream is closed and waiting resume in grace window
+type networkClosingGRPC struct {
+	stream *NetworkSyncStreamGRPC
+	timer  *time.Timer
+}
+
 type NetworkServerGRPC struct {
 	grpc.UnimplementedServerServer
+	Context    *NetworkContext
 	callback   NetworkCallback
 	connAll    map[string]map[string]*NetworkSyncStreamGRPC
 	connGroup  map[string]map[string]*NetworkSyncStreamGRPC
+	syncAll    map[string]*NetworkSyncStreamGRPC
 	sessionAll map[string]*NetworkBaseConnGRPC
+	closingAll map[string]*networkClosingGRPC
 	lock       sync.RWMutex
 }
 
 func NewNetworkServerGRPC(callback NetworkCallback) (server *NetworkServerGRPC) {
+	server = NewNetworkServerGRPCByContext(DefaultContext, callback)
+	return
+}
+
+func NewNetworkServerGRPCByContext(ctx *NetworkContext, callback NetworkCallback) (server *NetworkServerGRPC) {
 	server = &NetworkServerGRPC{
+		Context:    ctx,
 		callback:   callback,
 		connAll:    map[string]map[string]*NetworkSyncStreamGRPC{},
 		connGroup:  map[string]map[string]*NetworkSyncStreamGRPC{},
+		syncAll:    map[string]*NetworkSyncStreamGRPC{},
 		sessionAll: map[string]*NetworkBaseConnGRPC{},
+		closingAll: map[string]*networkClosingGRPC{},
 		lock:       sync.RWMutex{},
 	}
 	return
 }
 
+// NewSessionKey will create the opaque session key which is issued by server
+func NewSessionKey() string {
+	buf := make([]byte, 16)
+	rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
+
 func (n *NetworkServerGRPC) keepSession(session NetworkSession) *NetworkBaseConnGRPC {
+	return n.keepSessionByIdentity(session, nil)
+}
+
+// keepSessionByIdentity will keep session like keepSession and apply identity only when session is created or resumed after expired,
+// so the group changed by call is not reset by the claims on each ping, call and ack
+func (n *NetworkServerGRPC) keepSessionByIdentity(session NetworkSession, identity *NetworkIdentity) *NetworkBaseConnGRPC {
 	n.lock.Lock()
 	defer n.lock.Unlock()
 	sid := session.Key()
+	if len(sid) < 1 { //first contact
+		sid = NewSessionKey()
+		session.SetKey(sid)
+	}
 	having := n.sessionAll[sid]
 	if having == nil {
 		having = &NetworkBaseConnGRPC{
+			ctx:      n.Context,
 			session:  NewDefaultNetworkSessionByMeta(session.Meta()),
 			state:    NetworkStateReady,
 			isServer: true,
 			isClient: true,
 		}
-		if len(sid) > 0 {
-			n.sessionAll[sid] = having
+		n.sessionAll[sid] = having
+		if identity != nil {
+			identity.Apply(having.session)
 		}
 	}
 	having.session.SetLast(time.Now())
@@ -249,6 +433,54 @@ func (n *NetworkServerGRPC) keepSession(session NetworkSession) *NetworkBaseConn
 	return having
 }
 
+// verifySessionKey will return the client key only when it is issued by server and scoped by identity, else the new key is issued,
+// so client can't claim the session by made up key
+func (n *NetworkServerGRPC) verifySessionKey(key string, identity *NetworkIdentity) string {
+	if identity != nil && len(identity.Key) > 0 {
+		return identity.Key
+	}
+	if identity != nil && len(key) > 0 {
+		key = identity.SessionKey(key)
+	}
+	n.lock.RLock()
+	_, having := n.sessionAll[key]
+	_, closing := n.closingAll[key]
+	n.lock.RUnlock()
+	if len(key) > 0 && (having || closing) {
+		return key
+	}
+	key = NewSessionKey()
+	if identity != nil {
+		key = identity.SessionKey(key)
+	}
+	return key
+}
+
+// authenticate will create session by grpc metadata and keep it, the session key, user and group is replaced by identity when authenticator is set
+func (n *NetworkServerGRPC) authenticate(ctx context.Context) (conn *NetworkBaseConnGRPC, err error) {
+	session := NewNetworkSessionFromGRPC(ctx)
+	auth := n.Context.Network.Authenticator
+	if auth == nil {
+		session.SetKey(n.verifySessionKey(session.Key(), nil))
+		conn = n.keepSession(session)
+		return
+	}
+	identity, err := auth.Authenticate(session)
+	if err == nil && identity == nil {
+		err = fmt.Errorf("identity is nil")
+	}
+	if err != nil {
+		Warnf("[GRPC] authenticate session %v fail with %v", session.Key(), err)
+		if _, ok := status.FromError(err); !ok {
+			err = status.Error(codes.Unauthenticated, err.Error())
+		}
+		return
+	}
+	session.SetKey(n.verifySessionKey(session.Key(), identity))
+	conn = n.keepSessionByIdentity(session, identity)
+	return
+}
+
 func (n *NetworkServerGRPC) sessionTimeout(max time.Duration) []*NetworkBaseConnGRPC {
 	n.lock.RLock()
 	defer n.lock.RUnlock()
@@ -300,34 +532,107 @@ func (n *NetworkServerGRPC) groupConnCopy(group string) map[string]*NetworkSyncS
 	return connGroup
 }
 
+// addStream will add sync stream and notify ready, the ready info is "resumed" when session is resumed in grace window
 func (n *NetworkServerGRPC) addStream(stream *NetworkSyncStreamGRPC) {
+	var info interface{}
 	n.lock.Lock()
 	defer func() {
 		n.lock.Unlock()
-		n.networkState(stream, NetworkStateReady, nil)
+		n.networkState(stream, NetworkStateReady, info)
 	}()
 	sid := stream.ID()
 	session := stream.session.Key()
 	group := stream.session.Group()
+	stream.group = group
+	if closing := n.closingAll[session]; closing != nil {
+		closing.timer.Stop()
+		delete(n.closingAll, session)
+		info = "resumed"
+	}
 	n.sessionConnAll(session)[sid] = stream
 	n.groupConnAll(group)[sid] = stream
 	n.groupConnAll("*")[sid] = stream
+	if len(stream.syncID) > 0 {
+		n.syncAll[stream.syncID] = stream
+	}
 	Debugf("[GRPC] add one network sync stream on %v/%v/%v", group, stream.session.User(), session)
 }
 
+// cancleStream will remove sync stream and notify closed, the closed is notified after grace window when it is last stream of session
 func (n *NetworkServerGRPC) cancleStream(stream *NetworkSyncStreamGRPC) {
+	resumable := false
 	n.lock.Lock()
 	defer func() {
 		n.lock.Unlock()
-		n.networkState(stream, NetworkStateClosed, nil)
+		if !resumable {
+			n.networkState(stream, NetworkStateClosed, nil)
+		}
 	}()
 	sid := stream.ID()
 	session := stream.session.Key()
-	group := stream.session.Group()
+	group := stream.group
 	delete(n.sessionConnAll(session), sid)
 	delete(n.groupConnAll(group), sid)
 	delete(n.groupConnAll("*"), sid)
-	Debugf("[GRPC] remove network sync stream on %v/%v/%v", group, stream.session.User(), session)
+	if n.syncAll[stream.syncID] == stream {
+		delete(n.syncAll, stream.syncID)
+	}
+	grace := n.Context.Network.SessionGrace
+	resumable = grace > 0 && len(n.connAll[session]) < 1
+	if resumable {
+		closing := &networkClosingGRPC{stream: stream}
+		closing.timer = time.AfterFunc(grace, func() { n.closeSession(session, closing) })
+		if having := n.closingAll[session]; having != nil {
+			having.timer.Stop()
+		}
+		n.closingAll[session] = closing
+	}
+	Debugf("[GRPC] remove network sync stream on %v/%v/%v, resumable %v", group, stream.session.User(), session, resumable)
+}
+
+// regroup will move the sync streams of session to the group which is changed by call or Regroup and notify group changed,
+// so the whole data of new group is synced, the callback without NetworkGroupEvent is notified ready with info "regroup"
+func (n *NetworkServerGRPC) regroup(session NetworkSession) {
+	moved := map[*NetworkSyncStreamGRPC]string{}
+	n.lock.Lock()
+	group := session.Group()
+	for sid, stream := range n.connAll[session.Key()] {
+		if stream.group == group {
+			continue
+		}
+		delete(n.groupConnAll(stream.group), sid)
+		n.groupConnAll(group)[sid] = stream
+		moved[stream] = stream.group
+		stream.group = group
+	}
+	n.lock.Unlock()
+	for stream, from := range moved {
+		Debugf("[GRPC] move network sync stream on %v/%v from group %v to %v", stream.session.User(), session.Key(), from, group)
+		if callback, ok := n.callback.(NetworkGroupEvent); ok {
+			callback.OnNetworkGroup(stream, from, group)
+		} else {
+			n.networkState(stream, NetworkStateReady, "regroup")
+		}
+	}
+}
+
+// closeSession will notify closed when session is not resumed in grace window
+func (n *NetworkServerGRPC) closeSession(session string, closing *networkClosingGRPC) {
+	n.lock.Lock()
+	if n.closingAll[session] != closing {
+		n.lock.Unlock()
+		return
+	}
+	delete(n.closingAll, session)
+	n.lock.Unlock()
+	Debugf("[GRPC] network session %v is not resumed in grace window", session)
+	n.networkState(closing.stream, NetworkStateClosed, nil)
+}
+
+func (n *NetworkServerGRPC) findStream(syncID string) *NetworkSyncStreamGRPC {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+	return n.syncAll[syncID]
 }
 
 func (n *NetworkServerGRPC) countStream(session NetworkSession) (c int) {
@@ -341,11 +646,19 @@ func (n *NetworkServerGRPC) networkState(conn NetworkConnection, state NetworkSt
 	n.callback.OnNetworkState(n.sessionConnCopy(conn.Session().Key()), conn, state, info)
 }
 
+// timeout will close the streams of expired session, the expired session without stream is notified closed by info "expired"
 func (n *NetworkServerGRPC) timeout(max time.Duration) {
 	for _, s := range n.sessionTimeout(max) {
-		for _, c := range n.sessionConnCopy(s.session.Key()) {
+		connAll := n.sessionConnCopy(s.session.Key())
+		for _, c := range connAll {
 			c.(*NetworkSyncStreamGRPC).Close()
 		}
+		n.lock.RLock()
+		closing := n.closingAll[s.session.Key()]
+		n.lock.RUnlock()
+		if len(connAll) < 1 && closing == nil {
+			n.networkState(s, NetworkStateClosed, "expired")
+		}
 	}
 }
 
@@ -364,12 +677,7 @@ func (n *NetworkServerGRPC) NetworkSync(data *NetworkSyncData, excluded []Networ
 		if isExcluded(c) {
 			continue
 		}
-		sd := ParseSyncDataGRPC(data.Encode(c.session))
-		sd.Group = c.session.Group()
-		if Network.Verbose {
-			Debugf("[GRPC] network send to %v by\n %v", c.session.Key(), converter.JSON(sd))
-		}
-		c.Send(sd)
+		c.sendSyncData(data, c.session.Group())
 	}
 }
 
@@ -381,18 +689,25 @@ func (n *NetworkServerGRPC) Close() (err error) {
 }
 
 func (n *NetworkServerGRPC) RemoteCall(ctx context.Context, arg *grpc.CallArg) (result *grpc.CallResult, err error) {
-	session := NewNetworkSessionFromGRPC(ctx)
-	if Network.Verbose {
-		Debugf("[GRPC] network call from %v by\n %v", session.Key(), converter.JSON(arg))
+	conn, err := n.authenticate(ctx)
+	if err != nil {
+		return
+	}
+	result = n.remoteCall(conn, arg)
+	return
+}
+
+func (n *NetworkServerGRPC) remoteCall(conn *NetworkBaseConnGRPC, arg *grpc.CallArg) (result *grpc.CallResult) {
+	if n.Context.Network.Verbose {
+		Debugf("[GRPC] network call from %v by\n %v", conn.session.Key(), converter.JSON(arg))
 	}
-	conn := n.keepSession(session)
 	ret, xerr := n.callback.OnNetworkCall(conn, &NetworkCallArg{
 		UUID: arg.Id.Uuid,
 		CID:  arg.Cid,
 		Name: arg.Name,
 		Arg:  arg.Arg,
 	})
-
+	n.regroup(conn.session)
 	if xerr != nil {
 		result = &grpc.CallResult{
 			Id:    arg.Id,
@@ -412,54 +727,252 @@ func (n *NetworkServerGRPC) RemoteCall(ctx context.Context, arg *grpc.CallArg) (
 }
 
 func (n *NetworkServerGRPC) RemotePing(ctx context.Context, arg *grpc.PingArg) (result *grpc.PingResult, err error) {
-	session := NewNetworkSessionFromGRPC(ctx)
-	conn := n.keepSession(session)
-	connected := n.countStream(session)
-	n.callback.OnNetworkPing(conn, 0)
+	conn, err := n.authenticate(ctx)
+	if err != nil {
+		return
+	}
+	result = n.remotePing(conn, arg)
+	return
+}
+
+func (n *NetworkServerGRPC) remotePing(conn *NetworkBaseConnGRPC, arg *grpc.PingArg) (result *grpc.PingResult) {
+	recvTime := time.Now()
+	conn.session.SetLast(recvTime)
+	if arg.SendTime > 0 && arg.LastSendTime > 0 && arg.LastRecvTime > 0 {
+		//the server is local and client is remote on echoed last ping
+		conn.ping.Add(pingTimeUnix(arg.LastSendTime), pingTimeUnix(arg.LastRecvTime), pingTimeUnix(arg.SendTime), recvTime)
+	}
+	connected := n.countStream(conn.session)
+	n.callback.OnNetworkPing(conn, conn.ping.Stats().RTT)
 	result = &grpc.PingResult{
 		Id:         arg.Id,
 		ServerTime: xtime.Now(),
 		Connected:  int32(connected),
+		Session:    conn.session.Key(),
+		RecvTime:   recvTime.UnixNano(),
+		SendTime:   time.Now().UnixNano(),
 	}
 	return
 }
 
 func (n *NetworkServerGRPC) RemoteSync(arg *grpc.SyncArg, stream grpc.Server_RemoteSyncServer) (err error) {
-	session := NewNetworkSessionFromGRPC(stream.Context())
-	conn := n.keepSession(session)
+	conn, err := n.authenticate(stream.Context())
+	if err != nil {
+		return
+	}
+	err = stream.SendHeader(metadata.MD{}) //the client wait header to check sync is accepted
+	if err != nil {
+		return
+	}
 	sync := NewNetworkSyncStreamGRPC(conn, stream)
+	sync.syncID = arg.Id.GetUuid()
+	sync.SetEncoding(arg.Encoding)
+	sync.SetReliable(arg.Reliable)
 	n.addStream(sync)
 	defer n.cancleStream(sync)
 	err = sync.Wait()
 	return
 }
 
+// RemoteUpdate will receive the sync data published by client on unary call, it is used when stream is not supported
+func (n *NetworkServerGRPC) RemoteUpdate(ctx context.Context, sd *grpc.SyncData) (result *grpc.UpdateResult, err error) {
+	base, err := n.authenticate(ctx)
+	if err != nil {
+		return
+	}
+	var conn NetworkConnection = base
+	for _, c := range n.sessionConnCopy(base.session.Key()) {
+		conn = c //the sync stream of session is excluded when rebroadcast
+		break
+	}
+	n.publish(conn, sd)
+	result = &grpc.UpdateResult{Id: sd.Id}
+	return
+}
+
+func (n *NetworkServerGRPC) publish(conn NetworkConnection, sd *grpc.SyncData) {
+	if n.Context.Network.Verbose {
+		Debugf("[GRPC] network publish from %v by\n %v", conn.Session().Key(), converter.JSON(sd))
+	}
+	if callback, ok := n.callback.(NetworkPublishCallback); ok {
+		callback.OnNetworkPublish(conn, ParseNetworkSyncDataGRPC(sd))
+	} else {
+		Warnf("[GRPC] network publish from %v is dropped by callback not supported", conn.Session().Key())
+	}
+}
+
+// RemoteAck will receive the sequence of sync data which is received by client, the sync stream is found by sync id
+func (n *NetworkServerGRPC) RemoteAck(stream grpc.Server_RemoteAckServer) (err error) {
+	conn, err := n.authenticate(stream.Context())
+	if err != nil {
+		return
+	}
+	for {
+		ack, xerr := stream.Recv()
+		if xerr == io.EOF {
+			err = stream.SendAndClose(&grpc.AckResult{})
+			break
+		}
+		if xerr != nil {
+			err = xerr
+			break
+		}
+		sync := n.findStream(ack.Id.GetUuid())
+		if sync == nil || sync.reliable == nil || sync.session.Key() != conn.session.Key() {
+			continue //only the session owned the sync stream can ack it
+		}
+		sync.reliable.Ack(ack.Sequence)
+	}
+	return
+}
+
+// networkStreamSenderGRPC will send all data on bidi stream, the call result, ping result and sync data is sent by multi goroutine, so send must be locked
+type networkStreamSenderGRPC struct {
+	stream  grpc.Server_RemoteStreamServer
+	sending sync.Mutex
+}
+
+func (n *networkStreamSenderGRPC) Context() context.Context {
+	return n.stream.Context()
+}
+
+func (n *networkStreamSenderGRPC) Send(data *grpc.SyncData) (err error) {
+	err = n.SendStream(&grpc.StreamData{SyncData: data})
+	return
+}
+
+func (n *networkStreamSenderGRPC) SendStream(data *grpc.StreamData) (err error) {
+	n.sending.Lock()
+	defer n.sending.Unlock()
+	err = n.stream.Send(data)
+	return
+}
+
+// RemoteStream will multiplex call, ping, sync and ack on one bidi stream, the call is processed by received order and
+// the result is correlated by RequestID, the sync data is sent after sync is received
+func (n *NetworkServerGRPC) RemoteStream(stream grpc.Server_RemoteStreamServer) (err error) {
+	conn, err := n.authenticate(stream.Context())
+	if err != nil {
+		return
+	}
+	sender := &networkStreamSenderGRPC{stream: stream}
+	sync := NewNetworkSyncStreamGRPC(conn, sender)
+	go n.loopStream(stream, sender, sync)
+	err = sync.Wait()
+	return
+}
+
+// loopStream will receive data from stream until stream is closed, the sync stream is removed after loop is done
+func (n *NetworkServerGRPC) loopStream(stream grpc.Server_RemoteStreamServer, sender *networkStreamSenderGRPC, sync *NetworkSyncStreamGRPC) {
+	synced := false
+	defer func() {
+		if perr := recover(); perr != nil {
+			Errorf("[GRPC] loop server stream painc with %v, callstack is \n%v", perr, xdebug.CallStack())
+		}
+		sync.Close()
+		if synced {
+			n.cancleStream(sync)
+		}
+	}()
+	for {
+		data, err := stream.Recv()
+		if err != nil {
+			break
+		}
+		switch {
+		case data.Ping != nil:
+			result := n.remotePing(n.keepSession(sync.session), data.Ping)
+			err = sender.SendStream(&grpc.StreamData{PingResult: result})
+		case data.Call != nil:
+			result := n.remoteCall(n.keepSession(sync.session), data.Call)
+			result.Id = data.Call.Id
+			err = sender.SendStream(&grpc.StreamData{CallResult: result})
+		case data.Sync != nil && !synced:
+			sync.syncID = data.Sync.Id.GetUuid()
+			sync.SetEncoding(data.Sync.Encoding)
+			sync.SetReliable(data.Sync.Reliable)
+			synced = true
+			n.addStream(sync)
+		case data.Ack != nil && sync.reliable != nil:
+			sync.reliable.Ack(data.Ack.Sequence)
+		case data.SyncData != nil:
+			n.keepSession(sync.session)
+			n.publish(sync, data.SyncData)
+		}
+		if err != nil {
+			break
+		}
+	}
+}
+
 type NetworkClientGRPC struct {
 	*NetworkBaseConnGRPC
 	grpc.ServerClient
+	StreamOn   bool // if multiplex call, ping and sync on bidi stream, it will fallback to unary call when server is not supported
 	connection *ggrpc.ClientConn
 	sync       grpc.Server_RemoteSyncClient
+	syncID     string
+	syncCtx    context.Context
+	syncCancel context.CancelFunc
+	ack        grpc.Server_RemoteAckClient
+	sequence   int64
+	decoder    *NetworkBinaryDecoder
 	callback   NetworkCallback
 	waiter     sync.WaitGroup
+	stream     grpc.Server_RemoteStreamClient
+	waitingAll map[string]chan *grpc.StreamData
+	sending    sync.Mutex
+	streamLck  sync.RWMutex
+	keepCtx    context.Context // it is canceled when client is closed, so the restart is stopped
+	keepCancel context.CancelFunc
+	startLck   sync.Mutex
+	stateLck   sync.RWMutex
+	pingLast   [2]int64 // the server send and local receive time in nanoseconds of last ping result, it is echoed to server
+	pingLck    sync.Mutex
+	connected  int32 // the connection count of session on server by last ping result
 }
 
 func NewNetworkClientGRPC(connection *ggrpc.ClientConn, callback NetworkCallback) (client *NetworkClientGRPC) {
+	client = NewNetworkClientGRPCByContext(DefaultContext, connection, callback)
+	return
+}
+
+func NewNetworkClientGRPCByContext(ctx *NetworkContext, connection *ggrpc.ClientConn, callback NetworkCallback) (client *NetworkClientGRPC) {
 	client = &NetworkClientGRPC{
 		NetworkBaseConnGRPC: &NetworkBaseConnGRPC{
-			session:  Network.NetworkSession,
-			state:    NetworkStateReady,
+			ctx:      ctx,
+			session:  ctx.Network.NetworkSession,
+			state:    NetworkStateConnecting,
 			isServer: true,
 			isClient: true,
 		},
+		StreamOn:   true,
 		connection: connection, callback: callback,
-		waiter: sync.WaitGroup{},
+		waiter:     sync.WaitGroup{},
+		waitingAll: map[string]chan *grpc.StreamData{},
 	}
+	client.keepCtx, client.keepCancel = context.WithCancel(context.Background())
 	client.ServerClient = grpc.NewServerClient(client.connection)
 	return
 }
 
+func (n *NetworkClientGRPC) State() NetworkState {
+	n.stateLck.RLock()
+	defer n.stateLck.RUnlock()
+	return n.state
+}
+
+// setState will update the state and notify the transition
+func (n *NetworkClientGRPC) setState(state NetworkState, info interface{}) {
+	n.stateLck.Lock()
+	n.state = state
+	n.stateLck.Unlock()
+	n.callback.OnNetworkState(NetworkConnectionSet{n.ID(): n}, n, state, info)
+}
+
 func (n *NetworkClientGRPC) withNetworkContext() (ctx context.Context, cancel func()) {
-	ctx, cancel = context.WithTimeout(NewOutgoingContext(context.Background(), Network.NetworkSession), Network.Timeout)
+	network := n.ctx.Network
+	ctx, cancel = context.WithTimeout(NewOutgoingContext(context.Background(), network.NetworkSession), network.Timeout)
 	return
 }
 
@@ -470,29 +983,280 @@ func (n *NetworkClientGRPC) loopSync() (err error) {
 		}
 		n.waiter.Done()
 	}()
-	n.callback.OnNetworkState(NetworkConnectionSet{n.ID(): n}, n, NetworkStateReady, nil)
+	n.setState(NetworkStateReady, nil)
 	for {
 		sd, xerr := n.sync.Recv()
 		if xerr != nil {
 			err = xerr
 			break
 		}
-		n.callback.OnNetworkSync(n, ParseNetworkSyncDataGRPC(sd))
+		n.recvSyncData(sd)
+	}
+	if n.ack != nil {
+		n.ack.CloseSend()
+		n.ack = nil
+	}
+	n.restart(err)
+	return
+}
+
+func (n *NetworkClientGRPC) loopStream() (err error) {
+	defer func() {
+		if perr := recover(); perr != nil {
+			Errorf("[GRPC] loop client stream painc with %v, callstack is \n%v", perr, xdebug.CallStack())
+		}
+		n.waiter.Done()
+	}()
+	n.setState(NetworkStateReady, nil)
+	//sync data is processed on other goroutine, so the call in sync callback is not blocked by waiting result
+	queue := make(chan *grpc.SyncData, 1024)
+	done := make(chan int)
+	go func() {
+		defer close(done)
+		for sd := range queue {
+			n.procStreamSync(sd)
+		}
+	}()
+	for {
+		data, xerr := n.stream.Recv()
+		if xerr != nil {
+			err = xerr
+			break
+		}
+		switch {
+		case data.SyncData != nil:
+			queue <- data.SyncData
+		case data.CallResult != nil:
+			n.doneWaiting(data.CallResult.Id.GetUuid(), data)
+		case data.PingResult != nil:
+			n.doneWaiting(data.PingResult.Id.GetUuid(), data)
+		}
+	}
+	n.streamLck.Lock()
+	for id, waiting := range n.waitingAll {
+		close(waiting)
+		delete(n.waitingAll, id)
+	}
+	n.stream = nil
+	n.streamLck.Unlock()
+	close(queue)
+	<-done
+	n.restart(err)
+	return
+}
+
+// restart will restart the broken sync by backoff policy, it notify closed when client is closed or attempts is exhausted
+func (n *NetworkClientGRPC) restart(err error) {
+	backoff := n.ctx.Network.Reconnect
+	if n.keepCtx.Err() != nil || backoff == nil {
+		n.setState(NetworkStateClosed, err)
+		return
+	}
+	Warnf("[GRPC] network sync is broken by %v, will restart", err)
+	n.setState(NetworkStateError, err)
+	for attempt := 1; ; attempt++ {
+		delay, ok := backoff.Next(attempt)
+		if !ok {
+			Warnf("[GRPC] restart network sync is stopped after %v attempts", attempt-1)
+			break
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-n.keepCtx.Done():
+			timer.Stop()
+		}
+		if n.keepCtx.Err() != nil {
+			break
+		}
+		n.setState(NetworkStateConnecting, attempt)
+		n.startLck.Lock()
+		n.reset()
+		err = n.start()
+		n.startLck.Unlock()
+		if err == nil {
+			return
+		}
+		Warnf("[GRPC] restart network sync on %v attempt error %v", attempt, err)
+		n.setState(NetworkStateError, err)
+	}
+	n.startLck.Lock()
+	n.reset()
+	n.startLck.Unlock()
+	n.setState(NetworkStateClosed, err)
+}
+
+// reset will cancel the current sync, so it can be started again
+func (n *NetworkClientGRPC) reset() {
+	if n.syncCancel != nil {
+		n.syncCancel()
+	}
+	n.sync = nil
+	n.syncCancel = nil
+}
+
+// breakSync will cancel the current sync when connection is broken, the sync is restarted by backoff policy
+func (n *NetworkClientGRPC) breakSync() {
+	n.startLck.Lock()
+	defer n.startLck.Unlock()
+	if n.syncCancel != nil {
+		n.syncCancel()
+	}
+}
+
+func (n *NetworkClientGRPC) procStreamSync(sd *grpc.SyncData) {
+	defer func() {
+		if perr := recover(); perr != nil {
+			Errorf("[GRPC] proc client stream sync painc with %v, callstack is \n%v", perr, xdebug.CallStack())
+		}
+	}()
+	n.recvSyncData(sd)
+}
+
+// recvSyncData will skip the stale sync data and ack the reliable sync data after it is synced
+func (n *NetworkClientGRPC) recvSyncData(sd *grpc.SyncData) {
+	if sd.Sequence > 0 {
+		if sd.Sequence <= n.sequence { //stale
+			return
+		}
+		n.sequence = sd.Sequence
+	}
+	n.callback.OnNetworkSync(n, ParseNetworkSyncDataByDecoderGRPC(sd, n.decoder))
+	if sd.Sequence > 0 {
+		n.sendAck(sd.Sequence)
+	}
+}
+
+func (n *NetworkClientGRPC) doneWaiting(id string, data *grpc.StreamData) {
+	n.streamLck.Lock()
+	waiting := n.waitingAll[id]
+	delete(n.waitingAll, id)
+	n.streamLck.Unlock()
+	if waiting != nil {
+		waiting <- data
+	}
+}
+
+// sendStream will send data on stream, it return stream is nil when stream is not started or closed
+func (n *NetworkClientGRPC) sendStream(data *grpc.StreamData) (stream grpc.Server_RemoteStreamClient, err error) {
+	n.streamLck.RLock()
+	stream = n.stream
+	n.streamLck.RUnlock()
+	if stream == nil {
+		return
+	}
+	n.sending.Lock()
+	err = stream.Send(data)
+	n.sending.Unlock()
+	return
+}
+
+// callStream will send data on stream and wait the result by id, it return nil result when stream is not started or closed
+func (n *NetworkClientGRPC) callStream(id string, data *grpc.StreamData) (result *grpc.StreamData, err error) {
+	waiting := make(chan *grpc.StreamData, 1)
+	n.streamLck.Lock()
+	if n.stream == nil {
+		n.streamLck.Unlock()
+		return
+	}
+	n.waitingAll[id] = waiting
+	n.streamLck.Unlock()
+	defer func() {
+		n.streamLck.Lock()
+		delete(n.waitingAll, id)
+		n.streamLck.Unlock()
+	}()
+	stream, err := n.sendStream(data)
+	if err != nil || stream == nil {
+		err = fmt.Errorf("stream is closed")
+		return
+	}
+	timer := time.NewTimer(n.ctx.Network.Timeout)
+	defer timer.Stop()
+	select {
+	case result = <-waiting:
+		if result == nil {
+			err = fmt.Errorf("stream is closed")
+		}
+	case <-timer.C:
+		err = fmt.Errorf("timeout")
 	}
-	n.callback.OnNetworkState(NetworkConnectionSet{n.ID(): n}, n, NetworkStateClosed, err)
 	return
 }
 
+// sendAck will send the sequence of received sync data to server, the ack stream is opened when first reliable sync data is received
+func (n *NetworkClientGRPC) sendAck(sequence int64) {
+	var err error
+	ack := &grpc.AckArg{Id: &grpc.RequestID{Uuid: n.syncID}, Sequence: sequence}
+	if stream, xerr := n.sendStream(&grpc.StreamData{Ack: ack}); stream != nil {
+		if xerr != nil {
+			Warnf("[GRPC] send ack %v to server error %v", sequence, xerr)
+		}
+		return
+	}
+	if n.ack == nil {
+		n.ack, err = n.RemoteAck(n.syncCtx)
+	}
+	if err == nil {
+		err = n.ack.Send(ack)
+	}
+	if err != nil {
+		Warnf("[GRPC] send ack %v to server error %v", sequence, err)
+		n.ack = nil
+	}
+}
+
 func (n *NetworkClientGRPC) Start() (err error) {
-	if n.sync != nil {
+	n.startLck.Lock()
+	defer n.startLck.Unlock()
+	if n.sync != nil || n.syncCancel != nil {
 		err = fmt.Errorf("started")
 		return
 	}
-	ctx := NewOutgoingContext(context.Background(), Network.NetworkSession)
-	n.sync, err = n.RemoteSync(ctx, &grpc.SyncArg{
-		Id: &grpc.RequestID{Uuid: uuid.New()},
-	})
+	n.setState(NetworkStateConnecting, nil)
+	err = n.start()
 	if err != nil {
+		n.setState(NetworkStateError, err)
+	}
+	return
+}
+
+func (n *NetworkClientGRPC) start() (err error) {
+	n.syncCtx, n.syncCancel = context.WithCancel(NewOutgoingContext(n.keepCtx, n.ctx.Network.NetworkSession))
+	n.syncID = uuid.New()
+	n.sequence = 0
+	n.decoder = NewNetworkBinaryDecoder()
+	arg := &grpc.SyncArg{
+		Id:       &grpc.RequestID{Uuid: n.syncID},
+		Encoding: n.ctx.Network.SyncEncoding,
+		Reliable: n.ctx.Network.SyncReliable,
+	}
+	if n.StreamOn {
+		err = n.startStream(arg)
+		if err == nil {
+			n.waiter.Add(1)
+			go n.loopStream()
+			return
+		}
+		if status.Code(err) != codes.Unimplemented {
+			n.syncCancel()
+			n.syncCancel = nil
+			return
+		}
+		Infof("[GRPC] server is not supported stream, fallback to unary call")
+	}
+	n.sync, err = n.RemoteSync(n.syncCtx, arg)
+	var header metadata.MD
+	if err == nil {
+		header, err = n.sync.Header()
+	}
+	if err == nil && header == nil { //the error status is returned by Recv when stream is failed before header
+		_, err = n.sync.Recv()
+	}
+	if err != nil {
+		n.sync = nil
+		n.syncCancel()
+		n.syncCancel = nil
 		return
 	}
 	n.waiter.Add(1)
@@ -500,34 +1264,129 @@ func (n *NetworkClientGRPC) Start() (err error) {
 	return
 }
 
+// startStream will open bidi stream and check server is supported by ping, then start sync on stream
+func (n *NetworkClientGRPC) startStream(arg *grpc.SyncArg) (err error) {
+	stream, err := n.RemoteStream(n.syncCtx)
+	if err != nil {
+		return
+	}
+	err = stream.Send(&grpc.StreamData{Ping: &grpc.PingArg{Id: &grpc.RequestID{Uuid: uuid.New()}}})
+	var pong *grpc.StreamData
+	if err == nil || err == io.EOF { //the error status is returned by Recv when send fail by io.EOF
+		pong, err = stream.Recv()
+	}
+	if err == nil {
+		n.keepSession(pong.GetPingResult())
+	}
+	if err == nil {
+		err = stream.Send(&grpc.StreamData{Sync: arg})
+	}
+	if err != nil {
+		return
+	}
+	n.streamLck.Lock()
+	n.stream = stream
+	n.streamLck.Unlock()
+	return
+}
+
 func (n *NetworkClientGRPC) Stop() (err error) {
+	n.setState(NetworkStateClosing, nil)
 	n.Close()
 	n.waiter.Wait()
-	n.sync = nil
+	n.startLck.Lock()
+	n.reset()
+	n.startLck.Unlock()
+	if n.State() != NetworkStateClosed {
+		n.setState(NetworkStateClosed, nil)
+	}
 	return
 }
 
-func (n *NetworkClientGRPC) Ping() (speed time.Duration, err error) {
-	ctx, cancel := n.withNetworkContext()
-	defer cancel()
+func (n *NetworkClientGRPC) Ping() (speed time.Duration, serverTime time.Time, err error) {
 	startTime := time.Now()
-	_, err = n.RemotePing(ctx, &grpc.PingArg{Id: &grpc.RequestID{Uuid: uuid.New()}})
-	speed = time.Since(startTime)
+	n.pingLck.Lock()
+	last := n.pingLast
+	n.pingLck.Unlock()
+	arg := &grpc.PingArg{Id: &grpc.RequestID{Uuid: uuid.New()}, SendTime: startTime.UnixNano(), LastSendTime: last[0], LastRecvTime: last[1]}
+	var res *grpc.PingResult
+	if data, xerr := n.callStream(arg.Id.Uuid, &grpc.StreamData{Ping: arg}); data != nil || xerr != nil {
+		res, err = data.GetPingResult(), xerr
+	} else {
+		ctx, cancel := n.withNetworkContext()
+		defer cancel()
+		res, err = n.RemotePing(ctx, arg)
+	}
+	recvTime := time.Now()
+	speed = recvTime.Sub(startTime)
+	if err == nil {
+		serverTime = xtime.TimeUnix(res.ServerTime)
+		if res.RecvTime > 0 && res.SendTime > 0 {
+			speed = n.ping.Add(startTime, pingTimeUnix(res.RecvTime), pingTimeUnix(res.SendTime), recvTime)
+			serverTime = pingTimeUnix(res.SendTime)
+			n.pingLck.Lock()
+			n.pingLast = [2]int64{res.SendTime, recvTime.UnixNano()}
+			n.pingLck.Unlock()
+		}
+		atomic.StoreInt32(&n.connected, res.Connected)
+		n.keepSession(res)
+	}
 	return
 }
 
+// Connected will return the connection count of session on server by last ping result
+func (n *NetworkClientGRPC) Connected() int {
+	return int(atomic.LoadInt32(&n.connected))
+}
+
+// contact will ping server to get session key issued by server on first contact, the client key is replaced when it is not issued by server
+func (n *NetworkClientGRPC) contact() {
+	if _, _, err := n.Ping(); err != nil {
+		Warnf("[GRPC] first contact to server error %v", err)
+	}
+}
+
+// keepSession will update the session key to the key issued by server, so the session is resumed when reconnect by same key
+func (n *NetworkClientGRPC) keepSession(res *grpc.PingResult) {
+	network := n.ctx.Network
+	if key := res.GetSession(); len(key) > 0 && key != network.Key() {
+		Debugf("[GRPC] network session key is issued by server to %v", key)
+		network.SetKey(key)
+	}
+}
+
+// NetworkSync will publish the sync data of owned components to server
 func (n *NetworkClientGRPC) NetworkSync(data *NetworkSyncData) {
+	sd := ParseSyncDataGRPC(data.Encode(n.session))
+	if n.ctx.Network.Verbose {
+		Debugf("[GRPC] network publish to server by\n %v", converter.JSON(sd))
+	}
+	stream, err := n.sendStream(&grpc.StreamData{SyncData: sd})
+	if stream == nil {
+		ctx, cancel := n.withNetworkContext()
+		defer cancel()
+		_, err = n.RemoteUpdate(ctx, sd)
+	}
+	if err != nil {
+		Warnf("[GRPC] publish sync data to server error %v", err)
+	}
 }
 
 func (n *NetworkClientGRPC) NetworkCall(arg *NetworkCallArg) (ret *NetworkCallResult, err error) {
-	ctx, cancel := n.withNetworkContext()
-	defer cancel()
-	res, err := n.RemoteCall(ctx, &grpc.CallArg{
+	call := &grpc.CallArg{
 		Id:   &grpc.RequestID{Uuid: arg.UUID},
 		Cid:  arg.CID,
 		Name: arg.Name,
 		Arg:  arg.Arg,
-	})
+	}
+	var res *grpc.CallResult
+	if data, xerr := n.callStream(arg.UUID, &grpc.StreamData{Call: call}); data != nil || xerr != nil {
+		res, err = data.GetCallResult(), xerr
+	} else {
+		ctx, cancel := n.withNetworkContext()
+		defer cancel()
+		res, err = n.RemoteCall(ctx, call)
+	}
 	if err != nil {
 		return
 	}
@@ -545,7 +1404,10 @@ func (n *NetworkClientGRPC) NetworkCall(arg *NetworkCallArg) (ret *NetworkCallRe
 }
 
 func (n *NetworkClientGRPC) Close() (err error) {
-	err = n.connection.Close()
+	n.keepCancel()
+	if n.connection != nil {
+		err = n.connection.Close()
+	}
 	return
 }
 
@@ -672,8 +1534,10 @@ func (n *NetworkWebsocketServerGRPC) String() string {
 }
 
 type NetworkTransportGRPC struct {
+	Context      *NetworkContext
 	GrpcOn       bool
 	WebOn        bool
+	StreamOn     bool // if client multiplex call, ping and sync on bidi stream
 	GrpcAddress  *url.URL
 	GrpcOpts     []ggrpc.DialOption
 	WebAddress   *url.URL
@@ -687,6 +1551,7 @@ type NetworkTransportGRPC struct {
 	ConnConfig   *tls.Config
 	WebMux       *http.ServeMux
 	Websocket    *NetworkWebsocketServerGRPC
+	Simulator    *NetworkSimulator // if client connection is wrapped by simulator
 	initial      bool
 	running      bool
 	ready        bool
@@ -695,16 +1560,23 @@ type NetworkTransportGRPC struct {
 }
 
 func NewNetworkTransportGRPC() (transport *NetworkTransportGRPC) {
+	transport = NewNetworkTransportGRPCByContext(DefaultContext)
+	return
+}
+
+func NewNetworkTransportGRPCByContext(ctx *NetworkContext) (transport *NetworkTransportGRPC) {
 	transport = &NetworkTransportGRPC{
+		Context:  ctx,
 		GrpcOn:   true,
 		WebOn:    true,
+		StreamOn: true,
 		GrpcOpts: []ggrpc.DialOption{ggrpc.WithTransportCredentials(insecure.NewCredentials())},
 		exiter:   make(chan int, 8),
 		waiter:   sync.WaitGroup{},
 	}
 	transport.GrpcAddress, _ = url.Parse("grpc://127.0.0.1:50051")
 	transport.WebAddress, _ = url.Parse("ws://127.0.0.1:50052")
-	transport.Server = NewNetworkServerGRPC(Network)
+	transport.Server = NewNetworkServerGRPCByContext(ctx, ctx.Network)
 	transport.GrpcServer = ggrpc.NewServer()
 	transport.WebMux = http.NewServeMux()
 	transport.Websocket = NewNetworkWebsocketServerGRPC()
@@ -727,39 +1599,54 @@ func (n *NetworkTransportGRPC) serveWeb(ln net.Listener) {
 	Infof("[GRPC] web server on %v is stopped by %v", ln.Addr(), err)
 }
 
+func (n *NetworkTransportGRPC) dial(ctx context.Context, address string) (conn net.Conn, err error) {
+	if n.GrpcOn {
+		conn, err = (&net.Dialer{}).DialContext(ctx, "tcp", address)
+	} else {
+		originURL := n.WebAddress.String()
+		originURL = strings.ReplaceAll(originURL, "ws://", "http://")
+		originURL = strings.ReplaceAll(originURL, "wss://", "https://")
+		origin, _ := url.Parse(originURL)
+		config := &websocket.Config{
+			Location:  n.WebAddress,
+			Origin:    origin,
+			TlsConfig: n.ConnConfig,
+			Version:   websocket.ProtocolVersionHybi13,
+		}
+		var wcon *websocket.Conn
+		wcon, err = websocket.DialConfig(config)
+		if err == nil {
+			conn = &NetworkWebsocketConnGRPC{Conn: wcon}
+		}
+	}
+	if err == nil && n.Simulator != nil {
+		conn = NewNetworkConnSimulator(conn, n.Simulator)
+	}
+	return
+}
+
 func (n *NetworkTransportGRPC) connect() (err error) {
 	if n.Client != nil {
 		n.Client.Close()
 	}
 	opts := n.GrpcOpts
-	if !n.GrpcOn {
-		opts = append(opts, ggrpc.WithContextDialer(func(ctx context.Context, s string) (conn net.Conn, err error) {
-			originURL := n.WebAddress.String()
-			originURL = strings.ReplaceAll(originURL, "ws://", "http://")
-			originURL = strings.ReplaceAll(originURL, "wss://", "https://")
-			origin, _ := url.Parse(originURL)
-			config := &websocket.Config{
-				Location:  n.WebAddress,
-				Origin:    origin,
-				TlsConfig: n.ConnConfig,
-				Version:   websocket.ProtocolVersionHybi13,
-			}
-			wcon, err := websocket.DialConfig(config)
-			conn = &NetworkWebsocketConnGRPC{Conn: wcon}
-			return
-		}))
+	if !n.GrpcOn || n.Simulator != nil {
+		opts = append(opts, ggrpc.WithContextDialer(n.dial))
 	}
 	if n.ConnConfig != nil {
 		opts = append(opts, ggrpc.WithTransportCredentials(credentials.NewTLS(n.ConnConfig)))
 	}
-	ctx, cancel := context.WithTimeout(NewOutgoingContext(context.Background(), Network.NetworkSession), Network.Timeout)
+	network := n.Context.Network
+	ctx, cancel := context.WithTimeout(NewOutgoingContext(context.Background(), network.NetworkSession), network.Timeout)
 	defer cancel()
 	connection, xerr := ggrpc.DialContext(ctx, n.GrpcAddress.Host, opts...)
 	if xerr != nil {
 		err = xerr
 		return
 	}
-	n.Client = NewNetworkClientGRPC(connection, Network)
+	n.Client = NewNetworkClientGRPCByContext(n.Context, connection, network)
+	n.Client.StreamOn = n.StreamOn
+	n.Client.contact()
 	if n.ready {
 		err = n.Client.Start()
 	}
@@ -768,8 +1655,8 @@ func (n *NetworkTransportGRPC) connect() (err error) {
 
 func (n *NetworkTransportGRPC) loopKeep() {
 	defer n.waiter.Done()
-	Infof("[GRPC] keepalive task is starting by %v", Network.Keepalive)
-	ticker := time.NewTicker(Network.Keepalive)
+	Infof("[GRPC] keepalive task is starting by %v", n.Context.Network.Keepalive)
+	ticker := time.NewTicker(n.Context.Network.Keepalive)
 	running := true
 	for running {
 		select {
@@ -782,23 +1669,33 @@ func (n *NetworkTransportGRPC) loopKeep() {
 	Infof("[GRPC] keepalive task is stopped")
 }
 
+// ClientConn will return the current client connection
+func (n *NetworkTransportGRPC) ClientConn() NetworkClientConn {
+	if n.Client == nil {
+		return nil
+	}
+	return n.Client
+}
+
 func (n *NetworkTransportGRPC) procKeep() {
 	defer func() {
 		if perr := recover(); perr != nil {
 			Errorf("[GRPC] proc keep painc with %v, callstack is \n%v", perr, xdebug.CallStack())
 		}
 	}()
-	if Network.IsServer && n.running {
-		n.Server.timeout(Network.Keepalive * 2)
+	network := n.Context.Network
+	if network.IsServer && n.running {
+		n.Server.timeout(network.Keepalive*2 + network.SessionGrace)
 	}
-	if Network.IsClient && n.running {
-		speed, err := n.Client.Ping()
+	if network.IsClient && n.running {
+		speed, serverTime, err := n.Client.Ping()
 		if err != nil {
 			Warnf("[GRPC] ping to server error %v", err)
-			n.connect()
+			n.Client.breakSync()
 		} else {
-			Network.PingSpeed = speed
-			Network.OnNetworkPing(n.Client, speed)
+			network.PingSpeed = speed
+			network.SetServerTime(serverTime, speed)
+			network.OnNetworkPing(n.Client, speed)
 		}
 	}
 }
@@ -821,7 +1718,8 @@ func (n *NetworkTransportGRPC) Start() (err error) {
 		n.WebMux.Handle(path, n.Websocket)
 		n.initial = true
 	}
-	if Network.IsServer {
+	network := n.Context.Network
+	if network.IsServer {
 		if n.GrpcOn {
 			n.GrpcListener, err = n.createListener(n.GrpcAddress.Host)
 			if err != nil {
@@ -843,7 +1741,7 @@ func (n *NetworkTransportGRPC) Start() (err error) {
 		n.waiter.Add(1)
 		go n.serveGRPC(n.Websocket)
 	}
-	if Network.IsClient {
+	if network.IsClient {
 		if n.GrpcOn || n.WebOn {
 			err = n.connect()
 			n.running = err == nil
@@ -854,11 +1752,11 @@ func (n *NetworkTransportGRPC) Start() (err error) {
 			return
 		}
 	}
-	if Network.Keepalive > 0 {
+	if network.Keepalive > 0 {
 		n.waiter.Add(1)
 		go n.loopKeep()
 	}
-	if Network.IsServer && !Network.IsClient {
+	if network.IsServer && !network.IsClient {
 		err = n.Ready()
 	}
 	return
@@ -891,7 +1789,7 @@ func (n *NetworkTransportGRPC) IsReady() (ready bool) {
 }
 
 func (n *NetworkTransportGRPC) Ready() (err error) {
-	if Network.IsClient {
+	if n.Context.Network.IsClient {
 		if n.Client == nil {
 			err = fmt.Errorf("not started")
 			return
@@ -903,7 +1801,7 @@ func (n *NetworkTransportGRPC) Ready() (err error) {
 }
 
 func (n *NetworkTransportGRPC) Pause() (err error) {
-	if Network.IsClient {
+	if n.Context.Network.IsClient {
 		if n.Client == nil {
 			err = fmt.Errorf("not started")
 			return
@@ -914,14 +1812,23 @@ func (n *NetworkTransportGRPC) Pause() (err error) {
 	return
 }
 
+// Regroup will move the sync streams of session to the session group, see NetworkRegroupTransport
+func (n *NetworkTransportGRPC) Regroup(session NetworkSession) {
+	if n.Context.Network.IsServer {
+		n.Server.regroup(session)
+	}
+}
+
 func (n *NetworkTransportGRPC) NetworkSync(data *NetworkSyncData, excluded []NetworkConnection) {
-	if Network.IsServer {
+	if n.Context.Network.IsServer {
 		n.Server.NetworkSync(data, excluded)
+	} else if n.Context.Network.IsClient && n.Client != nil {
+		n.Client.NetworkSync(data)
 	}
 }
 
 func (n *NetworkTransportGRPC) NetworkCall(arg *NetworkCallArg) (ret *NetworkCallResult, err error) {
-	if !Network.IsClient || n.Client == nil {
+	if !n.Context.Network.IsClient || n.Client == nil {
 		err = fmt.Errorf("not client or not connect")
 		return
 	}
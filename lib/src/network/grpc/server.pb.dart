@@ -67,11 +67,23 @@ class RequestID extends $pb.GeneratedMessage {
 class PingArg extends $pb.GeneratedMessage {
   factory PingArg({
     RequestID? id,
+    $fixnum.Int64? sendTime,
+    $fixnum.Int64? lastSendTime,
+    $fixnum.Int64? lastRecvTime,
   }) {
     final $result = create();
     if (id != null) {
       $result.id = id;
     }
+    if (sendTime != null) {
+      $result.sendTime = sendTime;
+    }
+    if (lastSendTime != null) {
+      $result.lastSendTime = lastSendTime;
+    }
+    if (lastRecvTime != null) {
+      $result.lastRecvTime = lastRecvTime;
+    }
     return $result;
   }
   PingArg._() : super();
@@ -80,6 +92,9 @@ class PingArg extends $pb.GeneratedMessage {
 
   static final $pb.BuilderInfo _i = $pb.BuilderInfo(_omitMessageNames ? '' : 'PingArg', package: const $pb.PackageName(_omitMessageNames ? '' : 'grpc'), createEmptyInstance: create)
     ..aOM<RequestID>(1, _omitFieldNames ? '' : 'id', subBuilder: RequestID.create)
+    ..aInt64(2, _omitFieldNames ? '' : 'sendTime', protoName: 'sendTime')
+    ..aInt64(3, _omitFieldNames ? '' : 'lastSendTime', protoName: 'lastSendTime')
+    ..aInt64(4, _omitFieldNames ? '' : 'lastRecvTime', protoName: 'lastRecvTime')
     ..hasRequiredFields = false
   ;
 
@@ -114,6 +129,33 @@ class PingArg extends $pb.GeneratedMessage {
   void clearId() => clearField(1);
   @$pb.TagNumber(1)
   RequestID ensureId() => $_ensure(0);
+
+  @$pb.TagNumber(2)
+  $fixnum.Int64 get sendTime => $_getI64(1);
+  @$pb.TagNumber(2)
+  set sendTime($fixnum.Int64 v) { $_setInt64(1, v); }
+  @$pb.TagNumber(2)
+  $core.bool hasSendTime() => $_has(1);
+  @$pb.TagNumber(2)
+  void clearSendTime() => clearField(2);
+
+  @$pb.TagNumber(3)
+  $fixnum.Int64 get lastSendTime => $_getI64(2);
+  @$pb.TagNumber(3)
+  set lastSendTime($fixnum.Int64 v) { $_setInt64(2, v); }
+  @$pb.TagNumber(3)
+  $core.bool hasLastSendTime() => $_has(2);
+  @$pb.TagNumber(3)
+  void clearLastSendTime() => clearField(3);
+
+  @$pb.TagNumber(4)
+  $fixnum.Int64 get lastRecvTime => $_getI64(3);
+  @$pb.TagNumber(4)
+  set lastRecvTime($fixnum.Int64 v) { $_setInt64(3, v); }
+  @$pb.TagNumber(4)
+  $core.bool hasLastRecvTime() => $_has(3);
+  @$pb.TagNumber(4)
+  void clearLastRecvTime() => clearField(4);
 }
 
 class PingResult extends $pb.GeneratedMessage {
@@ -121,6 +163,9 @@ class PingResult extends $pb.GeneratedMessage {
     RequestID? id,
     $fixnum.Int64? serverTime,
     $core.int? connected,
+    $core.String? session,
+    $fixnum.Int64? recvTime,
+    $fixnum.Int64? sendTime,
   }) {
     final $result = create();
     if (id != null) {
@@ -132,6 +177,15 @@ class PingResult extends $pb.GeneratedMessage {
     if (connected != null) {
       $result.connected = connected;
     }
+    if (session != null) {
+      $result.session = session;
+    }
+    if (recvTime != null) {
+      $result.recvTime = recvTime;
+    }
+    if (sendTime != null) {
+      $result.sendTime = sendTime;
+    }
     return $result;
   }
   PingResult._() : super();
@@ -142,6 +196,9 @@ class PingResult extends $pb.GeneratedMessage {
     ..aOM<RequestID>(1, _omitFieldNames ? '' : 'id', subBuilder: RequestID.create)
     ..aInt64(2, _omitFieldNames ? '' : 'serverTime', protoName: 'serverTime')
     ..a<$core.int>(3, _omitFieldNames ? '' : 'connected', $pb.PbFieldType.O3)
+    ..aOS(4, _omitFieldNames ? '' : 'session')
+    ..aInt64(5, _omitFieldNames ? '' : 'recvTime', protoName: 'recvTime')
+    ..aInt64(6, _omitFieldNames ? '' : 'sendTime', protoName: 'sendTime')
     ..hasRequiredFields = false
   ;
 
@@ -194,6 +251,33 @@ class PingResult extends $pb.GeneratedMessage {
   $core.bool hasConnected() => $_has(2);
   @$pb.TagNumber(3)
   void clearConnected() => clearField(3);
+
+  @$pb.TagNumber(4)
+  $core.String get session => $_getSZ(3);
+  @$pb.TagNumber(4)
+  set session($core.String v) { $_setString(3, v); }
+  @$pb.TagNumber(4)
+  $core.bool hasSession() => $_has(3);
+  @$pb.TagNumber(4)
+  void clearSession() => clearField(4);
+
+  @$pb.TagNumber(5)
+  $fixnum.Int64 get recvTime => $_getI64(4);
+  @$pb.TagNumber(5)
+  set recvTime($fixnum.Int64 v) { $_setInt64(4, v); }
+  @$pb.TagNumber(5)
+  $core.bool hasRecvTime() => $_has(4);
+  @$pb.TagNumber(5)
+  void clearRecvTime() => clearField(5);
+
+  @$pb.TagNumber(6)
+  $fixnum.Int64 get sendTime => $_getI64(5);
+  @$pb.TagNumber(6)
+  set sendTime($fixnum.Int64 v) { $_setInt64(5, v); }
+  @$pb.TagNumber(6)
+  $core.bool hasSendTime() => $_has(5);
+  @$pb.TagNumber(6)
+  void clearSendTime() => clearField(6);
 }
 
 class SyncDataComponent extends $pb.GeneratedMessage {
@@ -204,6 +288,7 @@ class SyncDataComponent extends $pb.GeneratedMessage {
     $core.bool? removed,
     $core.String? props,
     $core.String? triggers,
+    $core.List<$core.int>? binary,
   }) {
     final $result = create();
     if (factoryType != null) {
@@ -224,6 +309,9 @@ class SyncDataComponent extends $pb.GeneratedMessage {
     if (triggers != null) {
       $result.triggers = triggers;
     }
+    if (binary != null) {
+      $result.binary = binary;
+    }
     return $result;
   }
   SyncDataComponent._() : super();
@@ -237,6 +325,7 @@ class SyncDataComponent extends $pb.GeneratedMessage {
     ..aOB(4, _omitFieldNames ? '' : 'removed')
     ..aOS(5, _omitFieldNames ? '' : 'props')
     ..aOS(6, _omitFieldNames ? '' : 'triggers')
+    ..a<$core.List<$core.int>>(7, _omitFieldNames ? '' : 'binary', $pb.PbFieldType.OY)
     ..hasRequiredFields = false
   ;
 
@@ -314,16 +403,33 @@ class SyncDataComponent extends $pb.GeneratedMessage {
   $core.bool hasTriggers() => $_has(5);
   @$pb.TagNumber(6)
   void clearTriggers() => clearField(6);
+
+  @$pb.TagNumber(7)
+  $core.List<$core.int> get binary => $_getN(6);
+  @$pb.TagNumber(7)
+  set binary($core.List<$core.int> v) { $_setBytes(6, v); }
+  @$pb.TagNumber(7)
+  $core.bool hasBinary() => $_has(6);
+  @$pb.TagNumber(7)
+  void clearBinary() => clearField(7);
 }
 
 class SyncArg extends $pb.GeneratedMessage {
   factory SyncArg({
     RequestID? id,
+    $core.String? encoding,
+    $core.bool? reliable,
   }) {
     final $result = create();
     if (id != null) {
       $result.id = id;
     }
+    if (encoding != null) {
+      $result.encoding = encoding;
+    }
+    if (reliable != null) {
+      $result.reliable = reliable;
+    }
     return $result;
   }
   SyncArg._() : super();
@@ -332,6 +438,8 @@ class SyncArg extends $pb.GeneratedMessage {
 
   static final $pb.BuilderInfo _i = $pb.BuilderInfo(_omitMessageNames ? '' : 'SyncArg', package: const $pb.PackageName(_omitMessageNames ? '' : 'grpc'), createEmptyInstance: create)
     ..aOM<RequestID>(1, _omitFieldNames ? '' : 'id', subBuilder: RequestID.create)
+    ..aOS(2, _omitFieldNames ? '' : 'encoding')
+    ..aOB(3, _omitFieldNames ? '' : 'reliable')
     ..hasRequiredFields = false
   ;
 
@@ -366,6 +474,24 @@ class SyncArg extends $pb.GeneratedMessage {
   void clearId() => clearField(1);
   @$pb.TagNumber(1)
   RequestID ensureId() => $_ensure(0);
+
+  @$pb.TagNumber(2)
+  $core.String get encoding => $_getSZ(1);
+  @$pb.TagNumber(2)
+  set encoding($core.String v) { $_setString(1, v); }
+  @$pb.TagNumber(2)
+  $core.bool hasEncoding() => $_has(1);
+  @$pb.TagNumber(2)
+  void clearEncoding() => clearField(2);
+
+  @$pb.TagNumber(3)
+  $core.bool get reliable => $_getBF(2);
+  @$pb.TagNumber(3)
+  set reliable($core.bool v) { $_setBool(2, v); }
+  @$pb.TagNumber(3)
+  $core.bool hasReliable() => $_has(2);
+  @$pb.TagNumber(3)
+  void clearReliable() => clearField(3);
 }
 
 class SyncData extends $pb.GeneratedMessage {
@@ -374,6 +500,9 @@ class SyncData extends $pb.GeneratedMessage {
     $core.String? group,
     $core.bool? whole,
     $core.Iterable<SyncDataComponent>? components,
+    $fixnum.Int64? sequence,
+    $fixnum.Int64? tick,
+    $fixnum.Int64? time,
   }) {
     final $result = create();
     if (id != null) {
@@ -388,6 +517,15 @@ class SyncData extends $pb.GeneratedMessage {
     if (components != null) {
       $result.components.addAll(components);
     }
+    if (sequence != null) {
+      $result.sequence = sequence;
+    }
+    if (tick != null) {
+      $result.tick = tick;
+    }
+    if (time != null) {
+      $result.time = time;
+    }
     return $result;
   }
   SyncData._() : super();
@@ -399,6 +537,9 @@ class SyncData extends $pb.GeneratedMessage {
     ..aOS(2, _omitFieldNames ? '' : 'group')
     ..aOB(3, _omitFieldNames ? '' : 'whole')
     ..pc<SyncDataComponent>(4, _omitFieldNames ? '' : 'components', $pb.PbFieldType.PM, subBuilder: SyncDataComponent.create)
+    ..aInt64(5, _omitFieldNames ? '' : 'sequence')
+    ..aInt64(6, _omitFieldNames ? '' : 'tick')
+    ..aInt64(7, _omitFieldNames ? '' : 'time')
     ..hasRequiredFields = false
   ;
 
@@ -454,6 +595,423 @@ class SyncData extends $pb.GeneratedMessage {
 
   @$pb.TagNumber(4)
   $core.List<SyncDataComponent> get components => $_getList(3);
+
+  @$pb.TagNumber(5)
+  $fixnum.Int64 get sequence => $_getI64(4);
+  @$pb.TagNumber(5)
+  set sequence($fixnum.Int64 v) { $_setInt64(4, v); }
+  @$pb.TagNumber(5)
+  $core.bool hasSequence() => $_has(4);
+  @$pb.TagNumber(5)
+  void clearSequence() => clearField(5);
+
+  @$pb.TagNumber(6)
+  $fixnum.Int64 get tick => $_getI64(5);
+  @$pb.TagNumber(6)
+  set tick($fixnum.Int64 v) { $_setInt64(5, v); }
+  @$pb.TagNumber(6)
+  $core.bool hasTick() => $_has(5);
+  @$pb.TagNumber(6)
+  void clearTick() => clearField(6);
+
+  @$pb.TagNumber(7)
+  $fixnum.Int64 get time => $_getI64(6);
+  @$pb.TagNumber(7)
+  set time($fixnum.Int64 v) { $_setInt64(6, v); }
+  @$pb.TagNumber(7)
+  $core.bool hasTime() => $_has(6);
+  @$pb.TagNumber(7)
+  void clearTime() => clearField(7);
+}
+
+class AckArg extends $pb.GeneratedMessage {
+  factory AckArg({
+    RequestID? id,
+    $fixnum.Int64? sequence,
+  }) {
+    final $result = create();
+    if (id != null) {
+      $result.id = id;
+    }
+    if (sequence != null) {
+      $result.sequence = sequence;
+    }
+    return $result;
+  }
+  AckArg._() : super();
+  factory AckArg.fromBuffer($core.List<$core.int> i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromBuffer(i, r);
+  factory AckArg.fromJson($core.String i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromJson(i, r);
+
+  static final $pb.BuilderInfo _i = $pb.BuilderInfo(_omitMessageNames ? '' : 'AckArg', package: const $pb.PackageName(_omitMessageNames ? '' : 'grpc'), createEmptyInstance: create)
+    ..aOM<RequestID>(1, _omitFieldNames ? '' : 'id', subBuilder: RequestID.create)
+    ..aInt64(2, _omitFieldNames ? '' : 'sequence')
+    ..hasRequiredFields = false
+  ;
+
+  @$core.Deprecated(
+  'Using this can add significant overhead to your binary. '
+  'Use [GeneratedMessageGenericExtensions.deepCopy] instead. '
+  'Will be removed in next major version')
+  AckArg clone() => AckArg()..mergeFromMessage(this);
+  @$core.Deprecated(
+  'Using this can add significant overhead to your binary. '
+  'Use [GeneratedMessageGenericExtensions.rebuild] instead. '
+  'Will be removed in next major version')
+  AckArg copyWith(void Function(AckArg) updates) => super.copyWith((message) => updates(message as AckArg)) as AckArg;
+
+  $pb.BuilderInfo get info_ => _i;
+
+  @$core.pragma('dart2js:noInline')
+  static AckArg create() => AckArg._();
+  AckArg createEmptyInstance() => create();
+  static $pb.PbList<AckArg> createRepeated() => $pb.PbList<AckArg>();
+  @$core.pragma('dart2js:noInline')
+  static AckArg getDefault() => _defaultInstance ??= $pb.GeneratedMessage.$_defaultFor<AckArg>(create);
+  static AckArg? _defaultInstance;
+
+  @$pb.TagNumber(1)
+  RequestID get id => $_getN(0);
+  @$pb.TagNumber(1)
+  set id(RequestID v) { setField(1, v); }
+  @$pb.TagNumber(1)
+  $core.bool hasId() => $_has(0);
+  @$pb.TagNumber(1)
+  void clearId() => clearField(1);
+  @$pb.TagNumber(1)
+  RequestID ensureId() => $_ensure(0);
+
+  @$pb.TagNumber(2)
+  $fixnum.Int64 get sequence => $_getI64(1);
+  @$pb.TagNumber(2)
+  set sequence($fixnum.Int64 v) { $_setInt64(1, v); }
+  @$pb.TagNumber(2)
+  $core.bool hasSequence() => $_has(1);
+  @$pb.TagNumber(2)
+  void clearSequence() => clearField(2);
+}
+
+class AckResult extends $pb.GeneratedMessage {
+  factory AckResult({
+    RequestID? id,
+  }) {
+    final $result = create();
+    if (id != null) {
+      $result.id = id;
+    }
+    return $result;
+  }
+  AckResult._() : super();
+  factory AckResult.fromBuffer($core.List<$core.int> i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromBuffer(i, r);
+  factory AckResult.fromJson($core.String i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromJson(i, r);
+
+  static final $pb.BuilderInfo _i = $pb.BuilderInfo(_omitMessageNames ? '' : 'AckResult', package: const $pb.PackageName(_omitMessageNames ? '' : 'grpc'), createEmptyInstance: create)
+    ..aOM<RequestID>(1, _omitFieldNames ? '' : 'id', subBuilder: RequestID.create)
+    ..hasRequiredFields = false
+  ;
+
+  @$core.Deprecated(
+  'Using this can add significant overhead to your binary. '
+  'Use [GeneratedMessageGenericExtensions.deepCopy] instead. '
+  'Will be removed in next major version')
+  AckResult clone() => AckResult()..mergeFromMessage(this);
+  @$core.Deprecated(
+  'Using this can add significant overhead to your binary. '
+  'Use [GeneratedMessageGenericExtensions.rebuild] instead. '
+  'Will be removed in next major version')
+  AckResult copyWith(void Function(AckResult) updates) => super.copyWith((message) => updates(message as AckResult)) as AckResult;
+
+  $pb.BuilderInfo get info_ => _i;
+
+  @$core.pragma('dart2js:noInline')
+  static AckResult create() => AckResult._();
+  AckResult createEmptyInstance() => create();
+  static $pb.PbList<AckResult> createRepeated() => $pb.PbList<AckResult>();
+  @$core.pragma('dart2js:noInline')
+  static AckResult getDefault() => _defaultInstance ??= $pb.GeneratedMessage.$_defaultFor<AckResult>(create);
+  static AckResult? _defaultInstance;
+
+  @$pb.TagNumber(1)
+  RequestID get id => $_getN(0);
+  @$pb.TagNumber(1)
+  set id(RequestID v) { setField(1, v); }
+  @$pb.TagNumber(1)
+  $core.bool hasId() => $_has(0);
+  @$pb.TagNumber(1)
+  void clearId() => clearField(1);
+  @$pb.TagNumber(1)
+  RequestID ensureId() => $_ensure(0);
+}
+
+class UpdateResult extends $pb.GeneratedMessage {
+  factory UpdateResult({
+    RequestID? id,
+  }) {
+    final $result = create();
+    if (id != null) {
+      $result.id = id;
+    }
+    return $result;
+  }
+  UpdateResult._() : super();
+  factory UpdateResult.fromBuffer($core.List<$core.int> i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromBuffer(i, r);
+  factory UpdateResult.fromJson($core.String i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromJson(i, r);
+
+  static final $pb.BuilderInfo _i = $pb.BuilderInfo(_omitMessageNames ? '' : 'UpdateResult', package: const $pb.PackageName(_omitMessageNames ? '' : 'grpc'), createEmptyInstance: create)
+    ..aOM<RequestID>(1, _omitFieldNames ? '' : 'id', subBuilder: RequestID.create)
+    ..hasRequiredFields = false
+  ;
+
+  @$core.Deprecated(
+  'Using this can add significant overhead to your binary. '
+  'Use [GeneratedMessageGenericExtensions.deepCopy] instead. '
+  'Will be removed in next major version')
+  UpdateResult clone() => UpdateResult()..mergeFromMessage(this);
+  @$core.Deprecated(
+  'Using this can add significant overhead to your binary. '
+  'Use [GeneratedMessageGenericExtensions.rebuild] instead. '
+  'Will be removed in next major version')
+  UpdateResult copyWith(void Function(UpdateResult) updates) => super.copyWith((message) => updates(message as UpdateResult)) as UpdateResult;
+
+  $pb.BuilderInfo get info_ => _i;
+
+  @$core.pragma('dart2js:noInline')
+  static UpdateResult create() => UpdateResult._();
+  UpdateResult createEmptyInstance() => create();
+  static $pb.PbList<UpdateResult> createRepeated() => $pb.PbList<UpdateResult>();
+  @$core.pragma('dart2js:noInline')
+  static UpdateResult getDefault() => _defaultInstance ??= $pb.GeneratedMessage.$_defaultFor<UpdateResult>(create);
+  static UpdateResult? _defaultInstance;
+
+  @$pb.TagNumber(1)
+  RequestID get id => $_getN(0);
+  @$pb.TagNumber(1)
+  set id(RequestID v) { setField(1, v); }
+  @$pb.TagNumber(1)
+  $core.bool hasId() => $_has(0);
+  @$pb.TagNumber(1)
+  void clearId() => clearField(1);
+  @$pb.TagNumber(1)
+  RequestID ensureId() => $_ensure(0);
+}
+
+class StreamData extends $pb.GeneratedMessage {
+  factory StreamData({
+    PingArg? ping,
+    PingResult? pingResult,
+    SyncArg? sync,
+    SyncData? syncData,
+    CallArg? call,
+    CallResult? callResult,
+    AckArg? ack,
+    $core.Iterable<StreamMeta>? meta,
+  }) {
+    final $result = create();
+    if (ping != null) {
+      $result.ping = ping;
+    }
+    if (pingResult != null) {
+      $result.pingResult = pingResult;
+    }
+    if (sync != null) {
+      $result.sync = sync;
+    }
+    if (syncData != null) {
+      $result.syncData = syncData;
+    }
+    if (call != null) {
+      $result.call = call;
+    }
+    if (callResult != null) {
+      $result.callResult = callResult;
+    }
+    if (ack != null) {
+      $result.ack = ack;
+    }
+    if (meta != null) {
+      $result.meta.addAll(meta);
+    }
+    return $result;
+  }
+  StreamData._() : super();
+  factory StreamData.fromBuffer($core.List<$core.int> i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromBuffer(i, r);
+  factory StreamData.fromJson($core.String i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromJson(i, r);
+
+  static final $pb.BuilderInfo _i = $pb.BuilderInfo(_omitMessageNames ? '' : 'StreamData', package: const $pb.PackageName(_omitMessageNames ? '' : 'grpc'), createEmptyInstance: create)
+    ..aOM<PingArg>(1, _omitFieldNames ? '' : 'ping', subBuilder: PingArg.create)
+    ..aOM<PingResult>(2, _omitFieldNames ? '' : 'pingResult', protoName: 'pingResult', subBuilder: PingResult.create)
+    ..aOM<SyncArg>(3, _omitFieldNames ? '' : 'sync', subBuilder: SyncArg.create)
+    ..aOM<SyncData>(4, _omitFieldNames ? '' : 'syncData', protoName: 'syncData', subBuilder: SyncData.create)
+    ..aOM<CallArg>(5, _omitFieldNames ? '' : 'call', subBuilder: CallArg.create)
+    ..aOM<CallResult>(6, _omitFieldNames ? '' : 'callResult', protoName: 'callResult', subBuilder: CallResult.create)
+    ..aOM<AckArg>(7, _omitFieldNames ? '' : 'ack', subBuilder: AckArg.create)
+    ..pc<StreamMeta>(8, _omitFieldNames ? '' : 'meta', $pb.PbFieldType.PM, subBuilder: StreamMeta.create)
+    ..hasRequiredFields = false
+  ;
+
+  @$core.Deprecated(
+  'Using this can add significant overhead to your binary. '
+  'Use [GeneratedMessageGenericExtensions.deepCopy] instead. '
+  'Will be removed in next major version')
+  StreamData clone() => StreamData()..mergeFromMessage(this);
+  @$core.Deprecated(
+  'Using this can add significant overhead to your binary. '
+  'Use [GeneratedMessageGenericExtensions.rebuild] instead. '
+  'Will be removed in next major version')
+  StreamData copyWith(void Function(StreamData) updates) => super.copyWith((message) => updates(message as StreamData)) as StreamData;
+
+  $pb.BuilderInfo get info_ => _i;
+
+  @$core.pragma('dart2js:noInline')
+  static StreamData create() => StreamData._();
+  StreamData createEmptyInstance() => create();
+  static $pb.PbList<StreamData> createRepeated() => $pb.PbList<StreamData>();
+  @$core.pragma('dart2js:noInline')
+  static StreamData getDefault() => _defaultInstance ??= $pb.GeneratedMessage.$_defaultFor<StreamData>(create);
+  static StreamData? _defaultInstance;
+
+  @$pb.TagNumber(1)
+  PingArg get ping => $_getN(0);
+  @$pb.TagNumber(1)
+  set ping(PingArg v) { setField(1, v); }
+  @$pb.TagNumber(1)
+  $core.bool hasPing() => $_has(0);
+  @$pb.TagNumber(1)
+  void clearPing() => clearField(1);
+  @$pb.TagNumber(1)
+  PingArg ensurePing() => $_ensure(0);
+
+  @$pb.TagNumber(2)
+  PingResult get pingResult => $_getN(1);
+  @$pb.TagNumber(2)
+  set pingResult(PingResult v) { setField(2, v); }
+  @$pb.TagNumber(2)
+  $core.bool hasPingResult() => $_has(1);
+  @$pb.TagNumber(2)
+  void clearPingResult() => clearField(2);
+  @$pb.TagNumber(2)
+  PingResult ensurePingResult() => $_ensure(1);
+
+  @$pb.TagNumber(3)
+  SyncArg get sync => $_getN(2);
+  @$pb.TagNumber(3)
+  set sync(SyncArg v) { setField(3, v); }
+  @$pb.TagNumber(3)
+  $core.bool hasSync() => $_has(2);
+  @$pb.TagNumber(3)
+  void clearSync() => clearField(3);
+  @$pb.TagNumber(3)
+  SyncArg ensureSync() => $_ensure(2);
+
+  @$pb.TagNumber(4)
+  SyncData get syncData => $_getN(3);
+  @$pb.TagNumber(4)
+  set syncData(SyncData v) { setField(4, v); }
+  @$pb.TagNumber(4)
+  $core.bool hasSyncData() => $_has(3);
+  @$pb.TagNumber(4)
+  void clearSyncData() => clearField(4);
+  @$pb.TagNumber(4)
+  SyncData ensureSyncData() => $_ensure(3);
+
+  @$pb.TagNumber(5)
+  CallArg get call => $_getN(4);
+  @$pb.TagNumber(5)
+  set call(CallArg v) { setField(5, v); }
+  @$pb.TagNumber(5)
+  $core.bool hasCall() => $_has(4);
+  @$pb.TagNumber(5)
+  void clearCall() => clearField(5);
+  @$pb.TagNumber(5)
+  CallArg ensureCall() => $_ensure(4);
+
+  @$pb.TagNumber(6)
+  CallResult get callResult => $_getN(5);
+  @$pb.TagNumber(6)
+  set callResult(CallResult v) { setField(6, v); }
+  @$pb.TagNumber(6)
+  $core.bool hasCallResult() => $_has(5);
+  @$pb.TagNumber(6)
+  void clearCallResult() => clearField(6);
+  @$pb.TagNumber(6)
+  CallResult ensureCallResult() => $_ensure(5);
+
+  @$pb.TagNumber(7)
+  AckArg get ack => $_getN(6);
+  @$pb.TagNumber(7)
+  set ack(AckArg v) { setField(7, v); }
+  @$pb.TagNumber(7)
+  $core.bool hasAck() => $_has(6);
+  @$pb.TagNumber(7)
+  void clearAck() => clearField(7);
+  @$pb.TagNumber(7)
+  AckArg ensureAck() => $_ensure(6);
+
+  @$pb.TagNumber(8)
+  $core.List<StreamMeta> get meta => $_getList(7);
+}
+
+class StreamMeta extends $pb.GeneratedMessage {
+  factory StreamMeta({
+    $core.String? key,
+    $core.String? value,
+  }) {
+    final $result = create();
+    if (key != null) {
+      $result.key = key;
+    }
+    if (value != null) {
+      $result.value = value;
+    }
+    return $result;
+  }
+  StreamMeta._() : super();
+  factory StreamMeta.fromBuffer($core.List<$core.int> i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromBuffer(i, r);
+  factory StreamMeta.fromJson($core.String i, [$pb.ExtensionRegistry r = $pb.ExtensionRegistry.EMPTY]) => create()..mergeFromJson(i, r);
+
+  static final $pb.BuilderInfo _i = $pb.BuilderInfo(_omitMessageNames ? '' : 'StreamMeta', package: const $pb.PackageName(_omitMessageNames ? '' : 'grpc'), createEmptyInstance: create)
+    ..aOS(1, _omitFieldNames ? '' : 'key')
+    ..aOS(2, _omitFieldNames ? '' : 'value')
+    ..hasRequiredFields = false
+  ;
+
+  @$core.Deprecated(
+  'Using this can add significant overhead to your binary. '
+  'Use [GeneratedMessageGenericExtensions.deepCopy] instead. '
+  'Will be removed in next major version')
+  StreamMeta clone() => StreamMeta()..mergeFromMessage(this);
+  @$core.Deprecated(
+  'Using this can add significant overhead to your binary. '
+  'Use [GeneratedMessageGenericExtensions.rebuild] instead. '
+  'Will be removed in next major version')
+  StreamMeta copyWith(void Function(StreamMeta) updates) => super.copyWith((message) => updates(message as StreamMeta)) as StreamMeta;
+
+  $pb.BuilderInfo get info_ => _i;
+
+  @$core.pragma('dart2js:noInline')
+  static StreamMeta create() => StreamMeta._();
+  StreamMeta createEmptyInstance() => create();
+  static $pb.PbList<StreamMeta> createRepeated() => $pb.PbList<StreamMeta>();
+  @$core.pragma('dart2js:noInline')
+  static StreamMeta getDefault() => _defaultInstance ??= $pb.GeneratedMessage.$_defaultFor<StreamMeta>(create);
+  static StreamMeta? _defaultInstance;
+
+  @$pb.TagNumber(1)
+  $core.String get key => $_getSZ(0);
+  @$pb.TagNumber(1)
+  set key($core.String v) { $_setString(0, v); }
+  @$pb.TagNumber(1)
+  $core.bool hasKey() => $_has(0);
+  @$pb.TagNumber(1)
+  void clearKey() => clearField(1);
+
+  @$pb.TagNumber(2)
+  $core.String get value => $_getSZ(1);
+  @$pb.TagNumber(2)
+  set value($core.String v) { $_setString(1, v); }
+  @$pb.TagNumber(2)
+  $core.bool hasValue() => $_has(1);
+  @$pb.TagNumber(2)
+  void clearValue() => clearField(2);
 }
 
 class CallArg extends $pb.GeneratedMessage {
@@ -33,6 +33,18 @@ class ServerClient extends $grpc.Client {
       '/grpc.Server/remoteCall',
       ($0.CallArg value) => value.writeToBuffer(),
       ($core.List<$core.int> value) => $0.CallResult.fromBuffer(value));
+  static final _$remoteAck = $grpc.ClientMethod<$0.AckArg, $0.AckResult>(
+      '/grpc.Server/remoteAck',
+      ($0.AckArg value) => value.writeToBuffer(),
+      ($core.List<$core.int> value) => $0.AckResult.fromBuffer(value));
+  static final _$remoteStream = $grpc.ClientMethod<$0.StreamData, $0.StreamData>(
+      '/grpc.Server/remoteStream',
+      ($0.StreamData value) => value.writeToBuffer(),
+      ($core.List<$core.int> value) => $0.StreamData.fromBuffer(value));
+  static final _$remoteUpdate = $grpc.ClientMethod<$0.SyncData, $0.UpdateResult>(
+      '/grpc.Server/remoteUpdate',
+      ($0.SyncData value) => value.writeToBuffer(),
+      ($core.List<$core.int> value) => $0.UpdateResult.fromBuffer(value));
 
   ServerClient($grpc.ClientChannel channel,
       {$grpc.CallOptions? options,
@@ -51,6 +63,18 @@ class ServerClient extends $grpc.Client {
   $grpc.ResponseFuture<$0.CallResult> remoteCall($0.CallArg request, {$grpc.CallOptions? options}) {
     return $createUnaryCall(_$remoteCall, request, options: options);
   }
+
+  $grpc.ResponseFuture<$0.AckResult> remoteAck($async.Stream<$0.AckArg> request, {$grpc.CallOptions? options}) {
+    return $createStreamingCall(_$remoteAck, request, options: options).single;
+  }
+
+  $grpc.ResponseStream<$0.StreamData> remoteStream($async.Stream<$0.StreamData> request, {$grpc.CallOptions? options}) {
+    return $createStreamingCall(_$remoteStream, request, options: options);
+  }
+
+  $grpc.ResponseFuture<$0.UpdateResult> remoteUpdate($0.SyncData request, {$grpc.CallOptions? options}) {
+    return $createUnaryCall(_$remoteUpdate, request, options: options);
+  }
 }
 
 @$pb.GrpcServiceName('grpc.Server')
@@ -79,6 +103,27 @@ abstract class ServerServiceBase extends $grpc.Service {
         false,
         ($core.List<$core.int> value) => $0.CallArg.fromBuffer(value),
         ($0.CallResult value) => value.writeToBuffer()));
+    $addMethod($grpc.ServiceMethod<$0.AckArg, $0.AckResult>(
+        'remoteAck',
+        remoteAck,
+        true,
+        false,
+        ($core.List<$core.int> value) => $0.AckArg.fromBuffer(value),
+        ($0.AckResult value) => value.writeToBuffer()));
+    $addMethod($grpc.ServiceMethod<$0.StreamData, $0.StreamData>(
+        'remoteStream',
+        remoteStream,
+        true,
+        true,
+        ($core.List<$core.int> value) => $0.StreamData.fromBuffer(value),
+        ($0.StreamData value) => value.writeToBuffer()));
+    $addMethod($grpc.ServiceMethod<$0.SyncData, $0.UpdateResult>(
+        'remoteUpdate',
+        remoteUpdate_Pre,
+        false,
+        false,
+        ($core.List<$core.int> value) => $0.SyncData.fromBuffer(value),
+        ($0.UpdateResult value) => value.writeToBuffer()));
   }
 
   $async.Future<$0.PingResult> remotePing_Pre($grpc.ServiceCall call, $async.Future<$0.PingArg> request) async {
@@ -93,7 +138,14 @@ abstract class ServerServiceBase extends $grpc.Service {
     return remoteCall(call, await request);
   }
 
+  $async.Future<$0.UpdateResult> remoteUpdate_Pre($grpc.ServiceCall call, $async.Future<$0.SyncData> request) async {
+    return remoteUpdate(call, await request);
+  }
+
   $async.Future<$0.PingResult> remotePing($grpc.ServiceCall call, $0.PingArg request);
   $async.Stream<$0.SyncData> remoteSync($grpc.ServiceCall call, $0.SyncArg request);
   $async.Future<$0.CallResult> remoteCall($grpc.ServiceCall call, $0.CallArg request);
+  $async.Future<$0.AckResult> remoteAck($grpc.ServiceCall call, $async.Stream<$0.AckArg> request);
+  $async.Stream<$0.StreamData> remoteStream($grpc.ServiceCall call, $async.Stream<$0.StreamData> request);
+  $async.Future<$0.UpdateResult> remoteUpdate($grpc.ServiceCall call, $0.SyncData request);
 }
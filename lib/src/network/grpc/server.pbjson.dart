@@ -30,12 +30,17 @@ const PingArg$json = {
   '1': 'PingArg',
   '2': [
     {'1': 'id', '3': 1, '4': 1, '5': 11, '6': '.grpc.RequestID', '10': 'id'},
+    {'1': 'sendTime', '3': 2, '4': 1, '5': 3, '10': 'sendTime'},
+    {'1': 'lastSendTime', '3': 3, '4': 1, '5': 3, '10': 'lastSendTime'},
+    {'1': 'lastRecvTime', '3': 4, '4': 1, '5': 3, '10': 'lastRecvTime'},
   ],
 };
 
 /// Descriptor for `PingArg`. Decode as a `google.protobuf.DescriptorProto`.
 final $typed_data.Uint8List pingArgDescriptor = $convert.base64Decode(
-    'CgdQaW5nQXJnEh8KAmlkGAEgASgLMg8uZ3JwYy5SZXF1ZXN0SURSAmlk');
+    'CgdQaW5nQXJnEh8KAmlkGAEgASgLMg8uZ3JwYy5SZXF1ZXN0SURSAmlkEhoKCHNlbmRUaW1lGA'
+    'IgASgDUghzZW5kVGltZRIiCgxsYXN0U2VuZFRpbWUYAyABKANSDGxhc3RTZW5kVGltZRIiCgxs'
+    'YXN0UmVjdlRpbWUYBCABKANSDGxhc3RSZWN2VGltZQ==');
 
 @$core.Deprecated('Use pingResultDescriptor instead')
 const PingResult$json = {
@@ -44,13 +49,18 @@ const PingResult$json = {
     {'1': 'id', '3': 1, '4': 1, '5': 11, '6': '.grpc.RequestID', '10': 'id'},
     {'1': 'serverTime', '3': 2, '4': 1, '5': 3, '10': 'serverTime'},
     {'1': 'connected', '3': 3, '4': 1, '5': 5, '10': 'connected'},
+    {'1': 'session', '3': 4, '4': 1, '5': 9, '10': 'session'},
+    {'1': 'recvTime', '3': 5, '4': 1, '5': 3, '10': 'recvTime'},
+    {'1': 'sendTime', '3': 6, '4': 1, '5': 3, '10': 'sendTime'},
   ],
 };
 
 /// Descriptor for `PingResult`. Decode as a `google.protobuf.DescriptorProto`.
 final $typed_data.Uint8List pingResultDescriptor = $convert.base64Decode(
     'CgpQaW5nUmVzdWx0Eh8KAmlkGAEgASgLMg8uZ3JwYy5SZXF1ZXN0SURSAmlkEh4KCnNlcnZlcl'
-    'RpbWUYAiABKANSCnNlcnZlclRpbWUSHAoJY29ubmVjdGVkGAMgASgFUgljb25uZWN0ZWQ=');
+    'RpbWUYAiABKANSCnNlcnZlclRpbWUSHAoJY29ubmVjdGVkGAMgASgFUgljb25uZWN0ZWQSGAoH'
+    'c2Vzc2lvbhgEIAEoCVIHc2Vzc2lvbhIaCghyZWN2VGltZRgFIAEoA1IIcmVjdlRpbWUSGgoIc2'
+    'VuZFRpbWUYBiABKANSCHNlbmRUaW1l');
 
 @$core.Deprecated('Use syncDataComponentDescriptor instead')
 const SyncDataComponent$json = {
@@ -62,6 +72,7 @@ const SyncDataComponent$json = {
     {'1': 'removed', '3': 4, '4': 1, '5': 8, '10': 'removed'},
     {'1': 'props', '3': 5, '4': 1, '5': 9, '10': 'props'},
     {'1': 'triggers', '3': 6, '4': 1, '5': 9, '10': 'triggers'},
+    {'1': 'binary', '3': 7, '4': 1, '5': 12, '10': 'binary'},
   ],
 };
 
@@ -70,19 +81,22 @@ final $typed_data.Uint8List syncDataComponentDescriptor = $convert.base64Decode(
     'ChFTeW5jRGF0YUNvbXBvbmVudBIgCgtmYWN0b3J5VHlwZRgBIAEoCVILZmFjdG9yeVR5cGUSEA'
     'oDY2lkGAIgASgJUgNjaWQSFAoFb3duZXIYAyABKAlSBW93bmVyEhgKB3JlbW92ZWQYBCABKAhS'
     'B3JlbW92ZWQSFAoFcHJvcHMYBSABKAlSBXByb3BzEhoKCHRyaWdnZXJzGAYgASgJUgh0cmlnZ2'
-    'Vycw==');
+    'VycxIWCgZiaW5hcnkYByABKAxSBmJpbmFyeQ==');
 
 @$core.Deprecated('Use syncArgDescriptor instead')
 const SyncArg$json = {
   '1': 'SyncArg',
   '2': [
     {'1': 'id', '3': 1, '4': 1, '5': 11, '6': '.grpc.RequestID', '10': 'id'},
+    {'1': 'encoding', '3': 2, '4': 1, '5': 9, '10': 'encoding'},
+    {'1': 'reliable', '3': 3, '4': 1, '5': 8, '10': 'reliable'},
   ],
 };
 
 /// Descriptor for `SyncArg`. Decode as a `google.protobuf.DescriptorProto`.
 final $typed_data.Uint8List syncArgDescriptor = $convert.base64Decode(
-    'CgdTeW5jQXJnEh8KAmlkGAEgASgLMg8uZ3JwYy5SZXF1ZXN0SURSAmlk');
+    'CgdTeW5jQXJnEh8KAmlkGAEgASgLMg8uZ3JwYy5SZXF1ZXN0SURSAmlkEhoKCGVuY29kaW5nGA'
+    'IgASgJUghlbmNvZGluZxIaCghyZWxpYWJsZRgDIAEoCFIIcmVsaWFibGU=');
 
 @$core.Deprecated('Use syncDataDescriptor instead')
 const SyncData$json = {
@@ -92,6 +106,9 @@ const SyncData$json = {
     {'1': 'group', '3': 2, '4': 1, '5': 9, '10': 'group'},
     {'1': 'whole', '3': 3, '4': 1, '5': 8, '10': 'whole'},
     {'1': 'components', '3': 4, '4': 3, '5': 11, '6': '.grpc.SyncDataComponent', '10': 'components'},
+    {'1': 'sequence', '3': 5, '4': 1, '5': 3, '10': 'sequence'},
+    {'1': 'tick', '3': 6, '4': 1, '5': 3, '10': 'tick'},
+    {'1': 'time', '3': 7, '4': 1, '5': 3, '10': 'time'},
   ],
 };
 
@@ -99,7 +116,83 @@ const SyncData$json = {
 final $typed_data.Uint8List syncDataDescriptor = $convert.base64Decode(
     'CghTeW5jRGF0YRIfCgJpZBgBIAEoCzIPLmdycGMuUmVxdWVzdElEUgJpZBIUCgVncm91cBgCIA'
     'EoCVIFZ3JvdXASFAoFd2hvbGUYAyABKAhSBXdob2xlEjcKCmNvbXBvbmVudHMYBCADKAsyFy5n'
-    'cnBjLlN5bmNEYXRhQ29tcG9uZW50Ugpjb21wb25lbnRz');
+    'cnBjLlN5bmNEYXRhQ29tcG9uZW50Ugpjb21wb25lbnRzEhoKCHNlcXVlbmNlGAUgASgDUghzZX'
+    'F1ZW5jZRISCgR0aWNrGAYgASgDUgR0aWNrEhIKBHRpbWUYByABKANSBHRpbWU=');
+
+@$core.Deprecated('Use ackArgDescriptor instead')
+const AckArg$json = {
+  '1': 'AckArg',
+  '2': [
+    {'1': 'id', '3': 1, '4': 1, '5': 11, '6': '.grpc.RequestID', '10': 'id'},
+    {'1': 'sequence', '3': 2, '4': 1, '5': 3, '10': 'sequence'},
+  ],
+};
+
+/// Descriptor for `AckArg`. Decode as a `google.protobuf.DescriptorProto`.
+final $typed_data.Uint8List ackArgDescriptor = $convert.base64Decode(
+    'CgZBY2tBcmcSHwoCaWQYASABKAsyDy5ncnBjLlJlcXVlc3RJRFICaWQSGgoIc2VxdWVuY2UYAi'
+    'ABKANSCHNlcXVlbmNl');
+
+@$core.Deprecated('Use ackResultDescriptor instead')
+const AckResult$json = {
+  '1': 'AckResult',
+  '2': [
+    {'1': 'id', '3': 1, '4': 1, '5': 11, '6': '.grpc.RequestID', '10': 'id'},
+  ],
+};
+
+/// Descriptor for `AckResult`. Decode as a `google.protobuf.DescriptorProto`.
+final $typed_data.Uint8List ackResultDescriptor = $convert.base64Decode(
+    'CglBY2tSZXN1bHQSHwoCaWQYASABKAsyDy5ncnBjLlJlcXVlc3RJRFICaWQ=');
+
+@$core.Deprecated('Use updateResultDescriptor instead')
+const UpdateResult$json = {
+  '1': 'UpdateResult',
+  '2': [
+    {'1': 'id', '3': 1, '4': 1, '5': 11, '6': '.grpc.RequestID', '10': 'id'},
+  ],
+};
+
+/// Descriptor for `UpdateResult`. Decode as a `google.protobuf.DescriptorProto`.
+final $typed_data.Uint8List updateResultDescriptor = $convert.base64Decode(
+    'CgxVcGRhdGVSZXN1bHQSHwoCaWQYASABKAsyDy5ncnBjLlJlcXVlc3RJRFICaWQ=');
+
+@$core.Deprecated('Use streamDataDescriptor instead')
+const StreamData$json = {
+  '1': 'StreamData',
+  '2': [
+    {'1': 'ping', '3': 1, '4': 1, '5': 11, '6': '.grpc.PingArg', '10': 'ping'},
+    {'1': 'pingResult', '3': 2, '4': 1, '5': 11, '6': '.grpc.PingResult', '10': 'pingResult'},
+    {'1': 'sync', '3': 3, '4': 1, '5': 11, '6': '.grpc.SyncArg', '10': 'sync'},
+    {'1': 'syncData', '3': 4, '4': 1, '5': 11, '6': '.grpc.SyncData', '10': 'syncData'},
+    {'1': 'call', '3': 5, '4': 1, '5': 11, '6': '.grpc.CallArg', '10': 'call'},
+    {'1': 'callResult', '3': 6, '4': 1, '5': 11, '6': '.grpc.CallResult', '10': 'callResult'},
+    {'1': 'ack', '3': 7, '4': 1, '5': 11, '6': '.grpc.AckArg', '10': 'ack'},
+    {'1': 'meta', '3': 8, '4': 3, '5': 11, '6': '.grpc.StreamMeta', '10': 'meta'},
+  ],
+};
+
+/// Descriptor for `StreamData`. Decode as a `google.protobuf.DescriptorProto`.
+final $typed_data.Uint8List streamDataDescriptor = $convert.base64Decode(
+    'CgpTdHJlYW1EYXRhEiEKBHBpbmcYASABKAsyDS5ncnBjLlBpbmdBcmdSBHBpbmcSMAoKcGluZ1'
+    'Jlc3VsdBgCIAEoCzIQLmdycGMuUGluZ1Jlc3VsdFIKcGluZ1Jlc3VsdBIhCgRzeW5jGAMgASgL'
+    'Mg0uZ3JwYy5TeW5jQXJnUgRzeW5jEioKCHN5bmNEYXRhGAQgASgLMg4uZ3JwYy5TeW5jRGF0YV'
+    'IIc3luY0RhdGESIQoEY2FsbBgFIAEoCzINLmdycGMuQ2FsbEFyZ1IEY2FsbBIwCgpjYWxsUmVz'
+    'dWx0GAYgASgLMhAuZ3JwYy5DYWxsUmVzdWx0UgpjYWxsUmVzdWx0Eh4KA2FjaxgHIAEoCzIMLm'
+    'dycGMuQWNrQXJnUgNhY2sSJAoEbWV0YRgIIAMoCzIQLmdycGMuU3RyZWFtTWV0YVIEbWV0YQ==');
+
+@$core.Deprecated('Use streamMetaDescriptor instead')
+const StreamMeta$json = {
+  '1': 'StreamMeta',
+  '2': [
+    {'1': 'key', '3': 1, '4': 1, '5': 9, '10': 'key'},
+    {'1': 'value', '3': 2, '4': 1, '5': 9, '10': 'value'},
+  ],
+};
+
+/// Descriptor for `StreamMeta`. Decode as a `google.protobuf.DescriptorProto`.
+final $typed_data.Uint8List streamMetaDescriptor = $convert.base64Decode(
+    'CgpTdHJlYW1NZXRhEhAKA2tleRgBIAEoCVIDa2V5EhQKBXZhbHVlGAIgASgJUgV2YWx1ZQ==');
 
 @$core.Deprecated('Use callArgDescriptor instead')
 const CallArg$json = {
@@ -25,6 +25,9 @@ type ServerClient interface {
 	RemotePing(ctx context.Context, in *PingArg, opts ...grpc.CallOption) (*PingResult, error)
 	RemoteSync(ctx context.Context, in *SyncArg, opts ...grpc.CallOption) (Server_RemoteSyncClient, error)
 	RemoteCall(ctx context.Context, in *CallArg, opts ...grpc.CallOption) (*CallResult, error)
+	RemoteAck(ctx context.Context, opts ...grpc.CallOption) (Server_RemoteAckClient, error)
+	RemoteStream(ctx context.Context, opts ...grpc.CallOption) (Server_RemoteStreamClient, error)
+	RemoteUpdate(ctx context.Context, in *SyncData, opts ...grpc.CallOption) (*UpdateResult, error)
 }
 
 type serverClient struct {
@@ -85,6 +88,80 @@ func (c *serverClient) RemoteCall(ctx context.Context, in *CallArg, opts ...grpc
 	return out, nil
 }
 
+func (c *serverClient) RemoteAck(ctx context.Context, opts ...grpc.CallOption) (Server_RemoteAckClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Server_ServiceDesc.Streams[1], "/grpc.Server/remoteAck", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &serverRemoteAckClient{stream}
+	return x, nil
+}
+
+type Server_RemoteAckClient interface {
+	Send(*AckArg) error
+	CloseAndRecv() (*AckResult, error)
+	grpc.ClientStream
+}
+
+type serverRemoteAckClient struct {
+	grpc.ClientStream
+}
+
+func (x *serverRemoteAckClient) Send(m *AckArg) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *serverRemoteAckClient) CloseAndRecv() (*AckResult, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(AckResult)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *serverClient) RemoteStream(ctx context.Context, opts ...grpc.CallOption) (Server_RemoteStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Server_ServiceDesc.Streams[2], "/grpc.Server/remoteStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &serverRemoteStreamClient{stream}
+	return x, nil
+}
+
+type Server_RemoteStreamClient interface {
+	Send(*StreamData) error
+	Recv() (*StreamData, error)
+	grpc.ClientStream
+}
+
+type serverRemoteStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *serverRemoteStreamClient) Send(m *StreamData) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *serverRemoteStreamClient) Recv() (*StreamData, error) {
+	m := new(StreamData)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *serverClient) RemoteUpdate(ctx context.Context, in *SyncData, opts ...grpc.CallOption) (*UpdateResult, error) {
+	out := new(UpdateResult)
+	err := c.cc.Invoke(ctx, "/grpc.Server/remoteUpdate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ServerServer is the server API for Server service.
 // All implementations must embed UnimplementedServerServer
 // for forward compatibility
@@ -92,6 +169,9 @@ type ServerServer interface {
 	RemotePing(context.Context, *PingArg) (*PingResult, error)
 	RemoteSync(*SyncArg, Server_RemoteSyncServer) error
 	RemoteCall(context.Context, *CallArg) (*CallResult, error)
+	RemoteAck(Server_RemoteAckServer) error
+	RemoteStream(Server_RemoteStreamServer) error
+	RemoteUpdate(context.Context, *SyncData) (*UpdateResult, error)
 	mustEmbedUnimplementedServerServer()
 }
 
@@ -108,6 +188,15 @@ func (UnimplementedServerServer) RemoteSync(*SyncArg, Server_RemoteSyncServer) e
 func (UnimplementedServerServer) RemoteCall(context.Context, *CallArg) (*CallResult, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RemoteCall not implemented")
 }
+func (UnimplementedServerServer) RemoteAck(Server_RemoteAckServer) error {
+	return status.Errorf(codes.Unimplemented, "method RemoteAck not implemented")
+}
+func (UnimplementedServerServer) RemoteStream(Server_RemoteStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method RemoteStream not implemented")
+}
+func (UnimplementedServerServer) RemoteUpdate(context.Context, *SyncData) (*UpdateResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoteUpdate not implemented")
+}
 func (UnimplementedServerServer) mustEmbedUnimplementedServerServer() {}
 
 // UnsafeServerServer may be embedded to opt out of forward compatibility for this service.
@@ -178,6 +267,76 @@ func _Server_RemoteCall_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Server_RemoteAck_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ServerServer).RemoteAck(&serverRemoteAckServer{stream})
+}
+
+type Server_RemoteAckServer interface {
+	SendAndClose(*AckResult) error
+	Recv() (*AckArg, error)
+	grpc.ServerStream
+}
+
+type serverRemoteAckServer struct {
+	grpc.ServerStream
+}
+
+func (x *serverRemoteAckServer) SendAndClose(m *AckResult) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *serverRemoteAckServer) Recv() (*AckArg, error) {
+	m := new(AckArg)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Server_RemoteStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ServerServer).RemoteStream(&serverRemoteStreamServer{stream})
+}
+
+type Server_RemoteStreamServer interface {
+	Send(*StreamData) error
+	Recv() (*StreamData, error)
+	grpc.ServerStream
+}
+
+type serverRemoteStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *serverRemoteStreamServer) Send(m *StreamData) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *serverRemoteStreamServer) Recv() (*StreamData, error) {
+	m := new(StreamData)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Server_RemoteUpdate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SyncData)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServerServer).RemoteUpdate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.Server/remoteUpdate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServerServer).RemoteUpdate(ctx, req.(*SyncData))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Server_ServiceDesc is the grpc.ServiceDesc for Server service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -193,6 +352,10 @@ var Server_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "remoteCall",
 			Handler:    _Server_RemoteCall_Handler,
 		},
+		{
+			MethodName: "remoteUpdate",
+			Handler:    _Server_RemoteUpdate_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -200,6 +363,17 @@ var Server_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _Server_RemoteSync_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "remoteAck",
+			Handler:       _Server_RemoteAck_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "remoteStream",
+			Handler:       _Server_RemoteStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "server.proto",
 }
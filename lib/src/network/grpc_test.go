@@ -8,17 +8,22 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/centny/flame_network/lib/src/network/grpc"
+	"github.com/codingeasygo/util/converter"
 	"github.com/codingeasygo/util/xcrypto"
 	"github.com/codingeasygo/util/xdebug"
 	"github.com/codingeasygo/util/xmap"
 	"go.uber.org/zap/zapcore"
 	"golang.org/x/net/websocket"
 	ggrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 type TestNetworkEvent struct {
@@ -226,13 +231,14 @@ func TestGRPC(t *testing.T) {
 	if tester.Run() { //NetworkManager.tls
 		xcrypto.GenerateWebServerClient("test.loc", "test.loc", "test.loc", "127.0.0.1", 2048)
 		_, _, rootCertPEM, rootKeyPEM, _, severCertPEM, serverKeyPEM, _, clientCertPEM, clientKeyPEM, _ := xcrypto.GenerateWebServerClient("test.loc", "test.loc", "test.loc", "127.0.0.1", 2048)
-		os.WriteFile("ca.pem", rootCertPEM, os.ModePerm)
-		os.WriteFile("ca.key", rootKeyPEM, os.ModePerm)
-		os.WriteFile("server.pem", severCertPEM, os.ModePerm)
-		os.WriteFile("server.key", serverKeyPEM, os.ModePerm)
-		os.WriteFile("client.pem", clientCertPEM, os.ModePerm)
-		os.WriteFile("client.key", clientKeyPEM, os.ModePerm)
-		cer, err := tls.LoadX509KeyPair("server.pem", "server.key")
+		dir := t.TempDir()
+		os.WriteFile(filepath.Join(dir, "ca.pem"), rootCertPEM, os.ModePerm)
+		os.WriteFile(filepath.Join(dir, "ca.key"), rootKeyPEM, os.ModePerm)
+		os.WriteFile(filepath.Join(dir, "server.pem"), severCertPEM, os.ModePerm)
+		os.WriteFile(filepath.Join(dir, "server.key"), serverKeyPEM, os.ModePerm)
+		os.WriteFile(filepath.Join(dir, "client.pem"), clientCertPEM, os.ModePerm)
+		os.WriteFile(filepath.Join(dir, "client.key"), clientKeyPEM, os.ModePerm)
+		cer, err := tls.LoadX509KeyPair(filepath.Join(dir, "server.pem"), filepath.Join(dir, "server.key"))
 		if err != nil {
 			t.Error(err)
 			return
@@ -408,3 +414,537 @@ func TestGRPC(t *testing.T) {
 		transport.procKeep()
 	}
 }
+
+func TestGRPCContext(t *testing.T) {
+	newContext := func(grpcAddr, webAddr string) *NetworkContext {
+		ctx := NewNetworkContext()
+		ctx.Network.IsServer = true
+		ctx.Network.IsClient = true
+		ctx.Network.SetGroup("test")
+		ctx.Network.SetKey("test")
+		transport := NewNetworkTransportGRPCByContext(ctx)
+		transport.GrpcAddress, _ = url.Parse(grpcAddr)
+		transport.WebAddress, _ = url.Parse(webAddr)
+		ctx.SetTransport(transport)
+		return ctx
+	}
+	newComponent := func(ctx *NetworkContext, name string) *NetworkComponent {
+		c := NewNetworkComponentByContext(ctx, "test", "test", "", "123")
+		c.RegisterNetworkCall("name", func(s NetworkSession, uuid string) (string, error) { return name, nil })
+		return c
+	}
+	ctx0 := newContext("grpc://127.0.0.1:50062", "ws://127.0.0.1:50063")
+	ctx1 := newContext("grpc://127.0.0.1:50064", "ws://127.0.0.1:50065")
+	c0 := newComponent(ctx0, "c0")
+	c1 := newComponent(ctx1, "c1")
+	for _, ctx := range []*NetworkContext{ctx0, ctx1} {
+		if err := ctx.Network.Start(); err != nil {
+			t.Error(err)
+			return
+		}
+		if err := ctx.Network.Ready(); err != nil {
+			t.Error(err)
+			return
+		}
+	}
+	var ret0, ret1 string
+	if err := c0.NetworkCall("name", nil, &ret0); err != nil || ret0 != "c0" {
+		t.Errorf("err:%v,ret:%v", err, ret0)
+		return
+	}
+	if err := c1.NetworkCall("name", nil, &ret1); err != nil || ret1 != "c1" {
+		t.Errorf("err:%v,ret:%v", err, ret1)
+		return
+	}
+	ctx0.Network.Stop()
+	ctx1.Network.Stop()
+}
+
+func TestGRPCEncoding(t *testing.T) {
+	server := NewNetworkContext()
+	server.Network.IsServer = true
+	server.Network.SetGroup("test")
+	transport := NewNetworkTransportGRPCByContext(server)
+	transport.GrpcAddress, _ = url.Parse("grpc://127.0.0.1:50066")
+	transport.WebAddress, _ = url.Parse("ws://127.0.0.1:50067")
+	server.SetTransport(transport)
+	sc := NewNetworkComponentByContext(server, "test", "", "", "123")
+	sc.SetValue("p0", 1)
+	sc.SetValue("p1", TestBinaryVec{1.5, 2.5})
+	sc.RegisterNetworkProp()
+	sc.RegisterNetworkTrigger("t0", func(v float64) {})
+	if err := server.Network.Start(); err != nil {
+		t.Error(err)
+		return
+	}
+	defer server.Network.Stop()
+	for _, encoding := range []string{SyncEncodingBinary, SyncEncodingJSON} {
+		sc.SetValue("p0", 1)
+		client := NewNetworkContext()
+		client.Network.IsClient = true
+		client.Network.SyncEncoding = encoding
+		client.Network.SetGroup("test")
+		client.Network.SetKey("test-" + encoding)
+		transport := NewNetworkTransportGRPCByContext(client)
+		transport.GrpcAddress, _ = url.Parse("grpc://127.0.0.1:50066")
+		client.SetTransport(transport)
+		synced := make(chan xmap.M, 8)
+		triggered := make(chan float64, 8)
+		ticks := make(chan *NetworkComponent, 8)
+		client.ComponentHub.RegisterFactory("test", "", func(key, group, owner, cid string) (c *NetworkComponent, err error) {
+			c = NewNetworkComponentByContext(client, key, group, owner, cid)
+			c.RegisterNetworkTrigger("t0", func(v float64) { triggered <- v })
+			c.OnNetworkSynced = func() {
+				ticks <- &NetworkComponent{SyncTick: c.SyncTick, SyncTime: c.SyncTime}
+				synced <- c.SendNetworkProp(true)
+			}
+			return
+		})
+		if err := client.Network.Start(); err != nil {
+			t.Error(err)
+			return
+		}
+		if err := client.Network.Ready(); err != nil {
+			t.Error(err)
+			return
+		}
+		props := <-synced
+		p1 := props.ArrayFloat64Def(nil, "p1")
+		if props.Int64Def(0, "p0") != 1 || len(p1) != 2 || p1[0] != 1.5 || p1[1] != 2.5 {
+			t.Errorf("%v,%v", encoding, props)
+			return
+		}
+		tick0 := <-ticks
+		if tick0.SyncTick < 1 || time.Since(tick0.SyncTime) > time.Second {
+			t.Errorf("%v,%v,%v", encoding, tick0.SyncTick, tick0.SyncTime)
+			return
+		}
+		time.Sleep(server.Network.MinSync)
+		sc.SetValue("p0", 2)
+		sc.NetworkTrigger("t0", 1.5)
+		server.Network.Sync("", nil)
+		props = <-synced
+		if props.Str("p0") != "2" || <-triggered != 1.5 {
+			t.Errorf("%v,%v", encoding, props)
+			return
+		}
+		if tick1 := <-ticks; tick1.SyncTick <= tick0.SyncTick || tick1.SyncTick != server.Network.Tick() || tick1.SyncTime.Before(tick0.SyncTime) {
+			t.Errorf("%v,%v,%v", encoding, tick0.SyncTick, tick1.SyncTick)
+			return
+		}
+		client.Network.Stop()
+	}
+}
+
+func TestGRPCParseBinary(t *testing.T) {
+	quantum := 1.0 / 256
+	c := &NetworkSyncDataComponent{
+		Factory:  "test",
+		CID:      "c1",
+		Props:    xmap.M{"x": 1.0 + 3*quantum, "y": 2.0, "v": []float64{-5 * quantum, 7 * quantum}},
+		Triggers: xmap.M{"t0": []interface{}{11 * quantum}},
+		Quantum:  map[string]float64{"x": quantum, "v": quantum},
+	}
+	c.Props = QuantizeProp(c.Props, c.Quantum)
+	sd := ParseSyncDataByEncoderGRPC(&NetworkSyncData{UUID: "123", Components: []*NetworkSyncDataComponent{c}}, NewNetworkBinaryEncoder())
+	data := ParseNetworkSyncDataByDecoderGRPC(sd, NewNetworkBinaryDecoder())
+	if len(data.Components) != 1 {
+		t.Errorf("%v", converter.JSON(data))
+		return
+	}
+	props, triggers := data.Components[0].Props, data.Components[0].Triggers
+	x := DecodePropValue(0.0, props["x"])
+	y := DecodePropValue(nil, props["y"])
+	v := DecodePropValue([]float64{}, props["v"]).([]float64)
+	t0 := DecodePropValue(0.0, triggers["t0"].([]interface{})[0])
+	if x != 1.0+3*quantum || y != 2.0 || len(v) != 2 || v[0] != -5*quantum || v[1] != 7*quantum || t0 != 11*quantum {
+		t.Errorf("%v,%v,%v,%v,%v", props, triggers, x, y, v)
+		return
+	}
+}
+
+type TestUnaryServerGRPC struct {
+	*NetworkServerGRPC
+}
+
+func (t *TestUnaryServerGRPC) RemoteStream(stream grpc.Server_RemoteStreamServer) (err error) {
+	err = status.Errorf(codes.Unimplemented, "method RemoteStream not implemented")
+	return
+}
+
+func TestGRPCStream(t *testing.T) {
+	for _, unary := range []bool{false, true} {
+		server := NewNetworkContext()
+		server.Network.IsServer = true
+		transport := NewNetworkTransportGRPCByContext(server)
+		transport.GrpcAddress, _ = url.Parse("grpc://127.0.0.1:50074")
+		transport.WebAddress, _ = url.Parse("ws://127.0.0.1:50075")
+		if unary {
+			transport.GrpcServer = ggrpc.NewServer()
+			grpc.RegisterServerServer(transport.GrpcServer, &TestUnaryServerGRPC{NetworkServerGRPC: transport.Server})
+		}
+		server.SetTransport(transport)
+		sc := NewNetworkComponentByContext(server, "test", "", "", "c1")
+		sc.SetValue("x", 1)
+		sc.RegisterNetworkProp()
+		sc.RegisterNetworkCall("add", func(ctx NetworkSession, uuid string, v int) (r int, err error) {
+			r = v + 1
+			return
+		})
+		sc.RegisterNetworkCall("ping", func(ctx NetworkSession, uuid string) (r int64, err error) {
+			r = int64(SessionConnection(ctx).(NetworkPingConnection).PingStats().RTT)
+			return
+		})
+		if err := server.Network.Start(); err != nil {
+			t.Error(err)
+			return
+		}
+
+		client := NewNetworkContext()
+		client.Network.IsClient = true
+		client.Network.SetKey("stream")
+		clientTransport := NewNetworkTransportGRPCByContext(client)
+		clientTransport.GrpcAddress, _ = url.Parse("grpc://127.0.0.1:50074")
+		client.SetTransport(clientTransport)
+		synced := make(chan string, 8)
+		client.ComponentHub.RegisterFactory("test", "", func(key, group, owner, cid string) (c *NetworkComponent, err error) {
+			c = NewNetworkComponentByContext(client, key, group, owner, cid)
+			c.RegisterNetworkProp()
+			c.OnNetworkSynced = func() { synced <- c.Str("x") }
+			return
+		})
+		if err := client.Network.Start(); err != nil {
+			t.Error(err)
+			return
+		}
+		if err := client.Network.Ready(); err != nil {
+			t.Error(err)
+			return
+		}
+		if x := <-synced; x != "1" {
+			t.Errorf("%v,%v", unary, x)
+			return
+		}
+		if (clientTransport.Client.stream == nil) != unary || (clientTransport.Client.sync == nil) == unary {
+			t.Errorf("%v", unary)
+			return
+		}
+		cc := client.ComponentHub.FindComponent("c1")
+		for i := 0; i < 10; i++ {
+			var r int
+			if err := cc.NetworkCall("add", i, &r); err != nil || r != i+1 {
+				t.Errorf("%v,%v,%v", unary, err, r)
+				return
+			}
+		}
+		if err := cc.NetworkCall("none", 1, nil); err == nil {
+			t.Errorf("%v", unary)
+			return
+		}
+		if _, serverTime, err := clientTransport.Client.Ping(); err != nil || serverTime.IsZero() {
+			t.Errorf("%v,%v", unary, err)
+			return
+		}
+		if _, _, err := clientTransport.Client.Ping(); err != nil || clientTransport.Client.Connected() != 1 {
+			t.Errorf("%v,%v,%v", unary, err, clientTransport.Client.Connected())
+			return
+		}
+		var ping int64
+		if err := cc.NetworkCall("ping", nil, &ping); err != nil || ping <= 0 {
+			t.Errorf("%v,%v,%v", unary, err, ping)
+			return
+		}
+		if stats := clientTransport.Client.PingStats(); stats.Samples < 2 || stats.RTT <= 0 || stats.Offset > time.Second {
+			t.Errorf("%v,%v", unary, stats)
+			return
+		}
+		time.Sleep(server.Network.MinSync)
+		sc.SetValue("x", 2)
+		server.Network.Sync("", nil)
+		if x := <-synced; x != "2" {
+			t.Errorf("%v,%v", unary, x)
+			return
+		}
+		client.Network.Stop()
+		server.Network.Stop()
+	}
+}
+
+func TestGRPCPublish(t *testing.T) {
+	server := NewNetworkContext()
+	server.Network.IsServer = true
+	server.ComponentHub.RegisterAuthority("test", "x", true)
+	transport := NewNetworkTransportGRPCByContext(server)
+	transport.GrpcAddress, _ = url.Parse("grpc://127.0.0.1:50076")
+	transport.WebAddress, _ = url.Parse("ws://127.0.0.1:50077")
+	server.SetTransport(transport)
+	sc := NewNetworkComponentByContext(server, "test", "", "u1", "c1")
+	sc.SetValue("x", 0)
+	sc.SetValue("y", 0)
+	sc.RegisterNetworkProp()
+	sc.RegisterNetworkCall("join", func(ctx NetworkSession, uuid string, user string) (err error) {
+		ctx.SetUser(user)
+		return
+	})
+	if err := server.Network.Start(); err != nil {
+		t.Error(err)
+		return
+	}
+	defer server.Network.Stop()
+	newClient := func(key string, stream bool) (client *NetworkContext, synced chan string) {
+		client = NewNetworkContext()
+		client.Network.IsClient = true
+		client.Network.SetKey(key)
+		client.Network.SetUser(key)
+		client.ComponentHub.RegisterAuthority("test", "x", true)
+		clientTransport := NewNetworkTransportGRPCByContext(client)
+		clientTransport.GrpcAddress, _ = url.Parse("grpc://127.0.0.1:50076")
+		clientTransport.StreamOn = stream
+		client.SetTransport(clientTransport)
+		synced = make(chan string, 64)
+		client.ComponentHub.RegisterFactory("test", "", func(key, group, owner, cid string) (c *NetworkComponent, err error) {
+			c = NewNetworkComponentByContext(client, key, group, owner, cid)
+			c.RegisterNetworkProp()
+			c.OnNetworkSynced = func() { synced <- c.Str("x") }
+			return
+		})
+		if err := client.Network.Start(); err != nil {
+			panic(err)
+		}
+		if err := client.Network.Ready(); err != nil {
+			panic(err)
+		}
+		<-synced
+		return
+	}
+	other, _ := newClient("u2", true)
+	defer other.Network.Stop()
+	for i, stream := range []bool{true, false} {
+		owner, _ := newClient("u1", stream)
+		oc := owner.ComponentHub.FindComponent("c1")
+		if err := oc.NetworkCall("join", "u1", nil); err != nil {
+			t.Error(err)
+			return
+		}
+		value := fmt.Sprintf("%v", i+1)
+		time.Sleep(owner.Network.MinSync)
+		oc.SetValue("x", i+1)
+		oc.SetValue("y", i+1)
+		if !owner.Network.Sync("", nil) {
+			t.Errorf("%v", stream)
+			return
+		}
+		for i := 0; i < 100 && sc.Int("x") == 0; i++ {
+			time.Sleep(10 * time.Millisecond)
+		}
+		if sc.Str("x") != value || sc.Int("y") != 0 {
+			t.Errorf("%v,%v,%v", stream, sc.Str("x"), sc.Int("y"))
+			return
+		}
+		oc2 := other.ComponentHub.FindComponent("c1")
+		for i := 0; i < 100 && oc2.Str("x") != value; i++ {
+			time.Sleep(10 * time.Millisecond)
+		}
+		if x := oc2.Str("x"); x != value {
+			t.Errorf("%v,%v", stream, x)
+			return
+		}
+		owner.Network.Stop()
+		sc.SetValue("x", 0)
+	}
+}
+
+type TestSessionEvent struct {
+	states chan string
+}
+
+func (t *TestSessionEvent) OnNetworkState(all NetworkConnectionSet, conn NetworkConnection, state NetworkState, info interface{}) {
+	t.states <- fmt.Sprintf("%v,%v", state, info)
+}
+
+func (t *TestSessionEvent) OnNetworkPing(conn NetworkConnection, ping time.Duration) {
+}
+
+func (t *TestSessionEvent) OnNetworkDataSynced(conn NetworkConnection, data *NetworkSyncData) {
+}
+
+func (t *TestSessionEvent) OnNetworkGroup(conn NetworkConnection, from, to string) {
+	t.states <- fmt.Sprintf("group,%v,%v", from, to)
+}
+
+func TestGRPCSession(t *testing.T) {
+	server := NewNetworkContext()
+	server.Network.IsServer = true
+	server.Network.SessionGrace = 300 * time.Millisecond
+	event := &TestSessionEvent{states: make(chan string, 64)}
+	server.EventHub.RegisterNetworkEvent("*", event)
+	transport := NewNetworkTransportGRPCByContext(server)
+	transport.GrpcAddress, _ = url.Parse("grpc://127.0.0.1:50083")
+	transport.WebAddress, _ = url.Parse("ws://127.0.0.1:50084")
+	server.SetTransport(transport)
+	sc := NewNetworkComponentByContext(server, "test", "", "", "c1")
+	sc.SetValue("x", 1)
+	sc.RegisterNetworkProp()
+	sc.RegisterNetworkCall("join", func(ctx NetworkSession, uuid string, user string) (err error) {
+		ctx.SetUser(user)
+		return
+	})
+	sc.RegisterNetworkCall("whoami", func(ctx NetworkSession, uuid string, v int) (r string, err error) {
+		r = ctx.User()
+		return
+	})
+	if err := server.Network.Start(); err != nil {
+		t.Error(err)
+		return
+	}
+	defer server.Network.Stop()
+	for _, streamOn := range []bool{true, false} {
+		client := NewNetworkContext()
+		client.Network.IsClient = true
+		clientTransport := NewNetworkTransportGRPCByContext(client)
+		clientTransport.StreamOn = streamOn
+		clientTransport.GrpcAddress, _ = url.Parse("grpc://127.0.0.1:50083")
+		client.SetTransport(clientTransport)
+		synced := make(chan string, 64)
+		register := func() { //the factory is cleared on stop
+			client.ComponentHub.RegisterFactory("test", "", func(key, group, owner, cid string) (c *NetworkComponent, err error) {
+				c = NewNetworkComponentByContext(client, key, group, owner, cid)
+				c.RegisterNetworkProp()
+				c.OnNetworkSynced = func() { synced <- c.Str("x") }
+				return
+			})
+		}
+		register()
+		//issued on first contact
+		if err := client.Network.Start(); err != nil {
+			t.Error(err)
+			return
+		}
+		key := client.Network.Key()
+		if len(key) != 32 {
+			t.Errorf("%v,%v", streamOn, key)
+			return
+		}
+		if _, err := client.Network.NetworkCall(&NetworkCallArg{CID: "c1", Name: "join", Arg: `"u1"`}); err != nil {
+			t.Error(err)
+			return
+		}
+		if err := client.Network.Ready(); err != nil {
+			t.Error(err)
+			return
+		}
+		if state := <-event.states; state != "200,<nil>" || <-synced != "1" {
+			t.Errorf("%v,%v", streamOn, state)
+			return
+		}
+		//resume in grace
+		client.Network.Stop()
+		time.Sleep(100 * time.Millisecond)
+		if len(event.states) != 0 {
+			t.Errorf("%v,%v", streamOn, <-event.states)
+			return
+		}
+		register()
+		if err := client.Network.Start(); err != nil {
+			t.Error(err)
+			return
+		}
+		if err := client.Network.Ready(); err != nil {
+			t.Error(err)
+			return
+		}
+		if state := <-event.states; state != "200,resumed" || <-synced != "1" || client.Network.Key() != key {
+			t.Errorf("%v,%v", streamOn, state)
+			return
+		}
+		ret, err := client.Network.NetworkCall(&NetworkCallArg{CID: "c1", Name: "whoami", Arg: "1"})
+		if err != nil || ret.Result != `"u1"` {
+			t.Errorf("%v,%v,%v", streamOn, err, ret)
+			return
+		}
+		//closed after grace
+		client.Network.Stop()
+		startTime := time.Now()
+		if state := <-event.states; state != "400,<nil>" || time.Since(startTime) < 200*time.Millisecond {
+			t.Errorf("%v,%v,%v", streamOn, state, time.Since(startTime))
+			return
+		}
+		//made up key is not trusted
+		client.Network.SetKey("made-up")
+		if err := client.Network.Start(); err != nil {
+			t.Error(err)
+			return
+		}
+		if having := client.Network.Key(); having == "made-up" || having == key || len(having) != 32 {
+			t.Errorf("%v,%v", streamOn, having)
+			return
+		}
+		ret, err = client.Network.NetworkCall(&NetworkCallArg{CID: "c1", Name: "whoami", Arg: "1"})
+		if err != nil || ret.Result != `""` {
+			t.Errorf("%v,%v,%v", streamOn, err, ret)
+			return
+		}
+		client.Network.Stop()
+	}
+}
+
+type TestAckServerGRPC struct {
+	ggrpc.ServerStream
+	ctx    context.Context
+	ackAll []*grpc.AckArg
+}
+
+func (t *TestAckServerGRPC) Context() context.Context {
+	return t.ctx
+}
+
+func (t *TestAckServerGRPC) Recv() (ack *grpc.AckArg, err error) {
+	if len(t.ackAll) < 1 {
+		err = io.EOF
+		return
+	}
+	ack, t.ackAll = t.ackAll[0], t.ackAll[1:]
+	return
+}
+
+func (t *TestAckServerGRPC) SendAndClose(*grpc.AckResult) error {
+	return nil
+}
+
+func TestGRPCAck(t *testing.T) {
+	ctx := NewNetworkContext()
+	ctx.Network.IsServer = true
+	server := NewNetworkServerGRPCByContext(ctx, ctx.Network)
+	c1 := NewNetworkComponentByContext(ctx, "test", "", "", "c1")
+	c1.SetValue("x", 1)
+	c1.RegisterNetworkProp()
+	owner := server.keepSession(NewDefaultNetworkSessionBySafeM())
+	other := server.keepSession(NewDefaultNetworkSessionBySafeM())
+	stream := NewNetworkSyncStreamGRPC(owner, nil)
+	stream.syncID = "s1"
+	stream.SetReliable(true)
+	server.syncAll[stream.syncID] = stream
+	stream.reliable.Filter(ctx, stream, NewNetworkSyncDataByHub(ctx.ComponentHub, "", true))
+	ack := func(session NetworkSession) {
+		md := metadata.New(map[string]string{"key": session.Key()})
+		err := server.RemoteAck(&TestAckServerGRPC{
+			ctx:    metadata.NewIncomingContext(context.Background(), md),
+			ackAll: []*grpc.AckArg{{Id: &grpc.RequestID{Uuid: "s1"}, Sequence: 1}},
+		})
+		if err != nil {
+			t.Error(err)
+		}
+	}
+	//other session can't ack
+	ack(other.session)
+	if stream.reliable.Pending() != 1 {
+		t.Errorf("%v", stream.reliable.Pending())
+		return
+	}
+	//owner ack
+	ack(owner.session)
+	if stream.reliable.Pending() != 0 {
+		t.Errorf("%v", stream.reliable.Pending())
+		return
+	}
+}
@@ -0,0 +1,192 @@
+package network
+
+import (
+	"sync"
+	"time"
+
+	"github.com/codingeasygo/util/xmap"
+)
+
+type networkHistorySnapshot struct {
+	Tick   int64
+	Time   time.Time
+	Values map[string]interface{}
+}
+
+// NetworkHistory is the snapshot buffer of props recorded by server tick, it is used to rewind the component
+// to the time which client saw it, so the hit detection on server can be compensated by client lag
+type NetworkHistory struct {
+	Duration        time.Duration // the max duration of snapshot to keep
+	interpolatorAll map[string]NetworkInterpolator
+	snapshotAll     []*networkHistorySnapshot
+	lock            sync.RWMutex
+}
+
+func NewNetworkHistory(duration time.Duration) (history *NetworkHistory) {
+	history = &NetworkHistory{
+		Duration:        duration,
+		interpolatorAll: map[string]NetworkInterpolator{},
+	}
+	return
+}
+
+// Register will record the prop by name, the rewound value between two snapshot is interpolated by interpolator
+func (n *NetworkHistory) Register(name string, interpolator NetworkInterpolator) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.interpolatorAll[name] = interpolator
+}
+
+func (n *NetworkHistory) Unregister(name string) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	delete(n.interpolatorAll, name)
+}
+
+// Record will add the snapshot of registered props on tick, the snapshot older than Duration is removed
+func (n *NetworkHistory) Record(tick int64, at time.Time, values xmap.Valuable) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	snapshot := &networkHistorySnapshot{Tick: tick, Time: at, Values: map[string]interface{}{}}
+	for k := range n.interpolatorAll {
+		v, err := values.ValueVal(k)
+		if err != nil {
+			continue
+		}
+		value, err := NormalizeBinaryValue(v)
+		if err != nil {
+			value = v //not normalized value is stepped
+		}
+		snapshot.Values[k] = value
+	}
+	if len(n.snapshotAll) > 0 && !at.After(n.snapshotAll[len(n.snapshotAll)-1].Time) {
+		n.snapshotAll[len(n.snapshotAll)-1] = snapshot
+	} else {
+		n.snapshotAll = append(n.snapshotAll, snapshot)
+	}
+	expired := 0
+	for expired < len(n.snapshotAll)-1 && at.Sub(n.snapshotAll[expired].Time) > n.Duration {
+		expired++
+	}
+	n.snapshotAll = n.snapshotAll[expired:]
+}
+
+// Clear will remove all snapshot
+func (n *NetworkHistory) Clear() {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.snapshotAll = nil
+}
+
+// Tick will return the tick of last snapshot recorded at or before time
+func (n *NetworkHistory) Tick(at time.Time) (tick int64, ok bool) {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+	for i := len(n.snapshotAll) - 1; i >= 0; i-- {
+		if !n.snapshotAll[i].Time.After(at) {
+			tick, ok = n.snapshotAll[i].Tick, true
+			return
+		}
+	}
+	return
+}
+
+// Value will return the prop value interpolated at time, it is clamped to the oldest and newest snapshot
+func (n *NetworkHistory) Value(name string, at time.Time) (value interface{}, ok bool) {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+	interpolator := n.interpolatorAll[name]
+	if interpolator == nil || len(n.snapshotAll) < 1 {
+		return
+	}
+	var prev, next *networkHistorySnapshot
+	for _, snapshot := range n.snapshotAll {
+		if _, having := snapshot.Values[name]; !having {
+			continue
+		}
+		if snapshot.Time.After(at) {
+			next = snapshot
+			break
+		}
+		prev = snapshot
+	}
+	switch {
+	case prev == nil && next == nil:
+		return
+	case prev == nil:
+		value, ok = next.Values[name], true
+	case next == nil:
+		value, ok = prev.Values[name], true
+	default:
+		t := float64(at.Sub(prev.Time)) / float64(next.Time.Sub(prev.Time))
+		value, ok = interpolator(prev.Values[name], next.Values[name], t), true
+	}
+	return
+}
+
+// Values will return all registered prop values interpolated at time
+func (n *NetworkHistory) Values(at time.Time) (values xmap.M) {
+	n.lock.RLock()
+	names := make([]string, 0, len(n.interpolatorAll))
+	for k := range n.interpolatorAll {
+		names = append(names, k)
+	}
+	n.lock.RUnlock()
+	values = xmap.M{}
+	for _, name := range names {
+		if value, ok := n.Value(name, at); ok {
+			values[name] = value
+		}
+	}
+	return
+}
+
+// RewoundValue will return the prop value at the time which conn saw the component, it is fallback to current value when History is nil
+func (n *NetworkComponent) RewoundValue(conn NetworkConnection, name string) (value interface{}, ok bool) {
+	value, ok = n.RewoundValueAt(n.Context.Network.RewindTime(conn), name)
+	return
+}
+
+// RewoundValueAt will return the prop value at the time which is captured by RewindTime, it is fallback to current value when History is nil
+func (n *NetworkComponent) RewoundValueAt(at time.Time, name string) (value interface{}, ok bool) {
+	if n.History != nil {
+		value, ok = n.History.Value(name, at)
+	}
+	if !ok {
+		var err error
+		value, err = n.ValueVal(name)
+		ok = err == nil
+	}
+	return
+}
+
+func (n *NetworkComponent) recordNetworkHistory(tick int64, at time.Time) {
+	if n.History != nil {
+		n.History.Record(tick, at, n.SafeM)
+	}
+}
+
+// RewindTime will return the server time which conn saw the components, it is now - rtt/2 - RewindDelay and limited by MaxRewind,
+// the rtt is read from NetworkPingConnection and it is zero when conn is not measured
+func (n *NetworkManager) RewindTime(conn NetworkConnection) time.Time {
+	rewind := n.RewindDelay
+	if ping, ok := conn.(NetworkPingConnection); ok {
+		rewind += ping.PingStats().RTT / 2
+	}
+	if n.MaxRewind > 0 && rewind > n.MaxRewind {
+		rewind = n.MaxRewind
+	}
+	return time.Now().Add(-rewind)
+}
+
+// Rewind will return the prop values of components at the time which conn saw them by cid, the component without History is skipped
+func (n *NetworkManager) Rewind(conn NetworkConnection, components ...*NetworkComponent) (rewound map[string]xmap.M) {
+	at := n.RewindTime(conn)
+	rewound = map[string]xmap.M{}
+	for _, c := range components {
+		if c.History != nil {
+			rewound[c.CID] = c.History.Values(at)
+		}
+	}
+	return
+}
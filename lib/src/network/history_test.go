@@ -0,0 +1,144 @@
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/codingeasygo/util/xmap"
+)
+
+func TestHistory(t *testing.T) {
+	history := NewNetworkHistory(time.Second)
+	history.Register("x", InterpolateNumber)
+	history.Register("v", InterpolateVector)
+	history.Register("s", interpolateStep)
+	if _, ok := history.Value("x", time.Now()); ok {
+		t.Error("error")
+		return
+	}
+	if _, ok := history.Tick(time.Now()); ok {
+		t.Error("error")
+		return
+	}
+	now := time.Now()
+	history.Record(1, now, xmap.M{"x": 0, "v": []float64{0, 0}, "s": "a"})
+	history.Record(2, now.Add(100*time.Millisecond), xmap.M{"x": 10, "v": []float64{10, 20}, "s": "b"})
+	history.Record(3, now.Add(200*time.Millisecond), xmap.M{"x": 20, "s": "c"})
+	if v, ok := history.Value("x", now.Add(50*time.Millisecond)); !ok || v != 5.0 {
+		t.Errorf("%v,%v", v, ok)
+		return
+	}
+	if v, ok := history.Value("v", now.Add(150*time.Millisecond)); !ok || v.([]float64)[1] != 20 {
+		t.Errorf("%v,%v", v, ok)
+		return
+	}
+	if v, ok := history.Value("s", now.Add(150*time.Millisecond)); !ok || v != "b" {
+		t.Errorf("%v,%v", v, ok)
+		return
+	}
+	if v, ok := history.Value("x", now.Add(-time.Second)); !ok || v != int64(0) {
+		t.Errorf("%v,%v", v, ok)
+		return
+	}
+	if v, ok := history.Value("x", now.Add(time.Second)); !ok || v != int64(20) {
+		t.Errorf("%v,%v", v, ok)
+		return
+	}
+	if v, ok := history.Value("none", now); ok {
+		t.Errorf("%v,%v", v, ok)
+		return
+	}
+	if tick, ok := history.Tick(now.Add(150 * time.Millisecond)); !ok || tick != 2 {
+		t.Errorf("%v,%v", tick, ok)
+		return
+	}
+	if values := history.Values(now.Add(50 * time.Millisecond)); values["x"] != 5.0 || len(values) != 3 {
+		t.Errorf("%v", values)
+		return
+	}
+	//replace
+	history.Record(4, now.Add(200*time.Millisecond), xmap.M{"x": 30})
+	if v, _ := history.Value("x", now.Add(time.Second)); v != int64(30) {
+		t.Errorf("%v", v)
+		return
+	}
+	//expired
+	history.Record(5, now.Add(1050*time.Millisecond), xmap.M{"x": 40})
+	if v, _ := history.Value("x", now); v != int64(10) {
+		t.Errorf("%v", v)
+		return
+	}
+	history.Unregister("s")
+	history.Clear()
+	if _, ok := history.Value("x", now); ok {
+		t.Error("error")
+		return
+	}
+}
+
+type testPingConnection struct {
+	*TestNetworkConnection
+	rtt time.Duration
+}
+
+func (t *testPingConnection) PingStats() NetworkPingStats {
+	return NetworkPingStats{RTT: t.rtt}
+}
+
+func TestHistoryRewind(t *testing.T) {
+	ctx := NewNetworkContext()
+	ctx.Network.IsServer = true
+	ctx.Network.MinSync = 0
+	ctx.Network.RewindDelay = 50 * time.Millisecond
+	ctx.SetTransport(NewNetworkTransportLoopbackByContext(ctx, NewNetworkLoopback()))
+	c := NewNetworkComponentByContext(ctx, "test", "history", "", "c1")
+	c.History = NewNetworkHistory(time.Second)
+	c.History.Register("x", InterpolateNumber)
+	c.SetValue("x", 0)
+	c.RegisterNetworkProp()
+	other := NewNetworkComponentByContext(ctx, "test", "history", "", "c2")
+	other.SetValue("x", 0)
+	other.RegisterNetworkProp()
+	for i := 1; i <= 10; i++ {
+		c.SetValue("x", i*10)
+		ctx.Network.Sync("history", nil)
+		time.Sleep(20 * time.Millisecond)
+	}
+	if tick, _ := c.History.Tick(time.Now()); tick != ctx.Network.Tick() {
+		t.Errorf("%v,%v", tick, ctx.Network.Tick())
+		return
+	}
+	session := &testPingConnection{TestNetworkConnection: &TestNetworkConnection{session: NewDefaultNetworkSessionBySafeM()}, rtt: 100 * time.Millisecond}
+	rewound := ctx.Network.Rewind(session, c, other)
+	x := rewound["c1"].Float64Def(0, "x")
+	if len(rewound) != 1 || x < 30 || x > 80 {
+		t.Errorf("%v", rewound)
+		return
+	}
+	v, ok := c.RewoundValue(session, "x")
+	if x := (xmap.M{"x": v}).Float64Def(0, "x"); !ok || x < 30 || x > 80 {
+		t.Errorf("%v,%v", v, ok)
+		return
+	}
+	if v, ok := other.RewoundValue(session, "x"); !ok || v != 0 {
+		t.Errorf("%v,%v", v, ok)
+		return
+	}
+	at := ctx.Network.RewindTime(session)
+	session.rtt = 0 //captured time is not changed by ping
+	v, ok = c.RewoundValueAt(at, "x")
+	if x := (xmap.M{"x": v}).Float64Def(0, "x"); !ok || x < 30 || x > 80 {
+		t.Errorf("%v,%v", v, ok)
+		return
+	}
+	if delay := NewNetworkContext().Network.RewindDelay; delay != DefaultInterpolationDelay {
+		t.Errorf("%v", delay)
+		return
+	}
+	ctx.Network.MaxRewind = 10 * time.Millisecond
+	v, _ = c.RewoundValue(session, "x")
+	if x := (xmap.M{"x": v}).Float64Def(0, "x"); x < 90 {
+		t.Errorf("%v", v)
+		return
+	}
+}
@@ -0,0 +1,234 @@
+package network
+
+import (
+	"math"
+	"sync"
+
+	"github.com/codingeasygo/util/xmap"
+)
+
+// InterestPolicy will decide which components the connection should see, the component not interested is despawned from connection
+type InterestPolicy interface {
+	Interest(conn NetworkConnection, all NetworkComponentSet) (interested NetworkComponentSet)
+}
+
+// NetworkPosition will return the position of component by prop key, the prop value can be number, float list or json.Marshaler of float list
+func NetworkPosition(c *NetworkComponent, key string) (pos []float64, ok bool) {
+	v, err := c.ValueVal(key)
+	if err != nil || v == nil {
+		return
+	}
+	value, err := NormalizeBinaryValue(v)
+	if err != nil {
+		return
+	}
+	if f, isFloat := interpolationFloat(value); isFloat {
+		pos, ok = []float64{f}, true
+		return
+	}
+	pos, ok = interpolationFloats(value)
+	return
+}
+
+// interestViewer return the component position owned by connection user, the component owned by user or without position is always interested
+func interestViewer(conn NetworkConnection, all NetworkComponentSet, key string) (viewer [][]float64, interested NetworkComponentSet, positioned map[string][]float64) {
+	user := conn.Session().User()
+	interested = NetworkComponentSet{}
+	positioned = map[string][]float64{}
+	for cid, c := range all {
+		pos, ok := NetworkPosition(c, key)
+		own := len(user) > 0 && c.Owner == user
+		if own && ok {
+			viewer = append(viewer, pos)
+		}
+		if own || !ok {
+			interested[cid] = c
+		} else {
+			positioned[cid] = pos
+		}
+	}
+	return
+}
+
+// NetworkRadiusInterest is interested in the components which position is in radius of any component owned by connection user
+type NetworkRadiusInterest struct {
+	Position string
+	Radius   float64
+}
+
+func NewNetworkRadiusInterest(position string, radius float64) (interest *NetworkRadiusInterest) {
+	interest = &NetworkRadiusInterest{
+		Position: position,
+		Radius:   radius,
+	}
+	return
+}
+
+func (n *NetworkRadiusInterest) Interest(conn NetworkConnection, all NetworkComponentSet) (interested NetworkComponentSet) {
+	viewer, interested, positioned := interestViewer(conn, all, n.Position)
+	for cid, pos := range positioned {
+		for _, center := range viewer {
+			if interestDistance(center, pos) <= n.Radius {
+				interested[cid] = all[cid]
+				break
+			}
+		}
+	}
+	return
+}
+
+func interestDistance(a, b []float64) float64 {
+	sum := 0.0
+	for i := 0; i < len(a) && i < len(b); i++ {
+		sum += (a[i] - b[i]) * (a[i] - b[i])
+	}
+	return math.Sqrt(sum)
+}
+
+// NetworkGridInterest is interested in the components which grid cell is in Range cells of any component owned by connection user
+type NetworkGridInterest struct {
+	Position string
+	Cell     float64
+	Range    int
+}
+
+func NewNetworkGridInterest(position string, cell float64, rang int) (interest *NetworkGridInterest) {
+	interest = &NetworkGridInterest{
+		Position: position,
+		Cell:     cell,
+		Range:    rang,
+	}
+	return
+}
+
+func (n *NetworkGridInterest) Interest(conn NetworkConnection, all NetworkComponentSet) (interested NetworkComponentSet) {
+	viewer, interested, positioned := interestViewer(conn, all, n.Position)
+	for cid, pos := range positioned {
+		for _, center := range viewer {
+			if n.inRange(center, pos) {
+				interested[cid] = all[cid]
+				break
+			}
+		}
+	}
+	return
+}
+
+func (n *NetworkGridInterest) inRange(a, b []float64) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if math.Abs(math.Floor(a[i]/n.Cell)-math.Floor(b[i]/n.Cell)) > float64(n.Range) {
+			return false
+		}
+	}
+	return true
+}
+
+// NetworkInterestFilter will keep the components known by one connection and filter sync data by InterestPolicy,
+// the component is spawned by whole props when it enter interest and despawned by removed when it leave interest
+type NetworkInterestFilter struct {
+	knownAll map[string]map[string]*NetworkComponent
+	lock     sync.Mutex
+}
+
+func NewNetworkInterestFilter() (filter *NetworkInterestFilter) {
+	filter = &NetworkInterestFilter{
+		knownAll: map[string]map[string]*NetworkComponent{},
+	}
+	return
+}
+
+// Known will return the components known by connection in group
+func (n *NetworkInterestFilter) Known(group string) (known NetworkComponentSet) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	known = NetworkComponentSet{}
+	for cid, c := range n.knownAll[group] {
+		known[cid] = c
+	}
+	return
+}
+
+// Filter will return the sync data which should be sent to connection, it return nil when nothing should be sent
+func (n *NetworkInterestFilter) Filter(ctx *NetworkContext, conn NetworkConnection, data *NetworkSyncData) *NetworkSyncData {
+	policy := ctx.Network.Interest
+	if policy == nil {
+		return data
+	}
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	interested := policy.Interest(conn, ctx.ComponentHub.ListGroupComponent(data.Group))
+	known := n.knownAll[data.Group]
+	if known == nil || data.Whole {
+		known = map[string]*NetworkComponent{}
+		n.knownAll[data.Group] = known
+	}
+	filtered := &NetworkSyncData{
+		UUID:  data.UUID,
+		Group: data.Group,
+		Whole: data.Whole,
+		Tick:  data.Tick,
+		Time:  data.Time,
+	}
+	sent := map[string]bool{}
+	for _, c := range data.Components {
+		if c.Removed {
+			if known[c.CID] != nil {
+				filtered.Components = append(filtered.Components, c)
+				delete(known, c.CID)
+			}
+			sent[c.CID] = true
+			continue
+		}
+		component := interested[c.CID]
+		if component == nil {
+			continue
+		}
+		if known[c.CID] == nil && !data.Whole {
+			spawn := component.networkSpawn()
+			spawn.Triggers = c.Triggers
+			c = spawn
+		}
+		filtered.Components = append(filtered.Components, c)
+		known[c.CID] = component
+		sent[c.CID] = true
+	}
+	for cid, component := range interested {
+		if sent[cid] || known[cid] != nil || component.Removed {
+			continue
+		}
+		filtered.Components = append(filtered.Components, component.networkSpawn())
+		known[cid] = component
+	}
+	for cid, component := range known {
+		if interested[cid] == nil {
+			filtered.Components = append(filtered.Components, &NetworkSyncDataComponent{
+				Factory: component.Factory,
+				CID:     component.CID,
+				Owner:   component.Owner,
+				Removed: true,
+			})
+			delete(known, cid)
+		}
+	}
+	if !filtered.IsUpdated() {
+		return nil
+	}
+	return filtered
+}
+
+// networkSpawn will return the sync data component by all props to spawn component on connection
+func (n *NetworkComponent) networkSpawn() *NetworkSyncDataComponent {
+	n.RLock()
+	props := xmap.M{}
+	for k, v := range n.propAll.value {
+		props[k] = v
+	}
+	n.RUnlock()
+	return &NetworkSyncDataComponent{
+		Factory: n.Factory,
+		CID:     n.CID,
+		Owner:   n.Owner,
+		Props:   props,
+		Quantum: n.networkQuantumAll(props),
+	}
+}
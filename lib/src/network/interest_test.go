@@ -0,0 +1,203 @@
+package network
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/codingeasygo/util/xdebug"
+)
+
+func TestInterest(t *testing.T) {
+	tester := xdebug.CaseTester{
+		0: 1,
+	}
+	if tester.Run() { //policy
+		ctx := NewNetworkContext()
+		conn := &TestNetworkConnection{session: NewDefaultNetworkSessionBySafeM()}
+		conn.session.SetUser("u1")
+		newComponent := func(cid, owner string, pos interface{}) *NetworkComponent {
+			c := NewNetworkComponentByContext(ctx, "test", "", owner, cid)
+			if pos != nil {
+				c.SetValue("pos", pos)
+			}
+			c.RegisterNetworkProp()
+			return c
+		}
+		newComponent("c1", "u1", TestBinaryVec{0, 0})
+		newComponent("c2", "u2", TestBinaryVec{5, 0})
+		newComponent("c3", "u2", []float64{15, 0})
+		newComponent("c4", "u2", nil)
+		newComponent("c5", "u2", 25)
+		newComponent("c6", "u2", "abc")
+		all := ctx.ComponentHub.ListGroupComponent("")
+		interested := NewNetworkRadiusInterest("pos", 10).Interest(conn, all)
+		if len(interested) != 4 || interested["c1"] == nil || interested["c2"] == nil || interested["c4"] == nil || interested["c6"] == nil {
+			t.Errorf("%v", interested)
+			return
+		}
+		interested = NewNetworkGridInterest("pos", 10, 1).Interest(conn, all)
+		if len(interested) != 5 || interested["c3"] == nil || interested["c5"] != nil {
+			t.Errorf("%v", interested)
+			return
+		}
+		//no viewer
+		conn.session.SetUser("")
+		interested = NewNetworkGridInterest("pos", 10, 1).Interest(conn, all)
+		if len(interested) != 2 || interested["c4"] == nil || interested["c6"] == nil {
+			t.Errorf("%v", interested)
+			return
+		}
+	}
+	if tester.Run() { //filter
+		ctx := NewNetworkContext()
+		ctx.Network.IsServer = true
+		conn := &TestNetworkConnection{session: NewDefaultNetworkSessionBySafeM()}
+		conn.session.SetUser("u1")
+		newComponent := func(cid, owner string, pos interface{}) *NetworkComponent {
+			c := NewNetworkComponentByContext(ctx, "test", "", owner, cid)
+			c.SetValue("pos", pos)
+			c.SetValue("name", cid)
+			c.RegisterNetworkProp()
+			return c
+		}
+		c1 := newComponent("c1", "u1", TestBinaryVec{0, 0})
+		c2 := newComponent("c2", "u2", TestBinaryVec{5, 0})
+		c3 := newComponent("c3", "u2", TestBinaryVec{50, 0})
+		filter := NewNetworkInterestFilter()
+		//not policy
+		data := NewNetworkSyncDataByHub(ctx.ComponentHub, "", true)
+		if filter.Filter(ctx, conn, data) != data {
+			t.Error("error")
+			return
+		}
+		ctx.Network.Interest = NewNetworkRadiusInterest("pos", 10)
+		//whole
+		filtered := filter.Filter(ctx, conn, NewNetworkSyncDataByHub(ctx.ComponentHub, "", true))
+		if !filtered.Whole || len(filtered.Components) != 2 || len(filter.Known("")) != 2 {
+			t.Errorf("%v", filtered)
+			return
+		}
+		//enter and leave
+		c2.SetValue("pos", TestBinaryVec{60, 0})
+		c3.SetValue("pos", TestBinaryVec{3, 0})
+		filtered = filter.Filter(ctx, conn, NewNetworkSyncDataByHub(ctx.ComponentHub, "", false))
+		if len(filtered.Components) != 2 {
+			t.Errorf("%v", filtered)
+			return
+		}
+		for _, c := range filtered.Components {
+			if (c.CID == "c2" && !c.Removed) || (c.CID == "c3" && (c.Removed || c.Props.Str("name") != "c3")) {
+				t.Errorf("%v", c)
+				return
+			}
+		}
+		known := filter.Known("")
+		if len(known) != 2 || known["c1"] == nil || known["c3"] == nil {
+			t.Errorf("%v", known)
+			return
+		}
+		//not changed
+		if filtered = filter.Filter(ctx, conn, NewNetworkSyncDataByHub(ctx.ComponentHub, "", false)); filtered != nil {
+			t.Errorf("%v", filtered)
+			return
+		}
+		//not interested update
+		c2.SetValue("name", "x")
+		if filtered = filter.Filter(ctx, conn, NewNetworkSyncDataByHub(ctx.ComponentHub, "", false)); filtered != nil {
+			t.Errorf("%v", filtered)
+			return
+		}
+		//viewer move and component is removed
+		c1.SetValue("pos", TestBinaryVec{58, 0})
+		c1.SetValue("name", "x")
+		c3.Removed = true
+		c2.Removed = true
+		filtered = filter.Filter(ctx, conn, NewNetworkSyncDataByHub(ctx.ComponentHub, "", false))
+		if len(filtered.Components) != 2 {
+			t.Errorf("%v", filtered)
+			return
+		}
+		for _, c := range filtered.Components {
+			if (c.CID == "c1" && c.Props.Str("name") != "x") || (c.CID == "c3" && !c.Removed) {
+				t.Errorf("%v", c)
+				return
+			}
+		}
+		if known := filter.Known(""); len(known) != 1 {
+			t.Errorf("%v", known)
+			return
+		}
+	}
+	if tester.Run() { //grpc
+		server := NewNetworkContext()
+		server.Network.IsServer = true
+		server.Network.Interest = NewNetworkRadiusInterest("pos", 10)
+		transport := NewNetworkTransportGRPCByContext(server)
+		transport.GrpcAddress, _ = url.Parse("grpc://127.0.0.1:50070")
+		transport.WebAddress, _ = url.Parse("ws://127.0.0.1:50071")
+		server.SetTransport(transport)
+		sc1 := NewNetworkComponentByContext(server, "test", "", "u1", "c1")
+		sc1.SetValue("pos", TestBinaryVec{0, 0})
+		sc1.RegisterNetworkProp()
+		sc1.RegisterNetworkCall("join", func(ctx NetworkSession, uuid string, user string) (err error) {
+			ctx.SetUser(user)
+			return
+		})
+		sc2 := NewNetworkComponentByContext(server, "test", "", "u2", "c2")
+		sc2.SetValue("pos", TestBinaryVec{50, 0})
+		sc2.RegisterNetworkProp()
+		if err := server.Network.Start(); err != nil {
+			t.Error(err)
+			return
+		}
+		defer server.Network.Stop()
+
+		client := NewNetworkContext()
+		client.Network.IsClient = true
+		client.Network.SetKey("interest")
+		transport = NewNetworkTransportGRPCByContext(client)
+		transport.GrpcAddress, _ = url.Parse("grpc://127.0.0.1:50070")
+		client.SetTransport(transport)
+		client.ComponentHub.RegisterFactory("test", "", func(key, group, owner, cid string) (c *NetworkComponent, err error) {
+			c = NewNetworkComponentByContext(client, key, group, owner, cid)
+			c.RegisterNetworkProp()
+			return
+		})
+		if err := client.Network.Start(); err != nil {
+			t.Error(err)
+			return
+		}
+		defer client.Network.Stop()
+		if err := client.Network.Ready(); err != nil {
+			t.Error(err)
+			return
+		}
+		sc := NewNetworkComponentByContext(client, "test", "", "", "c1")
+		if err := sc.NetworkCall("join", "u1", nil); err != nil {
+			t.Error(err)
+			return
+		}
+		waitComponent := func(cid string) (c *NetworkComponent) {
+			for i := 0; i < 100 && c == nil; i++ {
+				time.Sleep(10 * time.Millisecond)
+				c = client.ComponentHub.FindComponent(cid)
+			}
+			return
+		}
+		time.Sleep(server.Network.MinSync)
+		sc1.SetValue("pos", TestBinaryVec{1, 0})
+		server.Network.Sync("", nil)
+		if waitComponent("c1") == nil || client.ComponentHub.FindComponent("c2") != nil {
+			t.Error("error")
+			return
+		}
+		time.Sleep(server.Network.MinSync)
+		sc2.SetValue("pos", TestBinaryVec{5, 0})
+		server.Network.Sync("", nil)
+		if c2 := waitComponent("c2"); c2 == nil || len(c2.ArrayFloat64Def(nil, "pos")) != 2 || c2.ArrayFloat64Def(nil, "pos")[0] != 5 {
+			t.Errorf("%v", c2.Str("pos"))
+			return
+		}
+	}
+}
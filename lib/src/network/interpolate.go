@@ -0,0 +1,224 @@
+package network
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/codingeasygo/util/xmap"
+)
+
+// NetworkInterpolator will return the value between from and to by t, t is in [0,1] when interpolate and greater than 1 when extrapolate,
+// both from and to is normalized by NormalizeBinaryValue
+type NetworkInterpolator func(from, to interface{}, t float64) interface{}
+
+// InterpolateNumber is the linear interpolator for number, other value is stepped
+func InterpolateNumber(from, to interface{}, t float64) interface{} {
+	f, fok := interpolationFloat(from)
+	v, tok := interpolationFloat(to)
+	if !fok || !tok {
+		return interpolateStep(from, to, t)
+	}
+	return f + (v-f)*t
+}
+
+// InterpolateVector is the linear interpolator for each item of float list, other value is stepped
+func InterpolateVector(from, to interface{}, t float64) interface{} {
+	f, fok := interpolationFloats(from)
+	v, tok := interpolationFloats(to)
+	if !fok || !tok || len(f) != len(v) {
+		return interpolateStep(from, to, t)
+	}
+	vals := make([]float64, len(f))
+	for i := range f {
+		vals[i] = f[i] + (v[i]-f[i])*t
+	}
+	return vals
+}
+
+// InterpolateAngle is the interpolator for angle in radians by the shortest arc, other value is stepped
+func InterpolateAngle(from, to interface{}, t float64) interface{} {
+	f, fok := interpolationFloat(from)
+	v, tok := interpolationFloat(to)
+	if !fok || !tok {
+		return interpolateStep(from, to, t)
+	}
+	return f + math.Remainder(v-f, 2*math.Pi)*t
+}
+
+func interpolateStep(from, to interface{}, t float64) interface{} {
+	if t < 1 {
+		return from
+	}
+	return to
+}
+
+func interpolationFloat(v interface{}) (f float64, ok bool) {
+	switch v := v.(type) {
+	case float64:
+		f, ok = v, true
+	case int64:
+		f, ok = float64(v), true
+	}
+	return
+}
+
+func interpolationFloats(v interface{}) (vals []float64, ok bool) {
+	switch v := v.(type) {
+	case []float64:
+		vals, ok = v, true
+	case []interface{}:
+		vals = make([]float64, len(v))
+		for i, item := range v {
+			if vals[i], ok = interpolationFloat(item); !ok {
+				return
+			}
+		}
+		ok = true
+	}
+	return
+}
+
+type networkSnapshot struct {
+	Time   time.Time
+	Values map[string]interface{}
+}
+
+// DefaultInterpolationDelay is the interpolation delay which client should render remote props behind, the server rewinds
+// components by it as default
+const DefaultInterpolationDelay = 100 * time.Millisecond
+
+// NetworkInterpolation is the snapshot buffer of remote props, it will keep the timestamped snapshot when props is synced
+// and read the prop interpolated at now - Delay
+type NetworkInterpolation struct {
+	Delay            time.Duration // the interpolation delay behind server time, it should cover at least two sync interval
+	MaxExtrapolation time.Duration // the max duration to extrapolate after last snapshot when sync is stopped
+	MaxSnapshot      int           // the max snapshot to keep
+	interpolatorAll  map[string]NetworkInterpolator
+	snapshotAll      []*networkSnapshot
+	lock             sync.RWMutex
+}
+
+func NewNetworkInterpolation(delay time.Duration) (interpolation *NetworkInterpolation) {
+	interpolation = &NetworkInterpolation{
+		Delay:            delay,
+		MaxExtrapolation: delay,
+		MaxSnapshot:      32,
+		interpolatorAll:  map[string]NetworkInterpolator{},
+	}
+	return
+}
+
+func (n *NetworkInterpolation) Register(name string, interpolator NetworkInterpolator) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.interpolatorAll[name] = interpolator
+}
+
+func (n *NetworkInterpolation) Unregister(name string) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	delete(n.interpolatorAll, name)
+}
+
+// Push will add the snapshot at time by updated props, the prop not updated is carried forward from last snapshot
+func (n *NetworkInterpolation) Push(at time.Time, updated xmap.M) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	snapshot := &networkSnapshot{Time: at, Values: map[string]interface{}{}}
+	if len(n.snapshotAll) > 0 {
+		last := n.snapshotAll[len(n.snapshotAll)-1]
+		if snapshot.Time.Before(last.Time) {
+			snapshot.Time = last.Time
+		}
+		for k, v := range last.Values {
+			snapshot.Values[k] = v
+		}
+	}
+	for k, v := range updated {
+		if _, ok := n.interpolatorAll[k]; !ok {
+			continue
+		}
+		value, err := decodeInterpolationValue(v)
+		if err != nil {
+			Warnf("NetworkInterpolation decode %v=%v error %v", k, v, err)
+			continue
+		}
+		snapshot.Values[k] = value
+	}
+	n.snapshotAll = append(n.snapshotAll, snapshot)
+	if over := len(n.snapshotAll) - n.MaxSnapshot; n.MaxSnapshot > 0 && over > 0 {
+		n.snapshotAll = n.snapshotAll[over:]
+	}
+}
+
+// Clear will remove all snapshot
+func (n *NetworkInterpolation) Clear() {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.snapshotAll = nil
+}
+
+// Value will return the prop value interpolated at now - Delay, the value is extrapolated at most MaxExtrapolation after last snapshot
+func (n *NetworkInterpolation) Value(name string, now time.Time) (value interface{}, ok bool) {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+	interpolator := n.interpolatorAll[name]
+	if interpolator == nil {
+		return
+	}
+	snapshotAll := []*networkSnapshot{}
+	for _, snapshot := range n.snapshotAll {
+		if _, having := snapshot.Values[name]; having {
+			snapshotAll = append(snapshotAll, snapshot)
+		}
+	}
+	if len(snapshotAll) < 1 {
+		return
+	}
+	renderTime := now.Add(-n.Delay)
+	var prev, next *networkSnapshot
+	for i, snapshot := range snapshotAll {
+		if snapshot.Time.After(renderTime) {
+			if i == 0 {
+				value, ok = snapshot.Values[name], true
+				return
+			}
+			prev, next = snapshotAll[i-1], snapshot
+			break
+		}
+	}
+	if next == nil {
+		//extrapolate by last two snapshot
+		last := snapshotAll[len(snapshotAll)-1]
+		if len(snapshotAll) < 2 || n.MaxExtrapolation <= 0 || !last.Time.After(snapshotAll[len(snapshotAll)-2].Time) {
+			value, ok = last.Values[name], true
+			return
+		}
+		prev, next = snapshotAll[len(snapshotAll)-2], last
+		if limit := last.Time.Add(n.MaxExtrapolation); renderTime.After(limit) {
+			renderTime = limit
+		}
+	}
+	t := float64(renderTime.Sub(prev.Time)) / float64(next.Time.Sub(prev.Time))
+	value, ok = interpolator(prev.Values[name], next.Values[name], t), true
+	return
+}
+
+func decodeInterpolationValue(v interface{}) (value interface{}, err error) {
+	if s, ok := v.(string); ok {
+		//the prop on go client is json string
+		var raw interface{}
+		decoder := json.NewDecoder(strings.NewReader(s))
+		decoder.UseNumber()
+		if err = decoder.Decode(&raw); err != nil {
+			value, err = s, nil
+			return
+		}
+		v = raw
+	}
+	value, err = NormalizeBinaryValue(v)
+	return
+}
@@ -0,0 +1,175 @@
+package network
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/codingeasygo/util/xdebug"
+	"github.com/codingeasygo/util/xmap"
+)
+
+func TestInterpolate(t *testing.T) {
+	tester := xdebug.CaseTester{
+		0: 1,
+	}
+	if tester.Run() { //interpolator
+		if v := InterpolateNumber(1.0, int64(3), 0.5); v != 2.0 {
+			t.Errorf("%v", v)
+			return
+		}
+		if v := InterpolateNumber(1.0, "a", 0.5); v != 1.0 {
+			t.Errorf("%v", v)
+			return
+		}
+		if v := InterpolateNumber(1.0, "a", 1); v != "a" {
+			t.Errorf("%v", v)
+			return
+		}
+		if v := InterpolateVector([]float64{0, 1}, []interface{}{int64(2), 3.0}, 0.5); !reflect.DeepEqual(v, []float64{1, 2}) {
+			t.Errorf("%v", v)
+			return
+		}
+		if v := InterpolateVector([]float64{0, 1}, []float64{1}, 0.5); !reflect.DeepEqual(v, []float64{0, 1}) {
+			t.Errorf("%v", v)
+			return
+		}
+		if v := InterpolateVector([]float64{0, 1}, []interface{}{"a", 1.0}, 0.5); !reflect.DeepEqual(v, []float64{0, 1}) {
+			t.Errorf("%v", v)
+			return
+		}
+		if v := InterpolateAngle(math.Pi-0.1, -math.Pi+0.1, 0.5).(float64); math.Abs(v-math.Pi) > 0.0001 {
+			t.Errorf("%v", v)
+			return
+		}
+		if v := InterpolateAngle(0.0, nil, 0.5); v != 0.0 {
+			t.Errorf("%v", v)
+			return
+		}
+	}
+	if tester.Run() { //buffer
+		start := time.Now()
+		at := func(ms int) time.Time { return start.Add(time.Duration(ms) * time.Millisecond) }
+		interpolation := NewNetworkInterpolation(100 * time.Millisecond)
+		interpolation.MaxSnapshot = 4
+		interpolation.Register("x", InterpolateNumber)
+		interpolation.Register("p", InterpolateVector)
+		interpolation.Register("y", InterpolateNumber)
+		interpolation.Register("s", InterpolateNumber)
+		interpolation.Unregister("y")
+		if _, ok := interpolation.Value("x", at(0)); ok {
+			t.Error("error")
+			return
+		}
+		interpolation.Push(at(0), xmap.M{"x": "0", "p": "[0,0]", "y": "1", "s": "abc"})
+		if v, ok := interpolation.Value("x", at(200)); !ok || v != int64(0) {
+			t.Errorf("%v", v)
+			return
+		}
+		interpolation.Push(at(50), xmap.M{"x": "5", "p": "[1,2]", "s": `"x`})
+		interpolation.Push(at(40), xmap.M{"x": "10"}) //out of order is pushed at last time
+		interpolation.Push(at(100), xmap.M{"x": 20.0})
+		if v, ok := interpolation.Value("y", at(100)); ok {
+			t.Errorf("%v", v)
+			return
+		}
+		//before first
+		if v, ok := interpolation.Value("x", at(50)); !ok || v != int64(0) {
+			t.Errorf("%v", v)
+			return
+		}
+		//interpolate
+		if v, ok := interpolation.Value("x", at(125)); !ok || v != 2.5 {
+			t.Errorf("%v", v)
+			return
+		}
+		if v, ok := interpolation.Value("x", at(175)); !ok || v != 15.0 {
+			t.Errorf("%v", v)
+			return
+		}
+		//carry forward
+		if v, ok := interpolation.Value("p", at(200)); !ok || !reflect.DeepEqual(v, []float64{1, 2}) {
+			t.Errorf("%v", v)
+			return
+		}
+		//extrapolate and limit
+		if v, ok := interpolation.Value("x", at(225)); !ok || v != 25.0 {
+			t.Errorf("%v", v)
+			return
+		}
+		if v, ok := interpolation.Value("x", at(1000)); !ok || v != 40.0 {
+			t.Errorf("%v", v)
+			return
+		}
+		interpolation.MaxExtrapolation = 0
+		if v, ok := interpolation.Value("x", at(1000)); !ok || v != 20.0 {
+			t.Errorf("%v", v)
+			return
+		}
+		//not decodable json string is kept
+		if v, ok := interpolation.Value("s", at(1000)); !ok || v != `"x` {
+			t.Errorf("%v", v)
+			return
+		}
+		interpolation.Push(at(200), xmap.M{"x": &TestBinaryErrValue{}})
+		if len(interpolation.snapshotAll) != 4 {
+			t.Errorf("%v", len(interpolation.snapshotAll))
+			return
+		}
+		interpolation.Clear()
+		if _, ok := interpolation.Value("x", at(0)); ok {
+			t.Error("error")
+			return
+		}
+	}
+	if tester.Run() { //component
+		ctx := NewNetworkContext()
+		ctx.Network.IsClient = true
+		ctx.Network.SetServerTime(time.Now().Add(time.Hour), 0)
+		if offset := time.Until(ctx.Network.ServerTime()); offset < 59*time.Minute {
+			t.Errorf("%v", offset)
+			return
+		}
+		ctx.ComponentHub.RegisterFactory("test", "*", func(key, group, owner, cid string) (c *NetworkComponent, err error) {
+			c = NewNetworkComponentByContext(ctx, key, group, owner, cid)
+			c.Interpolation = NewNetworkInterpolation(0)
+			c.Interpolation.Register("x", InterpolateNumber)
+			return
+		})
+		ctx.ComponentHub.SyncRecv("", []*NetworkSyncDataComponent{
+			{Factory: "test", CID: "1", Props: xmap.M{"x": "1"}},
+			{Factory: "test", CID: "2", Props: xmap.M{"x": "2"}},
+		}, true)
+		ctx.ComponentHub.SyncRecv("", []*NetworkSyncDataComponent{
+			{Factory: "test", CID: "1", Props: xmap.M{"x": "3"}},
+		}, false)
+		c1 := ctx.ComponentHub.FindComponent("1")
+		c2 := ctx.ComponentHub.FindComponent("2")
+		if len(c1.Interpolation.snapshotAll) != 2 || len(c2.Interpolation.snapshotAll) != 2 {
+			t.Error("error")
+			return
+		}
+		if v, ok := c2.InterpolatedValue("x"); !ok || v != int64(2) {
+			t.Errorf("%v", v)
+			return
+		}
+		sent := ctx.Network.ServerTime().Add(-time.Second)
+		for i := 0; i < 2; i++ {
+			ctx.ComponentHub.SyncRecvData(&NetworkSyncData{Group: "g1", Tick: int64(i + 1), Time: sent.Add(time.Duration(i) * 100 * time.Millisecond), Components: []*NetworkSyncDataComponent{
+				{Factory: "test", CID: "3", Props: xmap.M{"x": fmt.Sprintf("%v", i)}},
+			}})
+		}
+		c3 := ctx.ComponentHub.FindComponent("3")
+		if len(c3.Interpolation.snapshotAll) != 2 || !c3.Interpolation.snapshotAll[0].Time.Equal(sent) || !c3.Interpolation.snapshotAll[1].Time.Equal(sent.Add(100*time.Millisecond)) {
+			t.Errorf("%v", c3.Interpolation.snapshotAll)
+			return
+		}
+		c2.Interpolation = nil
+		if _, ok := c2.InterpolatedValue("x"); ok {
+			t.Error("error")
+			return
+		}
+	}
+}
@@ -0,0 +1,603 @@
+package network
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/codingeasygo/util/converter"
+	"github.com/codingeasygo/util/uuid"
+	"github.com/codingeasygo/util/xdebug"
+	"github.com/codingeasygo/util/xmap"
+)
+
+// networkPipeLoopback will run the delivered call by order after the delay, the call delivered later is never run before the earlier
+type networkPipeLoopback struct {
+	queue  chan *networkPipeItemLoopback
+	closed chan int
+	closer sync.Once
+}
+
+type networkPipeItemLoopback struct {
+	At   time.Time
+	Call func()
+}
+
+func newNetworkPipeLoopback() (pipe *networkPipeLoopback) {
+	pipe = &networkPipeLoopback{
+		queue:  make(chan *networkPipeItemLoopback, 1024),
+		closed: make(chan int),
+	}
+	go pipe.loopDeliver()
+	return
+}
+
+func (n *networkPipeLoopback) loopDeliver() {
+	for {
+		select {
+		case item := <-n.queue:
+			if wait := time.Until(item.At); wait > 0 {
+				select {
+				case <-time.After(wait):
+				case <-n.closed:
+					return
+				}
+			}
+			n.procDeliver(item)
+		case <-n.closed:
+			return
+		}
+	}
+}
+
+func (n *networkPipeLoopback) procDeliver(item *networkPipeItemLoopback) {
+	defer func() {
+		if perr := recover(); perr != nil {
+			Errorf("[Loopback] deliver painc with %v, callstack is \n%v", perr, xdebug.CallStack())
+		}
+	}()
+	item.Call()
+}
+
+func (n *networkPipeLoopback) Deliver(delay time.Duration, call func()) {
+	select {
+	case n.queue <- &networkPipeItemLoopback{At: time.Now().Add(delay), Call: call}:
+	case <-n.closed:
+	}
+}
+
+func (n *networkPipeLoopback) Close() {
+	n.closer.Do(func() { close(n.closed) })
+}
+
+// NetworkLoopback will connect one server transport and any number of client transport in process by channels,
+// the sync data, call and ping is delayed by Latency and Jitter, and the sync data is dropped by Loss
+type NetworkLoopback struct {
+	Latency time.Duration // the one way delay
+	Jitter  time.Duration // the max random delay added to Latency
+	Loss    float64       // the probability of sync data and ack is dropped
+	server  *NetworkTransportLoopback
+	connAll map[string]*NetworkConnLoopback
+	random  *rand.Rand
+	lock    sync.RWMutex
+}
+
+func NewNetworkLoopback() (loopback *NetworkLoopback) {
+	loopback = NewNetworkLoopbackBySeed(time.Now().UnixNano())
+	return
+}
+
+// NewNetworkLoopbackBySeed will create the loopback which random jitter and loss is same on same seed
+func NewNetworkLoopbackBySeed(seed int64) (loopback *NetworkLoopback) {
+	loopback = &NetworkLoopback{
+		connAll: map[string]*NetworkConnLoopback{},
+		random:  rand.New(rand.NewSource(seed)),
+	}
+	return
+}
+
+func (n *NetworkLoopback) delay() (delay time.Duration) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	delay = n.Latency
+	if n.Jitter > 0 {
+		delay += time.Duration(n.random.Int63n(int64(n.Jitter)))
+	}
+	return
+}
+
+func (n *NetworkLoopback) lost() bool {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	return n.Loss > 0 && n.random.Float64() < n.Loss
+}
+
+func (n *NetworkLoopback) findServer() *NetworkTransportLoopback {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+	return n.server
+}
+
+func (n *NetworkLoopback) setServer(server *NetworkTransportLoopback) (err error) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	if server != nil && n.server != nil {
+		err = fmt.Errorf("server is started")
+		return
+	}
+	n.server = server
+	return
+}
+
+func (n *NetworkLoopback) addConn(conn *NetworkConnLoopback) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.connAll[conn.ID()] = conn
+}
+
+func (n *NetworkLoopback) removeConn(conn *NetworkConnLoopback) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	delete(n.connAll, conn.ID())
+}
+
+// ConnAll will return all the server side connection of client
+func (n *NetworkLoopback) ConnAll() (connAll NetworkConnectionSet) {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+	connAll = NetworkConnectionSet{}
+	for k, v := range n.connAll {
+		connAll[k] = v
+	}
+	return
+}
+
+// NetworkConnLoopback is one side of connection between server and client in process, the data sent by one side is
+// delivered to peer by peer's pipe
+type NetworkConnLoopback struct {
+	ctx      *NetworkContext
+	session  NetworkSession
+	state    NetworkState
+	isServer bool
+	loopback *NetworkLoopback
+	peer     *NetworkConnLoopback
+	pipe     *networkPipeLoopback
+	interest *NetworkInterestFilter
+	reliable *NetworkReliableTracker
+	group    string // the session group which connection is notified on server
+	sequence int64
+	ping     NetworkPingMeter
+	pingLast [2]time.Time // the server send and local receive time of last ping result on client side
+	sending  sync.Mutex
+	lock     sync.RWMutex
+}
+
+func (n *NetworkConnLoopback) ID() string {
+	return fmt.Sprintf("%p", n)
+}
+
+func (n *NetworkConnLoopback) Session() NetworkSession {
+	return n.session
+}
+
+func (n *NetworkConnLoopback) State() NetworkState {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+	return n.state
+}
+
+func (n *NetworkConnLoopback) setState(state NetworkState) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.state = state
+}
+
+func (n *NetworkConnLoopback) IsServer() bool {
+	return n.isServer
+}
+
+func (n *NetworkConnLoopback) IsClient() bool {
+	return !n.isServer
+}
+
+func (n *NetworkConnLoopback) PingStats() NetworkPingStats {
+	return n.ping.Stats()
+}
+
+// NetworkSync will send sync data to client on server side, and publish sync data to server on client side
+func (n *NetworkConnLoopback) NetworkSync(data *NetworkSyncData) {
+	if n.State() != NetworkStateReady {
+		return
+	}
+	if n.isServer {
+		n.sendSyncData(data)
+	} else {
+		n.sendPublish(data)
+	}
+}
+
+// sendSyncData will filter and encode data as grpc server, filter and deliver must be atomic to keep the sequence order
+func (n *NetworkConnLoopback) sendSyncData(data *NetworkSyncData) {
+	n.sending.Lock()
+	defer n.sending.Unlock()
+	if !data.IsUpdated() && (n.reliable == nil || !n.reliable.Resendable(data.Group)) {
+		return //nothing is changed and no unacked state to resend
+	}
+	if n.reliable != nil {
+		data = n.reliable.Filter(n.ctx, n, data)
+	} else {
+		data = n.interest.Filter(n.ctx, n, data)
+	}
+	if data == nil {
+		return
+	}
+	data = data.Encode(n.session)
+	if n.ctx.Network.Verbose {
+		Debugf("[Loopback] network send to %v by\n %v", n.session.Key(), converter.JSON(data))
+	}
+	if n.loopback.lost() {
+		return
+	}
+	peer := n.peer
+	peer.pipe.Deliver(n.loopback.delay(), func() { peer.recvSyncData(data) })
+}
+
+// recvSyncData will skip the stale sync data and ack the reliable sync data on client side
+func (n *NetworkConnLoopback) recvSyncData(data *NetworkSyncData) {
+	if data.Sequence > 0 {
+		if data.Sequence <= n.sequence { //stale
+			return
+		}
+		n.sequence = data.Sequence
+	}
+	n.ctx.Network.OnNetworkSync(n, data)
+	if data.Sequence > 0 && !n.loopback.lost() {
+		peer, sequence := n.peer, data.Sequence
+		peer.pipe.Deliver(n.loopback.delay(), func() {
+			if reliable := peer.reliable; reliable != nil {
+				reliable.Ack(sequence)
+			}
+		})
+	}
+}
+
+func (n *NetworkConnLoopback) sendPublish(data *NetworkSyncData) {
+	data = data.Encode(n.session)
+	if n.loopback.lost() {
+		return
+	}
+	peer := n.peer
+	peer.pipe.Deliver(n.loopback.delay(), func() {
+		peer.session.SetLast(time.Now())
+		peer.ctx.Network.OnNetworkPublish(peer, data)
+	})
+}
+
+// networkCall will process call on server side after delay and the result is returned after delay, the result is not delivered
+// by pipe, so the call in sync callback is not blocked
+func (n *NetworkConnLoopback) networkCall(arg *NetworkCallArg) (ret *NetworkCallResult, err error) {
+	type callResult struct {
+		Ret *NetworkCallResult
+		Err error
+	}
+	waiting := make(chan *callResult, 1)
+	peer := n.peer
+	peer.pipe.Deliver(n.loopback.delay(), func() {
+		peer.session.SetLast(time.Now())
+		ret, err := peer.ctx.Network.OnNetworkCall(peer, arg)
+		peer.regroup()
+		time.AfterFunc(n.loopback.delay(), func() { waiting <- &callResult{Ret: ret, Err: err} })
+	})
+	timer := time.NewTimer(n.ctx.Network.Timeout)
+	defer timer.Stop()
+	select {
+	case result := <-waiting:
+		ret, err = result.Ret, result.Err
+	case <-timer.C:
+		err = fmt.Errorf("timeout")
+	}
+	return
+}
+
+// NetworkTransportLoopback is the transport which connect server and client in process by NetworkLoopback
+type NetworkTransportLoopback struct {
+	Context  *NetworkContext
+	Loopback *NetworkLoopback
+	conn     *NetworkConnLoopback // the client side connection
+	ready    bool
+	lock     sync.RWMutex
+}
+
+func NewNetworkTransportLoopback(loopback *NetworkLoopback) (transport *NetworkTransportLoopback) {
+	transport = NewNetworkTransportLoopbackByContext(DefaultContext, loopback)
+	return
+}
+
+func NewNetworkTransportLoopbackByContext(ctx *NetworkContext, loopback *NetworkLoopback) (transport *NetworkTransportLoopback) {
+	transport = &NetworkTransportLoopback{
+		Context:  ctx,
+		Loopback: loopback,
+	}
+	return
+}
+
+// Conn will return the client side connection, it is nil on server or not started
+func (n *NetworkTransportLoopback) Conn() *NetworkConnLoopback {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+	return n.conn
+}
+
+func (n *NetworkTransportLoopback) connect() (err error) {
+	server := n.Loopback.findServer()
+	if server == nil {
+		err = fmt.Errorf("server is not started")
+		return
+	}
+	meta := xmap.M{}
+	if raw, ok := n.Context.Network.Meta().Raw().(xmap.M); ok {
+		for k, v := range raw {
+			meta[k] = v
+		}
+	}
+	client := &NetworkConnLoopback{
+		ctx:      n.Context,
+		session:  n.Context.Network.NetworkSession,
+		state:    NetworkStateConnecting,
+		loopback: n.Loopback,
+		pipe:     newNetworkPipeLoopback(),
+	}
+	conn := &NetworkConnLoopback{
+		ctx:      server.Context,
+		session:  NewDefaultNetworkSessionByMeta(xmap.NewSafeByBase(meta)),
+		state:    NetworkStateConnecting,
+		isServer: true,
+		loopback: n.Loopback,
+		pipe:     newNetworkPipeLoopback(),
+		interest: NewNetworkInterestFilter(),
+	}
+	if auth := server.Context.Network.Authenticator; auth != nil {
+		identity, xerr := auth.Authenticate(conn.session)
+		if xerr == nil && identity == nil {
+			xerr = fmt.Errorf("identity is nil")
+		}
+		if xerr != nil {
+			client.pipe.Close()
+			conn.pipe.Close()
+			err = xerr
+			return
+		}
+		conn.session.SetKey(identity.SessionKey(conn.session.Key()))
+		identity.Apply(conn.session)
+	}
+	client.peer, conn.peer = conn, client
+	n.lock.Lock()
+	n.conn = client
+	n.lock.Unlock()
+	return
+}
+
+func (n *NetworkTransportLoopback) Start() (err error) {
+	network := n.Context.Network
+	if network.IsServer {
+		err = n.Loopback.setServer(n)
+		if err != nil {
+			return
+		}
+	}
+	if network.IsClient {
+		err = n.connect()
+		if err != nil {
+			return
+		}
+	}
+	if network.IsServer && !network.IsClient {
+		err = n.Ready()
+	}
+	return
+}
+
+func (n *NetworkTransportLoopback) Stop() (err error) {
+	network := n.Context.Network
+	if network.IsClient {
+		n.Pause()
+		if conn := n.Conn(); conn != nil {
+			conn.pipe.Close()
+			conn.peer.pipe.Close()
+		}
+		n.lock.Lock()
+		n.conn = nil
+		n.lock.Unlock()
+	}
+	if network.IsServer && n.Loopback.findServer() == n {
+		for _, conn := range n.Loopback.ConnAll() {
+			conn := conn.(*NetworkConnLoopback)
+			conn.peer.pipe.Deliver(0, func() { conn.peer.close() })
+		}
+		n.Loopback.setServer(nil)
+	}
+	n.ready = false
+	return
+}
+
+func (n *NetworkTransportLoopback) IsReady() (ready bool) {
+	ready = n.ready
+	return
+}
+
+// Ready will start sync on client, the server will send whole sync data after ready is delivered
+func (n *NetworkTransportLoopback) Ready() (err error) {
+	if n.Context.Network.IsClient {
+		client := n.Conn()
+		if client == nil {
+			err = fmt.Errorf("not started")
+			return
+		}
+		if client.State() == NetworkStateReady {
+			err = fmt.Errorf("started")
+			return
+		}
+		conn, reliable := client.peer, n.Context.Network.SyncReliable
+		client.pipe.Deliver(0, func() { client.sequence = 0 })
+		client.setState(NetworkStateReady)
+		n.Context.Network.OnNetworkState(NetworkConnectionSet{client.ID(): client}, client, NetworkStateReady, nil)
+		conn.pipe.Deliver(n.Loopback.delay(), func() { conn.open(reliable) })
+	}
+	n.ready = err == nil
+	return
+}
+
+// Pause will stop sync on client, the server is notified closed after delay
+func (n *NetworkTransportLoopback) Pause() (err error) {
+	if n.Context.Network.IsClient {
+		client := n.Conn()
+		if client == nil {
+			err = fmt.Errorf("not started")
+			return
+		}
+		if client.State() == NetworkStateReady {
+			conn := client.peer
+			conn.pipe.Deliver(n.Loopback.delay(), func() { conn.close() })
+			client.close()
+		}
+	}
+	n.ready = false
+	return
+}
+
+// regroup will notify group changed when the session group is changed on server, so the whole data of new group is synced
+func (n *NetworkConnLoopback) regroup() {
+	n.lock.Lock()
+	from, to := n.group, n.session.Group()
+	n.group = to
+	n.lock.Unlock()
+	if from != to && n.State() == NetworkStateReady {
+		n.ctx.Network.OnNetworkGroup(n, from, to)
+	}
+}
+
+// open will reset sync state and add server side connection and notify ready to server
+func (n *NetworkConnLoopback) open(reliable bool) {
+	n.lock.Lock()
+	n.group = n.session.Group()
+	n.lock.Unlock()
+	n.sending.Lock()
+	n.interest = NewNetworkInterestFilter()
+	n.reliable = nil
+	if reliable {
+		n.reliable = NewNetworkReliableTracker()
+	}
+	n.sending.Unlock()
+	n.loopback.addConn(n)
+	n.setState(NetworkStateReady)
+	n.session.SetLast(time.Now())
+	n.ctx.Network.OnNetworkState(n.loopback.sessionConnAll(n.session.Key()), n, NetworkStateReady, nil)
+}
+
+// close will change state to closed and notify closed
+func (n *NetworkConnLoopback) close() {
+	if n.State() != NetworkStateReady {
+		return
+	}
+	n.setState(NetworkStateClosed)
+	all := NetworkConnectionSet{n.ID(): n}
+	if n.isServer {
+		n.loopback.removeConn(n)
+		all = n.loopback.sessionConnAll(n.session.Key())
+	}
+	n.ctx.Network.OnNetworkState(all, n, NetworkStateClosed, nil)
+}
+
+func (n *NetworkLoopback) sessionConnAll(session string) (connAll NetworkConnectionSet) {
+	connAll = NetworkConnectionSet{}
+	for k, c := range n.ConnAll() {
+		if c.Session().Key() == session {
+			connAll[k] = c
+		}
+	}
+	return
+}
+
+// Ping will measure the round trip to server by the simulated delay and update the server time
+func (n *NetworkTransportLoopback) Ping() (speed time.Duration, err error) {
+	client := n.Conn()
+	if client == nil {
+		err = fmt.Errorf("not started")
+		return
+	}
+	network := n.Context.Network
+	startTime := time.Now()
+	waiting := make(chan [2]time.Time, 1)
+	conn := client.peer
+	client.lock.RLock()
+	last := client.pingLast
+	client.lock.RUnlock()
+	conn.pipe.Deliver(n.Loopback.delay(), func() {
+		recvTime := time.Now()
+		conn.session.SetLast(recvTime)
+		if !last[0].IsZero() {
+			conn.ping.Add(last[0], last[1], startTime, recvTime)
+		}
+		conn.ctx.Network.OnNetworkPing(conn, conn.ping.Stats().RTT)
+		serverTime := [2]time.Time{recvTime, time.Now()}
+		time.AfterFunc(n.Loopback.delay(), func() { waiting <- serverTime })
+	})
+	timer := time.NewTimer(network.Timeout)
+	defer timer.Stop()
+	select {
+	case server := <-waiting:
+		recvTime, serverTime := time.Now(), server[1]
+		speed = client.ping.Add(startTime, server[0], serverTime, recvTime)
+		client.lock.Lock()
+		client.pingLast = [2]time.Time{serverTime, recvTime}
+		client.lock.Unlock()
+		network.PingSpeed = speed
+		network.SetServerTime(serverTime, speed)
+		network.OnNetworkPing(client, speed)
+	case <-timer.C:
+		err = fmt.Errorf("timeout")
+	}
+	return
+}
+
+// Regroup will notify the server side connections of session when session group is changed, see NetworkRegroupTransport
+func (n *NetworkTransportLoopback) Regroup(session NetworkSession) {
+	for _, conn := range n.Loopback.sessionConnAll(session.Key()) {
+		conn.(*NetworkConnLoopback).regroup()
+	}
+}
+
+func (n *NetworkTransportLoopback) NetworkSync(data *NetworkSyncData, excluded []NetworkConnection) {
+	if n.Context.Network.IsServer {
+		isExcluded := func(c NetworkConnection) bool {
+			for _, e := range excluded {
+				if c == e {
+					return true
+				}
+			}
+			return false
+		}
+		for _, c := range n.Loopback.ConnAll() {
+			if isExcluded(c) || (data.Group != "*" && data.Group != c.Session().Group()) {
+				continue
+			}
+			c.NetworkSync(data)
+		}
+	} else if conn := n.Conn(); n.Context.Network.IsClient && conn != nil {
+		conn.NetworkSync(data)
+	}
+}
+
+func (n *NetworkTransportLoopback) NetworkCall(arg *NetworkCallArg) (ret *NetworkCallResult, err error) {
+	conn := n.Conn()
+	if !n.Context.Network.IsClient || conn == nil {
+		err = fmt.Errorf("not client or not connect")
+		return
+	}
+	if len(arg.UUID) < 1 {
+		arg.UUID = uuid.New()
+	}
+	ret, err = conn.networkCall(arg)
+	return
+}
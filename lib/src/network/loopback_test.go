@@ -0,0 +1,190 @@
+package network
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLoopback(t *testing.T) {
+	loopback := NewNetworkLoopbackBySeed(1)
+	loopback.Latency = 5 * time.Millisecond
+	loopback.Jitter = 5 * time.Millisecond
+	server := NewNetworkContext()
+	server.Network.IsServer = true
+	server.ComponentHub.RegisterAuthority("test", "x", true)
+	server.SetTransport(NewNetworkTransportLoopbackByContext(server, loopback))
+	sc := NewNetworkComponentByContext(server, "test", "", "u1", "c1")
+	sc.SetValue("x", 1)
+	sc.RegisterNetworkProp()
+	sc.RegisterNetworkCall("add", func(ctx NetworkSession, uuid string, v int) (r int, err error) {
+		r = v + 1
+		return
+	})
+	sc.RegisterNetworkCall("join", func(ctx NetworkSession, uuid string, user string) (err error) {
+		ctx.SetUser(user)
+		return
+	})
+	//not server
+	client := NewNetworkContext()
+	client.Network.IsClient = true
+	client.SetTransport(NewNetworkTransportLoopbackByContext(client, loopback))
+	if err := client.Network.Start(); err == nil {
+		t.Error("error")
+		return
+	}
+	if err := server.Network.Start(); err != nil {
+		t.Error(err)
+		return
+	}
+	defer server.Network.Stop()
+	if err := NewNetworkTransportLoopbackByContext(server, loopback).Start(); err == nil {
+		t.Error("error")
+		return
+	}
+	newClient := func(user string, reliable bool) (client *NetworkContext, synced chan string) {
+		client = NewNetworkContext()
+		client.Network.IsClient = true
+		client.Network.SyncReliable = reliable
+		client.Network.SetKey(user)
+		client.Network.SetUser(user)
+		client.ComponentHub.RegisterAuthority("test", "x", true)
+		client.SetTransport(NewNetworkTransportLoopbackByContext(client, loopback))
+		synced = make(chan string, 64)
+		client.ComponentHub.RegisterFactory("test", "", func(key, group, owner, cid string) (c *NetworkComponent, err error) {
+			c = NewNetworkComponentByContext(client, key, group, owner, cid)
+			c.RegisterNetworkProp()
+			c.OnNetworkSynced = func() { synced <- c.Str("x") }
+			return
+		})
+		return
+	}
+	c1, synced1 := newClient("u1", false)
+	c2, synced2 := newClient("u2", true)
+	for _, c := range []*NetworkContext{c1, c2} {
+		if err := c.Network.Start(); err != nil {
+			t.Error(err)
+			return
+		}
+		defer c.Network.Stop()
+		//call before ready
+		ret, err := c.Network.NetworkCall(&NetworkCallArg{CID: "c1", Name: "add", Arg: "1"})
+		if err != nil || ret.Result != "2" {
+			t.Errorf("%v,%v", err, ret)
+			return
+		}
+		if _, err := c.Network.NetworkCall(&NetworkCallArg{CID: "c1", Name: "join", Arg: `"` + c.Network.User() + `"`}); err != nil {
+			t.Error(err)
+			return
+		}
+		if err := c.Network.Ready(); err != nil {
+			t.Error(err)
+			return
+		}
+		if err := c.Network.Ready(); err == nil {
+			t.Error("error")
+			return
+		}
+	}
+	if x := <-synced1; x != "1" {
+		t.Error(x)
+		return
+	}
+	if x := <-synced2; x != "1" {
+		t.Error(x)
+		return
+	}
+	//ping
+	if speed, err := c1.Transport().(*NetworkTransportLoopback).Ping(); err != nil || speed < 2*loopback.Latency {
+		t.Errorf("%v,%v", err, speed)
+		return
+	}
+	if _, err := c1.Transport().(*NetworkTransportLoopback).Ping(); err != nil {
+		t.Error(err)
+		return
+	}
+	time.Sleep(loopback.Latency) //wait server stats
+	conn := c1.Transport().(*NetworkTransportLoopback).Conn()
+	if stats := conn.PingStats(); stats.Samples != 2 || stats.RTT < 2*loopback.Latency {
+		t.Errorf("%v", stats)
+		return
+	}
+	if stats := conn.peer.PingStats(); stats.Samples != 1 || stats.RTT < 2*loopback.Latency {
+		t.Errorf("%v", stats)
+		return
+	}
+	//sync
+	time.Sleep(server.Network.MinSync)
+	sc.SetValue("x", 2)
+	server.Network.Sync("", nil)
+	for x := <-synced1; x != "2"; x = <-synced1 {
+	}
+	for x := <-synced2; x != "2"; x = <-synced2 {
+	}
+	//publish
+	cc := c1.ComponentHub.FindComponent("c1")
+	time.Sleep(c1.Network.MinSync)
+	cc.SetValue("x", 3)
+	c1.Network.Sync("", nil)
+	for x := <-synced2; x != "3"; x = <-synced2 {
+	}
+	if sc.Int("x") != 3 || len(loopback.ConnAll()) != 2 {
+		t.Errorf("%v", sc.Int("x"))
+		return
+	}
+	//reliable on loss
+	loopback.Loss = 0.5
+	for i := 4; i < 10; i++ {
+		time.Sleep(server.Network.MinSync)
+		sc.SetValue("x", i)
+		server.Network.Sync("", nil)
+	}
+	loopback.Loss = 0
+	for i := 0; i < 100 && c2.ComponentHub.FindComponent("c1").Int("x") != 9; i++ {
+		time.Sleep(server.Network.MinSync)
+		server.Network.Sync("", nil) //resend lost on sync without change after ack timeout
+	}
+	if x := c2.ComponentHub.FindComponent("c1").Int("x"); x != 9 {
+		t.Error(x)
+		return
+	}
+	//pause
+	c1.Network.Pause()
+	for i := 0; i < 100 && len(loopback.ConnAll()) != 1; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if len(loopback.ConnAll()) != 1 {
+		t.Error("error")
+		return
+	}
+	c1.Network.Pause()
+	//deterministic
+	a, b := NewNetworkLoopbackBySeed(100), NewNetworkLoopbackBySeed(100)
+	a.Jitter, b.Jitter, a.Loss, b.Loss = time.Second, time.Second, 0.5, 0.5
+	for i := 0; i < 10; i++ {
+		if a.delay() != b.delay() || a.lost() != b.lost() {
+			t.Error("error")
+			return
+		}
+	}
+	NewNetworkLoopback()
+	//not started
+	transport := NewNetworkTransportLoopback(loopback)
+	transport.Context = NewNetworkContext()
+	transport.Context.Network.IsClient = true
+	if err := transport.Ready(); err == nil {
+		t.Error("error")
+		return
+	}
+	if err := transport.Pause(); err == nil {
+		t.Error("error")
+		return
+	}
+	if _, err := transport.Ping(); err == nil {
+		t.Error("error")
+		return
+	}
+	if _, err := transport.NetworkCall(&NetworkCallArg{}); err == nil {
+		t.Error("error")
+		return
+	}
+}
@@ -6,6 +6,7 @@ import 'package:flutter/foundation.dart';
 import 'package:uuid/uuid.dart';
 
 import '../common/log.dart';
+import 'binary.dart';
 
 mixin NetworkSession {
   Map<String, String> get meta;
@@ -17,6 +18,10 @@ mixin NetworkSession {
   String get key => meta["key"] ?? "";
   set key(String v) => meta["key"] = v;
 
+  /// the token sent to server in metadata, it is validated by Authenticator on go server
+  String get token => meta["token"] ?? "";
+  set token(String v) => meta["token"] = v;
+
   String? get user => context["user"];
   set user(String? v) => context["user"] = v!;
 
@@ -187,12 +192,14 @@ class NetworkSyncData {
   String uuid;
   String group = "*";
   bool? whole; // if components container all NetworkComponents, if true client should remove NetworkComponents which is not in components
+  int tick; // the monotonically increasing server tick of sync, zero is not synced by server
+  DateTime? time; // the server time of sync
 
   List<NetworkSyncDataComponent> components;
 
   bool get isUpdated => components.isNotEmpty || (whole ?? false);
 
-  NetworkSyncData({required this.uuid, required this.group, this.whole, required this.components});
+  NetworkSyncData({required this.uuid, required this.group, this.whole, this.tick = 0, this.time, required this.components});
 
   factory NetworkSyncData.create({List<NetworkSyncDataComponent>? components, bool? whole}) =>
       NetworkSyncData(uuid: const Uuid().v1(), group: "*", whole: whole, components: components ?? List.empty(growable: true));
@@ -213,6 +220,7 @@ abstract class NetworkManager with NetworkTransport, NetworkCallback {
 
   bool verbose = false;
   Duration minSync = const Duration(milliseconds: 30);
+  String syncEncoding = syncEncodingJSON; // the sync encoding client request to server, default is json like go client, server will fallback to json if binary is not supported
   DateTime _lastSync = DateTime.fromMillisecondsSinceEpoch(0);
 
   String? get user => session.user;
@@ -6,6 +6,7 @@ import (
 	"reflect"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/codingeasygo/util/converter"
@@ -114,6 +115,25 @@ type NetworkConnection interface {
 	NetworkSync(data *NetworkSyncData)
 }
 
+// NetworkCallSession is the session passed to NetworkCall on server, the connection which the call is received from is kept in it
+type NetworkCallSession struct {
+	NetworkSession
+	Conn NetworkConnection
+}
+
+// SessionConnection will return the connection of session passed to NetworkCall, it is nil when session is not NetworkCallSession
+func SessionConnection(session NetworkSession) (conn NetworkConnection) {
+	if call, ok := session.(*NetworkCallSession); ok {
+		conn = call.Conn
+	}
+	return
+}
+
+// NetworkPingConnection is the optional connection which measure the ping stats, it is checked by type assertion on NetworkConnection
+type NetworkPingConnection interface {
+	PingStats() NetworkPingStats // the round trip, jitter and clock offset measured by ping on connection
+}
+
 type NetworkConnectionSet map[string]NetworkConnection
 
 type NetworkCallback interface {
@@ -122,6 +142,12 @@ type NetworkCallback interface {
 	OnNetworkSync(conn NetworkConnection, data *NetworkSyncData)
 }
 
+// NetworkPublishCallback is the optional callback which receive the sync data published by client for owned components,
+// it is checked by type assertion on NetworkCallback and the published data is dropped when it is not implemented
+type NetworkPublishCallback interface {
+	OnNetworkPublish(conn NetworkConnection, data *NetworkSyncData)
+}
+
 type NetworkTransport interface {
 	Start() (err error)
 	Stop() (err error)
@@ -138,6 +164,18 @@ type NetworkEvent interface {
 	OnNetworkDataSynced(conn NetworkConnection, data *NetworkSyncData)
 }
 
+// NetworkGroupEvent is the optional event which is notified when the group of connection is changed by call on server,
+// it is checked by type assertion on NetworkCallback and NetworkEvent
+type NetworkGroupEvent interface {
+	OnNetworkGroup(conn NetworkConnection, from, to string)
+}
+
+// NetworkRegroupTransport is the optional server transport which can move the connections of session to the group changed out of call,
+// it is checked by type assertion on NetworkManager.Regroup
+type NetworkRegroupTransport interface {
+	Regroup(session NetworkSession)
+}
+
 func JsonEncode(v interface{}) string {
 	switch v.(type) {
 	case float32, float64:
@@ -154,24 +192,44 @@ type NetworkSyncDataComponent struct {
 	Removed  bool
 	Props    xmap.M
 	Triggers xmap.M
+	Quantum  map[string]float64 // the prop quantum, it is only used when encode and not transfer
 }
 
-func EncodeProp(props xmap.M, session NetworkSession) xmap.M {
+// FilterProp will filter the props which can be accessed by session
+func FilterProp(props xmap.M, session NetworkSession) xmap.M {
 	propAll := xmap.M{}
 	for k, v := range props {
 		switch v := v.(type) {
 		case NetworkValue:
 			if v.Access(session) {
-				propAll[k] = JsonEncode(v)
+				propAll[k] = v
 			}
 		default:
+			propAll[k] = v
+		}
+	}
+	return propAll
+}
+
+func EncodeProp(props xmap.M, session NetworkSession) xmap.M {
+	return EncodePropByQuantum(props, nil, session)
+}
+
+// EncodePropByQuantum will encode the props to json, the prop having quantum is quantized by JsonEncodeByQuantum
+func EncodePropByQuantum(props xmap.M, quantum map[string]float64, session NetworkSession) xmap.M {
+	propAll := xmap.M{}
+	for k, v := range FilterProp(props, session) {
+		if q := quantum[k]; q > 0 {
+			propAll[k] = JsonEncodeByQuantum(v, q)
+		} else {
 			propAll[k] = JsonEncode(v)
 		}
 	}
 	return propAll
 }
 
-func EncodeTrigger(triggers xmap.M, session NetworkSession) xmap.M {
+// FilterTrigger will filter the trigger values which can be accessed by session
+func FilterTrigger(triggers xmap.M, session NetworkSession) xmap.M {
 	propAll := xmap.M{}
 	for k, vals := range triggers {
 		valAll := []interface{}{}
@@ -179,10 +237,10 @@ func EncodeTrigger(triggers xmap.M, session NetworkSession) xmap.M {
 			switch v := v.(type) {
 			case NetworkValue:
 				if v.Access(session) {
-					valAll = append(valAll, JsonEncode(v))
+					valAll = append(valAll, v)
 				}
 			default:
-				valAll = append(valAll, JsonEncode(v))
+				valAll = append(valAll, v)
 			}
 		}
 		if len(valAll) > 0 {
@@ -192,13 +250,36 @@ func EncodeTrigger(triggers xmap.M, session NetworkSession) xmap.M {
 	return propAll
 }
 
+func EncodeTrigger(triggers xmap.M, session NetworkSession) xmap.M {
+	propAll := xmap.M{}
+	for k, vals := range FilterTrigger(triggers, session) {
+		valAll := []interface{}{}
+		for _, v := range vals.([]interface{}) {
+			valAll = append(valAll, JsonEncode(v))
+		}
+		propAll[k] = valAll
+	}
+	return propAll
+}
+
+func (n *NetworkSyncDataComponent) Filter(session NetworkSession) *NetworkSyncDataComponent {
+	return &NetworkSyncDataComponent{
+		Factory:  n.Factory,
+		CID:      n.CID,
+		Owner:    n.Owner,
+		Removed:  n.Removed,
+		Props:    QuantizeProp(FilterProp(n.Props, session), n.Quantum),
+		Triggers: FilterTrigger(n.Triggers, session),
+	}
+}
+
 func (n *NetworkSyncDataComponent) Encode(session NetworkSession) *NetworkSyncDataComponent {
 	return &NetworkSyncDataComponent{
 		Factory:  n.Factory,
 		CID:      n.CID,
 		Owner:    n.Owner,
 		Removed:  n.Removed,
-		Props:    EncodeProp(n.Props, session),
+		Props:    EncodePropByQuantum(n.Props, n.Quantum, session),
 		Triggers: EncodeTrigger(n.Triggers, session),
 	}
 }
@@ -206,16 +287,24 @@ func (n *NetworkSyncDataComponent) Encode(session NetworkSession) *NetworkSyncDa
 type NetworkSyncData struct {
 	UUID       string
 	Group      string
-	Whole      bool // if components container all NetworkComponents, if true client should remove NetworkComponents which is not in components
+	Whole      bool      // if components container all NetworkComponents, if true client should remove NetworkComponents which is not in components
+	Sequence   int64     // the sequence of reliable sync data on connection, zero is not reliable
+	Tick       int64     // the monotonically increasing server tick of sync, zero is not synced by server
+	Time       time.Time // the server time of sync
 	Components []*NetworkSyncDataComponent
 }
 
 func NewNetworkSyncDataBySyncSend(group string, whole bool) (data *NetworkSyncData) {
+	data = NewNetworkSyncDataByHub(ComponentHub, group, whole)
+	return
+}
+
+func NewNetworkSyncDataByHub(hub *NetworkComponentHub, group string, whole bool) (data *NetworkSyncData) {
 	data = &NetworkSyncData{
 		UUID:       uuid.New(),
 		Group:      group,
 		Whole:      whole,
-		Components: ComponentHub.SyncSend(group, whole),
+		Components: hub.SyncSend(group, whole),
 	}
 	return
 }
@@ -224,6 +313,22 @@ func (n *NetworkSyncData) IsUpdated() bool {
 	return len(n.Components) > 0 || n.Whole
 }
 
+func (n *NetworkSyncData) Filter(session NetworkSession) *NetworkSyncData {
+	components := []*NetworkSyncDataComponent{}
+	for _, c := range n.Components {
+		components = append(components, c.Filter(session))
+	}
+	return &NetworkSyncData{
+		UUID:       n.UUID,
+		Group:      n.Group,
+		Whole:      n.Whole,
+		Sequence:   n.Sequence,
+		Tick:       n.Tick,
+		Time:       n.Time,
+		Components: components,
+	}
+}
+
 func (n *NetworkSyncData) Encode(session NetworkSession) *NetworkSyncData {
 	components := []*NetworkSyncDataComponent{}
 	for _, c := range n.Components {
@@ -233,35 +338,105 @@ func (n *NetworkSyncData) Encode(session NetworkSession) *NetworkSyncData {
 		UUID:       n.UUID,
 		Group:      n.Group,
 		Whole:      n.Whole,
+		Sequence:   n.Sequence,
+		Tick:       n.Tick,
+		Time:       n.Time,
 		Components: components,
 	}
 }
 
-var Network = NewNetworkManager()
+// NetworkContext is one isolated network instance, it owns the manager, component hub, event hub and transport,
+// so multiple servers or server with loopback client can running in one process.
+type NetworkContext struct {
+	Network      *NetworkManager
+	ComponentHub *NetworkComponentHub
+	EventHub     *NetworkEventHub
+}
+
+func NewNetworkContext() (ctx *NetworkContext) {
+	ctx = &NetworkContext{
+		ComponentHub: NewNetworkComponentHub(),
+		EventHub:     NewNetworkEventHub(),
+	}
+	ctx.ComponentHub.Context = ctx
+	ctx.Network = NewNetworkManagerByContext(ctx)
+	return
+}
+
+func (c *NetworkContext) Transport() NetworkTransport {
+	return c.Network.Transport
+}
+
+func (c *NetworkContext) SetTransport(transport NetworkTransport) {
+	c.Network.Transport = transport
+}
+
+// DefaultContext is the context used by package level Network, ComponentHub and EventHub
+var DefaultContext = NewNetworkContext()
+
+var Network = DefaultContext.Network
 
 type NetworkManager struct {
 	NetworkSession
-	Verbose   bool
-	MinSync   time.Duration
-	Keepalive time.Duration
-	Timeout   time.Duration
-	IsServer  bool
-	IsClient  bool
-	Transport NetworkTransport
-	PingSpeed time.Duration
-	lastSync  time.Time
+	Context       *NetworkContext
+	Verbose       bool
+	MinSync       time.Duration
+	Keepalive     time.Duration
+	Timeout       time.Duration
+	IsServer      bool
+	IsClient      bool
+	SyncEncoding  string          // the sync encoding client request to server, server will fallback to json if not supported
+	SyncReliable  bool            // if client request server to sync delta against the state acked by client
+	Interest      InterestPolicy  // the interest policy to filter components for each connection on server, nil is all components
+	Authenticator Authenticator   // the authenticator to validate session on server, nil is all session is trusted
+	SessionGrace  time.Duration   // the window which closed session can be resumed by reconnecting with same key, the closed state is notified after window
+	Reconnect     *NetworkBackoff // the policy to restart the broken sync on client, nil is not restart
+	Transport     NetworkTransport
+	PingSpeed     time.Duration
+	RewindDelay   time.Duration        // the interpolation delay on client, it is added to half ping when rewind components, default is DefaultInterpolationDelay
+	MaxRewind     time.Duration        // the max duration to rewind components, zero is not limited
+	lastSync      map[string]time.Time // the last sync time by group, so each group is limited by MinSync
+	syncLck       sync.Mutex
+	serverOffset  int64
+	tick          int64
 }
 
 func NewNetworkManager() (network *NetworkManager) {
+	network = NewNetworkManagerByContext(DefaultContext)
+	return
+}
+
+func NewNetworkManagerByContext(ctx *NetworkContext) (network *NetworkManager) {
 	network = &NetworkManager{
 		NetworkSession: NewDefaultNetworkSessionBySafeM(),
+		Context:        ctx,
 		MinSync:        30 * time.Millisecond,
 		Keepalive:      3 * time.Second,
 		Timeout:        5 * time.Second,
+		Reconnect:      NewNetworkBackoff(),
+		RewindDelay:    DefaultInterpolationDelay,
+		MaxRewind:      time.Second,
+		lastSync:       map[string]time.Time{},
 	}
 	return
 }
 
+// SetServerTime will update the offset from local clock to server clock by server time and ping speed
+func (n *NetworkManager) SetServerTime(serverTime time.Time, ping time.Duration) {
+	offset := serverTime.Add(ping / 2).Sub(time.Now())
+	atomic.StoreInt64(&n.serverOffset, int64(offset))
+}
+
+// SetToken will set the token in session meta, it is sent to server and validated by Authenticator
+func (n *NetworkManager) SetToken(token string) {
+	n.Meta().SetValue(NetworkTokenMeta, token)
+}
+
+// ServerTime will return the current server time aligned by ping, it is local time on server
+func (n *NetworkManager) ServerTime() time.Time {
+	return time.Now().Add(time.Duration(atomic.LoadInt64(&n.serverOffset)))
+}
+
 func (n *NetworkManager) Start() (err error) {
 	err = n.Transport.Start()
 	return
@@ -269,7 +444,7 @@ func (n *NetworkManager) Start() (err error) {
 
 func (n *NetworkManager) Stop() (err error) {
 	err = n.Transport.Stop()
-	ComponentHub.Clear("")
+	n.Context.ComponentHub.Clear("")
 	return
 }
 
@@ -280,7 +455,7 @@ func (n *NetworkManager) IsReady() (ready bool) {
 
 func (n *NetworkManager) Ready() (err error) {
 	if group := n.Group(); len(group) > 0 {
-		ComponentHub.Clear(n.Group())
+		n.Context.ComponentHub.Clear(n.Group())
 	}
 	err = n.Transport.Ready()
 	return
@@ -291,13 +466,40 @@ func (n *NetworkManager) Pause() (err error) {
 	return
 }
 
+func (n *NetworkManager) syncTime(group string) time.Time {
+	n.syncLck.Lock()
+	defer n.syncLck.Unlock()
+	return n.lastSync[group]
+}
+
+func (n *NetworkManager) markSync(group string) {
+	n.syncLck.Lock()
+	defer n.syncLck.Unlock()
+	n.lastSync[group] = time.Now()
+}
+
+// Tick will return the last server tick which is set to sync data
+func (n *NetworkManager) Tick() int64 {
+	return atomic.LoadInt64(&n.tick)
+}
+
+func (n *NetworkManager) nextTick() (tick int64, now time.Time) {
+	tick, now = atomic.AddInt64(&n.tick, 1), time.Now()
+	return
+}
+
 func (n *NetworkManager) Sync(group string, whole NetworkConnection) bool {
-	if whole == nil && time.Since(n.lastSync) < n.MinSync {
+	if whole == nil && time.Since(n.syncTime(group)) < n.MinSync {
 		return false
 	}
 	var updated = false
 	if n.IsServer {
-		var updatedData = NewNetworkSyncDataBySyncSend(group, false)
+		tick, now := n.nextTick()
+		for _, c := range n.Context.ComponentHub.ListGroupComponent(group) {
+			c.recordNetworkHistory(tick, now)
+		}
+		var updatedData = NewNetworkSyncDataByHub(n.Context.ComponentHub, group, false)
+		updatedData.Tick, updatedData.Time = tick, now
 		if updatedData.IsUpdated() {
 			if whole == nil {
 				n.NetworkSync(updatedData, []NetworkConnection{})
@@ -305,12 +507,29 @@ func (n *NetworkManager) Sync(group string, whole NetworkConnection) bool {
 				n.NetworkSync(updatedData, []NetworkConnection{whole})
 			}
 			updated = true
-			n.lastSync = time.Now()
+			n.markSync(group)
+		} else if whole == nil {
+			//nothing changed, the reliable connection will resend the unacked state when ack is timeout, it is also limited by MinSync
+			n.NetworkSync(updatedData, []NetworkConnection{})
+			n.markSync(group)
 		}
 		if whole != nil {
-			wholeData := NewNetworkSyncDataBySyncSend(group, true)
+			wholeData := NewNetworkSyncDataByHub(n.Context.ComponentHub, group, true)
+			wholeData.Tick, wholeData.Time = tick, now
 			whole.NetworkSync(wholeData)
 		}
+	} else if n.IsClient {
+		//client only publish the authority props and triggers on owned components
+		publishData := &NetworkSyncData{
+			UUID:       uuid.New(),
+			Group:      group,
+			Components: n.Context.ComponentHub.SyncSendOwned(group, n.User()),
+		}
+		if publishData.IsUpdated() {
+			n.NetworkSync(publishData, nil)
+			updated = true
+			n.markSync(group)
+		}
 	}
 	return updated
 }
@@ -329,25 +548,52 @@ func (n *NetworkManager) OnNetworkState(all NetworkConnectionSet, conn NetworkCo
 	if n.IsServer && conn.IsServer() && state == NetworkStateReady {
 		n.Sync(group, conn)
 	}
-	EventHub.OnNetworkState(all, conn, state, info)
+	n.Context.EventHub.OnNetworkState(all, conn, state, info)
+}
+
+// Regroup will move the connections of session to the session group which is changed out of its own call, such as the group of
+// other player is changed by call, the connections is notified like the group is changed by call, it is skipped when transport is not NetworkRegroupTransport
+func (n *NetworkManager) Regroup(session NetworkSession) {
+	if transport, ok := n.Context.Transport().(NetworkRegroupTransport); ok && n.IsServer {
+		transport.Regroup(session)
+	}
+}
+
+// OnNetworkGroup will sync whole data of new group to connection and notify the events of both group
+func (n *NetworkManager) OnNetworkGroup(conn NetworkConnection, from, to string) {
+	if n.IsServer && conn.IsServer() {
+		n.Sync(to, conn)
+	}
+	n.Context.EventHub.OnNetworkGroup(conn, from, to)
 }
 
 func (n *NetworkManager) OnNetworkCall(conn NetworkConnection, arg *NetworkCallArg) (ret *NetworkCallResult, err error) {
-	ret, err = ComponentHub.OnNetworkCall(conn, arg)
+	ret, err = n.Context.ComponentHub.OnNetworkCall(conn, arg)
 	return
 }
 
 func (n *NetworkManager) OnNetworkSync(conn NetworkConnection, data *NetworkSyncData) {
-	ComponentHub.OnNetworkSync(conn, data)
+	n.Context.ComponentHub.OnNetworkSync(conn, data)
 	n.OnNetworkDataSynced(conn, data)
 }
 
+// OnNetworkPublish will apply the sync data published by client and rebroadcast the accepted to other connections
+func (n *NetworkManager) OnNetworkPublish(conn NetworkConnection, data *NetworkSyncData) {
+	accepted := n.Context.ComponentHub.SyncPublish(conn, data)
+	if accepted == nil {
+		return
+	}
+	accepted.Tick, accepted.Time = n.nextTick()
+	n.NetworkSync(accepted, []NetworkConnection{conn})
+	n.OnNetworkDataSynced(conn, accepted)
+}
+
 func (n *NetworkManager) OnNetworkPing(conn NetworkConnection, ping time.Duration) {
-	EventHub.OnNetworkPing(conn, ping)
+	n.Context.EventHub.OnNetworkPing(conn, ping)
 }
 
 func (n *NetworkManager) OnNetworkDataSynced(conn NetworkConnection, data *NetworkSyncData) {
-	EventHub.OnNetworkDataSynced(conn, data)
+	n.Context.EventHub.OnNetworkDataSynced(conn, data)
 }
 
 type NetworkCallArg struct {
@@ -382,9 +628,11 @@ type NetworkComponentFactory func(key, group, owner, cid string) (c *NetworkComp
 
 type SyncMap struct {
 	OnUpdate func(key string, val interface{})
+	Quantum  func(key string) float64 // the value changed less than quantum will not be marked updated
 	value    xmap.M
 	updated  map[string]int
 	removed  map[string]int
+	synced   map[string]interface{}
 }
 
 func NewSyncMap() (s *SyncMap) {
@@ -392,10 +640,34 @@ func NewSyncMap() (s *SyncMap) {
 		value:   xmap.M{},
 		updated: map[string]int{},
 		removed: map[string]int{},
+		synced:  map[string]interface{}{},
+	}
+	return
+}
+
+func (s *SyncMap) quantum(path string) (quantum float64) {
+	if s.Quantum != nil {
+		quantum = s.Quantum(path)
 	}
 	return
 }
 
+func (s *SyncMap) isChanged(path string, val interface{}) bool {
+	quantum := s.quantum(path)
+	if quantum <= 0 {
+		return true
+	}
+	last, ok := s.synced[path]
+	if !ok {
+		return true
+	}
+	having, err := NormalizeBinaryValue(val)
+	if err != nil {
+		return true
+	}
+	return QuantumChanged(last, having, quantum)
+}
+
 func (s *SyncMap) ValueVal(path ...string) (v interface{}, err error) {
 	v, err = s.value.ValueVal(path...)
 	return
@@ -403,7 +675,9 @@ func (s *SyncMap) ValueVal(path ...string) (v interface{}, err error) {
 func (s *SyncMap) SetValue(path string, val interface{}) (err error) {
 	err = s.value.SetValue(path, val)
 	if err == nil {
-		s.updated[path] = 1
+		if s.isChanged(path, val) {
+			s.updated[path] = 1
+		}
 		if s.OnUpdate != nil {
 			s.OnUpdate(path, val)
 		}
@@ -413,12 +687,14 @@ func (s *SyncMap) SetValue(path string, val interface{}) (err error) {
 func (s *SyncMap) Delete(path string) (err error) {
 	err = s.value.Delete(path)
 	s.removed[path] = 1
+	delete(s.synced, path)
 	return
 }
 func (s *SyncMap) Clear() (err error) {
 	for k := range s.value {
 		s.removed[k] = 1
 	}
+	s.synced = map[string]interface{}{}
 	err = s.value.Clear()
 	return
 }
@@ -439,23 +715,105 @@ func (s *SyncMap) Updated(whole bool) (value xmap.M) {
 	} else {
 		for k := range s.updated {
 			value[k] = s.value[k]
+			if s.quantum(k) > 0 { //only record the value which is synced to all
+				s.synced[k], _ = NormalizeBinaryValue(s.value[k])
+			}
 		}
 	}
 	s.updated = map[string]int{}
 	return
 }
 
+// UpdatedBy will return the updated value which key is accepted, only the returned key is marked not updated
+func (s *SyncMap) UpdatedBy(accept func(key string) bool) (value xmap.M) {
+	value = xmap.New()
+	for k := range s.updated {
+		if accept(k) {
+			value[k] = s.value[k]
+			delete(s.updated, k)
+		}
+	}
+	return
+}
+
+// Sync will apply the received props, each value is decoded by DecodePropValue to the type of having value
 func (s *SyncMap) Sync(value xmap.M) {
 	for k, v := range value {
-		s.value[k] = v
+		s.value[k] = DecodePropValue(s.value[k], v)
 	}
 }
 
+// DecodePropValue will decode the received prop value v which is json string to the type of having value, the having pointer
+// implemented json.Unmarshaler is copied and decoded on the copy, so the state like sequence is kept and having is not changed,
+// and v is normalized by NormalizeBinaryValue when having is nil or decode fail, so the prop must be declared before received to be decoded
+func DecodePropValue(having, v interface{}) (value interface{}) {
+	if having != nil {
+		var data []byte
+		var err error
+		if s, ok := v.(string); ok {
+			data = []byte(s)
+		} else {
+			data, err = json.Marshal(v)
+		}
+		if _, ok := having.(json.Unmarshaler); ok && err == nil && reflect.TypeOf(having).Kind() == reflect.Pointer {
+			current := reflect.ValueOf(having)
+			fresh := reflect.New(current.Type().Elem())
+			if !current.IsNil() {
+				fresh.Elem().Set(current.Elem())
+			}
+			if err = fresh.Interface().(json.Unmarshaler).UnmarshalJSON(data); err == nil {
+				value = fresh.Interface()
+				return
+			}
+		} else if err == nil {
+			target := reflect.New(reflect.TypeOf(having))
+			if err = json.Unmarshal(data, target.Interface()); err == nil {
+				value = target.Elem().Interface()
+				return
+			}
+		}
+	}
+	value, err := decodeInterpolationValue(v)
+	if err != nil {
+		value = v
+	}
+	return
+}
+
 type NetworkPropUpdate func(key string, val interface{})
 
 type NetworkTrigger interface{}
+
+// NetworkCall is the call handler by func(ctx NetworkSession, uuid string[, arg A]) ([R, ]error), the arg is decoded from json
 type NetworkCall interface{}
 
+var networkSessionType = reflect.TypeOf((*NetworkSession)(nil)).Elem()
+var networkErrorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// ValidNetworkCall will check the call is func(ctx NetworkSession, uuid string[, arg A]) ([R, ]error)
+func ValidNetworkCall(call NetworkCall) (err error) {
+	callType := reflect.TypeOf(call)
+	if callType == nil || callType.Kind() != reflect.Func {
+		err = fmt.Errorf("NetworkCall must be func, but %v", callType)
+		return
+	}
+	switch {
+	case callType.IsVariadic():
+		err = fmt.Errorf("NetworkCall %v must not be variadic", callType)
+	case callType.NumIn() < 2 || callType.NumIn() > 3:
+		err = fmt.Errorf("NetworkCall %v must have 2 or 3 arguments", callType)
+	case callType.In(0) != networkSessionType:
+		err = fmt.Errorf("NetworkCall %v first argument must be NetworkSession", callType)
+	case callType.In(1).Kind() != reflect.String:
+		err = fmt.Errorf("NetworkCall %v second argument must be string", callType)
+	case callType.NumOut() < 1 || callType.NumOut() > 2:
+		err = fmt.Errorf("NetworkCall %v must have 1 or 2 results", callType)
+	case callType.Out(callType.NumOut()-1) != networkErrorType:
+		err = fmt.Errorf("NetworkCall %v last result must be error", callType)
+	}
+	return
+}
+
 type networkTriggerItem struct {
 	Name    string
 	Trigger NetworkTrigger
@@ -528,6 +886,7 @@ const (
 
 type NetworkComponent struct {
 	*xmap.SafeM
+	Context         *NetworkContext
 	Creator         string
 	Factory         string
 	Group           string
@@ -535,6 +894,8 @@ type NetworkComponent struct {
 	CID             string
 	Removed         bool
 	Resync          bool
+	SyncTick        int64     // the server tick of last received sync
+	SyncTime        time.Time // the server time of last received sync
 	OnNetworkRemove func()
 	OnNetworkSynced func()
 	OnPropUpdate    map[string]NetworkPropUpdate
@@ -542,10 +903,23 @@ type NetworkComponent struct {
 	propAll         *SyncMap
 	triggerAll      map[string]*networkTriggerItem
 	callAll         map[string]NetworkCall
+	quantumAll      map[string]float64
+	quantumLck      sync.RWMutex
+	authorityAll    map[string]bool
+	authorityLck    sync.RWMutex
+	Interpolation   *NetworkInterpolation // the snapshot buffer of remote props, it is disabled by nil
+	History         *NetworkHistory       // the snapshot buffer of props recorded by server tick for lag compensation, it is disabled by nil
+	predictor       *networkPredictor
 }
 
 func NewNetworkComponent(factory, group, owner, cid string) (c *NetworkComponent) {
+	c = NewNetworkComponentByContext(DefaultContext, factory, group, owner, cid)
+	return
+}
+
+func NewNetworkComponentByContext(ctx *NetworkContext, factory, group, owner, cid string) (c *NetworkComponent) {
 	c = &NetworkComponent{
+		Context:      ctx,
 		Creator:      LocCreator,
 		Factory:      factory,
 		Group:        group,
@@ -555,33 +929,38 @@ func NewNetworkComponent(factory, group, owner, cid string) (c *NetworkComponent
 		propAll:      NewSyncMap(),
 		triggerAll:   map[string]*networkTriggerItem{},
 		callAll:      map[string]NetworkCall{},
+		quantumAll:   map[string]float64{},
+		quantumLck:   sync.RWMutex{},
+		authorityAll: map[string]bool{},
+		authorityLck: sync.RWMutex{},
 	}
 	c.propAll.OnUpdate = c.onPropUpdate
+	c.propAll.Quantum = c.NetworkQuantum
 	c.SafeM = xmap.NewSafeByBase(c.propAll)
 	return
 }
 
 func (n *NetworkComponent) IsServer() bool {
-	return Network.IsServer
+	return n.Context.Network.IsServer
 }
 
 func (n *NetworkComponent) IsClient() bool {
-	return Network.IsClient
+	return n.Context.Network.IsClient
 }
 
 func (n *NetworkComponent) IsOwner() bool {
-	return n.Owner == Network.User()
+	return n.Owner == n.Context.Network.User()
 }
 
 func (n *NetworkComponent) addSelfToHub() {
-	ComponentHub.addComponent(n)
+	n.Context.ComponentHub.addComponent(n)
 }
 
 func (n *NetworkComponent) shouldRemoveSelfFromHub() (call func()) {
 	remove := n.propAll.Length() < 1 && len(n.callAll) < 1
 	call = func() {
 		if remove {
-			ComponentHub.removeComponent(n)
+			n.Context.ComponentHub.removeComponent(n)
 		}
 	}
 	return
@@ -622,10 +1001,19 @@ func (n *NetworkComponent) SendNetworkProp(whole bool) xmap.M {
 }
 
 func (n *NetworkComponent) RecvNetworkProp(updated xmap.M) {
-	n.RLock()
-	defer n.RUnlock()
+	n.recvNetworkProp(updated)
+	n.reconcileNetworkInput(updated)
+}
+
+func (n *NetworkComponent) recvNetworkProp(updated xmap.M) {
+	n.Lock()
 	n.propAll.Sync(updated)
-	for k, v := range updated {
+	decoded := xmap.M{}
+	for k := range updated {
+		decoded[k] = n.propAll.value[k]
+	}
+	n.Unlock()
+	for k, v := range decoded {
 		call := n.OnPropUpdate[k]
 		if call != nil {
 			call(k, v)
@@ -633,6 +1021,110 @@ func (n *NetworkComponent) RecvNetworkProp(updated xmap.M) {
 	}
 }
 
+// InterpolatedValue will return the prop value interpolated by Interpolation at current server time
+func (n *NetworkComponent) InterpolatedValue(name string) (value interface{}, ok bool) {
+	if n.Interpolation == nil {
+		return
+	}
+	value, ok = n.Interpolation.Value(name, n.Context.Network.ServerTime())
+	return
+}
+
+// pushNetworkSnapshot will push the snapshot stamped by server time of sync data, the arrival time is used when it is zero
+func (n *NetworkComponent) pushNetworkSnapshot(at time.Time, updated xmap.M) {
+	if n.Interpolation == nil {
+		return
+	}
+	if at.IsZero() {
+		at = n.Context.Network.ServerTime()
+	}
+	n.Interpolation.Push(at, updated)
+}
+
+//------ NetworkQuantum -------//
+
+// RegisterNetworkQuantum will register the prop quantum, the float in prop is rounded to multiple of quantum when encode
+// and the change less than quantum will not be synced, it will override the quantum registered on factory
+func (n *NetworkComponent) RegisterNetworkQuantum(name string, quantum float64) {
+	n.quantumLck.Lock()
+	defer n.quantumLck.Unlock()
+	n.quantumAll[name] = quantum
+}
+
+func (n *NetworkComponent) UnregisterNetworkQuantum(name string) {
+	n.quantumLck.Lock()
+	defer n.quantumLck.Unlock()
+	delete(n.quantumAll, name)
+}
+
+// NetworkQuantum will return the prop quantum registered on component or factory, zero is not quantized
+func (n *NetworkComponent) NetworkQuantum(name string) (quantum float64) {
+	n.quantumLck.RLock()
+	quantum, ok := n.quantumAll[name]
+	n.quantumLck.RUnlock()
+	if !ok {
+		quantum = n.Context.ComponentHub.FactoryQuantum(n.Factory, name)
+	}
+	return
+}
+
+func (n *NetworkComponent) networkQuantumAll(props xmap.M) (quantum map[string]float64) {
+	for k := range props {
+		if q := n.NetworkQuantum(k); q > 0 {
+			if quantum == nil {
+				quantum = map[string]float64{}
+			}
+			quantum[k] = q
+		}
+	}
+	return
+}
+
+//------ NetworkAuthority -------//
+
+// RegisterNetworkAuthority will mark the prop or trigger can be written by client which is owner of component,
+// it will override the authority registered on factory
+func (n *NetworkComponent) RegisterNetworkAuthority(name string, authority bool) {
+	n.authorityLck.Lock()
+	defer n.authorityLck.Unlock()
+	n.authorityAll[name] = authority
+}
+
+func (n *NetworkComponent) UnregisterNetworkAuthority(name string) {
+	n.authorityLck.Lock()
+	defer n.authorityLck.Unlock()
+	delete(n.authorityAll, name)
+}
+
+// NetworkAuthority will return if the prop or trigger can be written by owner client, it is registered on component or factory
+func (n *NetworkComponent) NetworkAuthority(name string) (authority bool) {
+	n.authorityLck.RLock()
+	authority, ok := n.authorityAll[name]
+	n.authorityLck.RUnlock()
+	if !ok {
+		authority = n.Context.ComponentHub.FactoryAuthority(n.Factory, name)
+	}
+	return
+}
+
+// sendNetworkAuthority will return the updated authority props and triggers to publish
+func (n *NetworkComponent) sendNetworkAuthority() (props, triggers xmap.M) {
+	n.RLock()
+	defer n.RUnlock()
+	props = n.propAll.UpdatedBy(n.NetworkAuthority)
+	triggers = xmap.M{}
+	for _, trigger := range n.triggerAll {
+		if !n.NetworkAuthority(trigger.Name) {
+			continue
+		}
+		send := trigger.Send()
+		if len(send) > 0 {
+			triggers[trigger.Name] = send
+		}
+	}
+	return
+}
+
 //------ NetworkTrigger -------//
 
 func (n *NetworkComponent) RegisterNetworkTrigger(name string, trigger NetworkTrigger) (err error) {
@@ -727,16 +1219,20 @@ func (n *NetworkComponent) RecvNetworkTrigger(updated xmap.M) {
 //------ NetworkEvent -------//
 
 func (n *NetworkComponent) RegisterNetworkEvent(group string, event NetworkEvent) {
-	EventHub.RegisterNetworkEvent(group, event)
+	n.Context.EventHub.RegisterNetworkEvent(group, event)
 }
 
 func (n *NetworkComponent) UnregisterNetworkEvent(event NetworkEvent) {
-	EventHub.UnregisterNetworkEvent(event)
+	n.Context.EventHub.UnregisterNetworkEvent(event)
 }
 
 //------ NetworkCall -------//
 
+// RegisterNetworkCall will register the call handler by name, it return error when call is not valid by ValidNetworkCall
 func (n *NetworkComponent) RegisterNetworkCall(name string, call NetworkCall) (err error) {
+	if err = ValidNetworkCall(call); err != nil {
+		return
+	}
 	n.Lock()
 	defer n.Unlock()
 	if n.callAll[name] != nil {
@@ -777,7 +1273,7 @@ func (n *NetworkComponent) findNetworkCall(name string) NetworkCall {
 }
 
 func (n *NetworkComponent) NetworkCall(name string, arg interface{}, ret interface{}) (err error) {
-	res, err := Network.NetworkCall(&NetworkCallArg{
+	res, err := n.Context.Network.NetworkCall(&NetworkCallArg{
 		UUID: uuid.New(),
 		CID:  n.CID,
 		Name: name,
@@ -803,7 +1299,7 @@ func (n *NetworkComponent) CallNetworkCall(ctx NetworkSession, arg *NetworkCallA
 	}
 	callValue := reflect.ValueOf(call)
 	callType := callValue.Type()
-	argAll := []reflect.Value{reflect.ValueOf(ctx), reflect.ValueOf(arg.UUID)}
+	argAll := []reflect.Value{reflect.ValueOf(&ctx).Elem(), reflect.ValueOf(arg.UUID).Convert(callType.In(1))}
 	if callType.NumIn() > 2 {
 		argType := callType.In(2)
 		argValue := reflect.New(argType)
@@ -831,7 +1327,7 @@ func (n *NetworkComponent) CallNetworkCall(ctx NetworkSession, arg *NetworkCallA
 	return
 }
 
-var EventHub = NewNetworkEventHub()
+var EventHub = DefaultContext.EventHub
 
 type NetworkEventHub struct {
 	eventAll map[NetworkEvent]string
@@ -879,6 +1375,16 @@ func (n *NetworkEventHub) OnNetworkDataSynced(conn NetworkConnection, data *Netw
 	}
 }
 
+func (n *NetworkEventHub) OnNetworkGroup(conn NetworkConnection, from, to string) {
+	n.eventLck.RLock()
+	defer n.eventLck.RUnlock()
+	for event, g := range n.eventAll {
+		if groupEvent, ok := event.(NetworkGroupEvent); ok && (g == from || g == to || g == "*") {
+			groupEvent.OnNetworkGroup(conn, from, to)
+		}
+	}
+}
+
 func (n *NetworkEventHub) RegisterNetworkEvent(group string, event NetworkEvent) {
 	n.eventLck.Lock()
 	defer n.eventLck.Unlock()
@@ -891,13 +1397,18 @@ func (n *NetworkEventHub) UnregisterNetworkEvent(event NetworkEvent) {
 	delete(n.eventAll, event)
 }
 
-var ComponentHub = NewNetworkComponentHub()
+var ComponentHub = DefaultContext.ComponentHub
 
 type NetworkComponentHub struct {
+	Context        *NetworkContext // the context owned hub, the component created by factory is bound to it
 	OnAdd          func(c *NetworkComponent)
 	OnRemove       func(c *NetworkComponent)
 	factoryAll     map[string]NetworkComponentFactory
 	factoryLck     sync.RWMutex
+	quantumAll     map[string]map[string]float64
+	quantumLck     sync.RWMutex
+	authorityAll   map[string]map[string]bool
+	authorityLck   sync.RWMutex
 	componentAll   NetworkComponentSet
 	componentGroup map[string]NetworkComponentSet
 	componentLck   sync.RWMutex
@@ -907,6 +1418,10 @@ func NewNetworkComponentHub() (hub *NetworkComponentHub) {
 	hub = &NetworkComponentHub{
 		factoryAll:     map[string]NetworkComponentFactory{},
 		factoryLck:     sync.RWMutex{},
+		quantumAll:     map[string]map[string]float64{},
+		quantumLck:     sync.RWMutex{},
+		authorityAll:   map[string]map[string]bool{},
+		authorityLck:   sync.RWMutex{},
 		componentAll:   make(NetworkComponentSet),
 		componentGroup: map[string]NetworkComponentSet{},
 		componentLck:   sync.RWMutex{},
@@ -994,6 +1509,15 @@ func (n *NetworkComponentHub) removeComponent(c *NetworkComponent) {
 	removed = n.removeComponentNotLock(c)
 }
 
+// detachComponent will remove the component without callback, it is used when component is moved to other hub
+func (n *NetworkComponentHub) detachComponent(c *NetworkComponent) {
+	n.componentLck.Lock()
+	defer n.componentLck.Unlock()
+	if n.componentAll[c.CID] == c {
+		n.removeComponentNotLock(c)
+	}
+}
+
 func (n *NetworkComponentHub) removeComponentNotLock(c *NetworkComponent) (removed bool) {
 	c = n.componentAll[c.CID]
 	if c == nil {
@@ -1038,6 +1562,56 @@ func (n *NetworkComponentHub) UnregisterFactory(key, group string) {
 	}
 }
 
+// RegisterQuantum will register the prop quantum for all component created by factory, see NetworkComponent.RegisterNetworkQuantum
+func (n *NetworkComponentHub) RegisterQuantum(factory, name string, quantum float64) {
+	n.quantumLck.Lock()
+	defer n.quantumLck.Unlock()
+	quantumAll := n.quantumAll[factory]
+	if quantumAll == nil {
+		quantumAll = map[string]float64{}
+		n.quantumAll[factory] = quantumAll
+	}
+	quantumAll[name] = quantum
+}
+
+func (n *NetworkComponentHub) UnregisterQuantum(factory, name string) {
+	n.quantumLck.Lock()
+	defer n.quantumLck.Unlock()
+	delete(n.quantumAll[factory], name)
+}
+
+func (n *NetworkComponentHub) FactoryQuantum(factory, name string) (quantum float64) {
+	n.quantumLck.RLock()
+	defer n.quantumLck.RUnlock()
+	quantum = n.quantumAll[factory][name]
+	return
+}
+
+// RegisterAuthority will register the prop or trigger authority for all component created by factory, see NetworkComponent.RegisterNetworkAuthority
+func (n *NetworkComponentHub) RegisterAuthority(factory, name string, authority bool) {
+	n.authorityLck.Lock()
+	defer n.authorityLck.Unlock()
+	authorityAll := n.authorityAll[factory]
+	if authorityAll == nil {
+		authorityAll = map[string]bool{}
+		n.authorityAll[factory] = authorityAll
+	}
+	authorityAll[name] = authority
+}
+
+func (n *NetworkComponentHub) UnregisterAuthority(factory, name string) {
+	n.authorityLck.Lock()
+	defer n.authorityLck.Unlock()
+	delete(n.authorityAll[factory], name)
+}
+
+func (n *NetworkComponentHub) FactoryAuthority(factory, name string) (authority bool) {
+	n.authorityLck.RLock()
+	defer n.authorityLck.RUnlock()
+	authority = n.authorityAll[factory][name]
+	return
+}
+
 func (n *NetworkComponentHub) CreateComponent(key, group, owner, cid string) (c *NetworkComponent, err error) {
 	creator := n.factoryAll[key]
 	if creator == nil {
@@ -1054,6 +1628,12 @@ func (n *NetworkComponentHub) CreateComponent(key, group, owner, cid string) (c
 	if err != nil {
 		return
 	}
+	if n.Context != nil && c.Context != n.Context {
+		if c.Context != nil {
+			c.Context.ComponentHub.detachComponent(c)
+		}
+		c.Context = n.Context
+	}
 	n.addComponent(c)
 	return
 }
@@ -1108,13 +1688,116 @@ func (n *NetworkComponentHub) SyncSend(group string, whole bool) []*NetworkSyncD
 				Owner:    c.Owner,
 				Props:    props,
 				Triggers: triggers,
+				Quantum:  c.networkQuantumAll(props),
+			})
+		}
+	}
+	return components
+}
+
+// SyncSendOwned will return the updated authority props and triggers on components owned by user, it is used by client to publish
+func (n *NetworkComponentHub) SyncSendOwned(group, user string) []*NetworkSyncDataComponent {
+	components := []*NetworkSyncDataComponent{}
+	if len(user) < 1 {
+		return components
+	}
+	for _, c := range n.ListGroupComponent(group) {
+		if c.Removed || c.Owner != user {
+			continue
+		}
+		props, triggers := c.sendNetworkAuthority()
+		if len(props) > 0 || len(triggers) > 0 {
+			components = append(components, &NetworkSyncDataComponent{
+				Factory:  c.Factory,
+				CID:      c.CID,
+				Owner:    c.Owner,
+				Props:    props,
+				Triggers: triggers,
+				Quantum:  c.networkQuantumAll(props),
 			})
 		}
 	}
 	return components
 }
 
+// SyncPublish will apply the sync data published by client connection through SyncRecv, only the authority props and triggers
+// on components owned by connection user is accepted, it return the accepted data with decoded value or nil if nothing is accepted
+func (n *NetworkComponentHub) SyncPublish(conn NetworkConnection, data *NetworkSyncData) (accepted *NetworkSyncData) {
+	user := conn.Session().User()
+	received := []*NetworkSyncDataComponent{}
+	decoded := []*NetworkSyncDataComponent{}
+	for _, c := range data.Components {
+		component := n.FindComponent(c.CID)
+		if len(user) < 1 || component == nil || component.Removed || component.Owner != user {
+			Warnf("NetworkComponent(%v) publish by %v is not owner", c.CID, user)
+			continue
+		}
+		props := xmap.M{}
+		for k, v := range c.Props {
+			if !component.NetworkAuthority(k) {
+				Warnf("NetworkComponent(%v) prop %v publish by %v is not authority", c.CID, k, user)
+				continue
+			}
+			value, err := decodeInterpolationValue(v)
+			if err != nil {
+				Warnf("NetworkComponent(%v) prop %v publish by %v decode error %v", c.CID, k, user, err)
+				continue
+			}
+			props[k] = value
+		}
+		triggers, triggerValues := xmap.M{}, xmap.M{}
+		for k, vals := range c.Triggers {
+			if !component.NetworkAuthority(k) {
+				Warnf("NetworkComponent(%v) trigger %v publish by %v is not authority", c.CID, k, user)
+				continue
+			}
+			valAll, _ := vals.([]interface{})
+			values := []interface{}{}
+			for _, v := range valAll {
+				value, _ := decodeInterpolationValue(v)
+				values = append(values, value)
+			}
+			if len(values) > 0 {
+				triggers[k], triggerValues[k] = valAll, values
+			}
+		}
+		if len(props) < 1 && len(triggers) < 1 {
+			continue
+		}
+		received = append(received, &NetworkSyncDataComponent{Factory: component.Factory, CID: c.CID, Owner: component.Owner, Props: props, Triggers: triggers})
+		decoded = append(decoded, &NetworkSyncDataComponent{
+			Factory:  component.Factory,
+			CID:      c.CID,
+			Owner:    component.Owner,
+			Props:    props,
+			Triggers: triggerValues,
+			Quantum:  component.networkQuantumAll(props),
+		})
+	}
+	if len(received) < 1 {
+		return
+	}
+	n.SyncRecv(data.Group, received, false)
+	accepted = &NetworkSyncData{
+		UUID:       data.UUID,
+		Group:      data.Group,
+		Components: decoded,
+	}
+	return
+}
+
 func (n *NetworkComponentHub) SyncRecv(group string, components []*NetworkSyncDataComponent, whole bool) (err error) {
+	err = n.syncRecv(group, components, whole, 0, time.Time{})
+	return
+}
+
+// SyncRecvData will apply the sync data and mark the received components by server tick and time of data
+func (n *NetworkComponentHub) SyncRecvData(data *NetworkSyncData) (err error) {
+	err = n.syncRecv(data.Group, data.Components, data.Whole, data.Tick, data.Time)
+	return
+}
+
+func (n *NetworkComponentHub) syncRecv(group string, components []*NetworkSyncDataComponent, whole bool, tick int64, at time.Time) (err error) {
 	cidAll := map[string]int{}
 	var componnetSynced []*NetworkComponent
 	for _, c := range components {
@@ -1134,9 +1817,13 @@ func (n *NetworkComponentHub) SyncRecv(group string, components []*NetworkSyncDa
 			component.Creator = NetCreator
 		}
 		component.Resync = whole
+		if tick > 0 {
+			component.SyncTick, component.SyncTime = tick, at
+		}
 		if len(c.Props) > 0 {
 			component.RecvNetworkProp(c.Props)
 		}
+		component.pushNetworkSnapshot(at, c.Props)
 		if len(c.Triggers) > 0 {
 			component.RecvNetworkTrigger(c.Triggers)
 		}
@@ -1152,6 +1839,12 @@ func (n *NetworkComponentHub) SyncRecv(group string, components []*NetworkSyncDa
 			}
 		}
 	}
+	for _, c := range n.ListGroupComponent(group) {
+		if cidAll[c.CID] < 1 {
+			//not changed on server, keep the snapshot timeline continuous
+			c.pushNetworkSnapshot(at, nil)
+		}
+	}
 	for _, c := range componnetSynced {
 		c.OnNetworkSynced()
 	}
@@ -1159,7 +1852,7 @@ func (n *NetworkComponentHub) SyncRecv(group string, components []*NetworkSyncDa
 }
 
 func (n *NetworkComponentHub) OnNetworkSync(conn NetworkConnection, data *NetworkSyncData) {
-	n.SyncRecv(data.Group, data.Components, data.Whole)
+	n.SyncRecvData(data)
 }
 
 func (n *NetworkComponentHub) OnNetworkCall(conn NetworkConnection, arg *NetworkCallArg) (ret *NetworkCallResult, err error) {
@@ -1168,6 +1861,6 @@ func (n *NetworkComponentHub) OnNetworkCall(conn NetworkConnection, arg *Network
 		err = fmt.Errorf("NetworkComponent(%v) is not exists", arg.CID)
 		return
 	}
-	ret, err = c.CallNetworkCall(conn.Session(), arg)
+	ret, err = c.CallNetworkCall(&NetworkCallSession{NetworkSession: conn.Session(), Conn: conn}, arg)
 	return
 }
@@ -7,6 +7,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/centny/flame_network/lib/src/network/grpc"
+	"github.com/codingeasygo/util/converter"
 	"github.com/codingeasygo/util/xdebug"
 	"github.com/codingeasygo/util/xmap"
 )
@@ -230,6 +232,12 @@ func TestNetwork(t *testing.T) {
 		Network.Sync("*", nil)
 		Network.Sync("*", nil)
 		time.Sleep(Network.MinSync * 2)
+		tick := Network.Tick()
+		if Network.Sync("*", nil) || Network.Sync("*", nil) || Network.Tick() != tick+1 { //idle sync is limited by MinSync
+			t.Errorf("%v,%v", tick, Network.Tick())
+			return
+		}
+		time.Sleep(Network.MinSync * 2)
 		nc.SetValue("test", 1)
 		Network.Sync("*", transport.conn)
 		nc.Unregister()
@@ -524,3 +532,224 @@ func TestNetwork(t *testing.T) {
 		item.Add(1)
 	}
 }
+
+func TestNetworkContext(t *testing.T) {
+	ctx0 := NewNetworkContext()
+	ctx1 := NewNetworkContext()
+	ctx0.Network.IsServer = true
+	ctx1.Network.IsClient = true
+	ctx1.Network.SetUser("u1")
+	c0 := NewNetworkComponentByContext(ctx0, "test", "test", "u1", "123")
+	c0.RegisterNetworkProp()
+	c1 := NewNetworkComponentByContext(ctx1, "test", "test", "u1", "123")
+	c1.RegisterNetworkProp()
+	if ctx0.ComponentHub.FindComponent("123") != c0 || ctx1.ComponentHub.FindComponent("123") != c1 {
+		t.Error("error")
+		return
+	}
+	if !c0.IsServer() || c0.IsClient() || c0.IsOwner() || c1.IsServer() || !c1.IsClient() || !c1.IsOwner() {
+		t.Error("error")
+		return
+	}
+	if ComponentHub.FindComponent("123") != nil {
+		t.Error("error")
+		return
+	}
+	transport := &TestNetworkTransport{}
+	ctx0.SetTransport(transport)
+	if ctx0.Transport() != transport || ctx1.Transport() != nil {
+		t.Error("error")
+		return
+	}
+	c0.UnregisterNetworkProp()
+	c1.UnregisterNetworkProp()
+	if ctx0.ComponentHub.FindComponent("123") != nil || ctx1.ComponentHub.FindComponent("123") != nil {
+		t.Error("error")
+		return
+	}
+}
+
+func TestNetworkContextFactory(t *testing.T) {
+	ctx0 := NewNetworkContext()
+	ctx1 := NewNetworkContext()
+	ctx0.Network.IsServer = true
+	ctx1.Network.IsClient = true
+	factory := func(key, group, owner, cid string) (c *NetworkComponent, err error) {
+		c = NewNetworkComponent(key, group, owner, cid)
+		c.RegisterNetworkProp()
+		return
+	}
+	ctx0.ComponentHub.RegisterFactory("test", "", factory)
+	ctx1.ComponentHub.RegisterFactory("test", "", factory)
+	c0, err := ctx0.ComponentHub.CreateComponent("test", "g1", "", "c0")
+	if err != nil || c0.Context != ctx0 || !c0.IsServer() {
+		t.Error(err)
+		return
+	}
+	c1, err := ctx1.ComponentHub.CreateComponent("test", "g1", "", "c1")
+	if err != nil || c1.Context != ctx1 || !c1.IsClient() {
+		t.Error(err)
+		return
+	}
+	if ctx0.ComponentHub.FindComponent("c0") != c0 || ctx1.ComponentHub.FindComponent("c1") != c1 {
+		t.Error("error")
+		return
+	}
+	if ComponentHub.FindComponent("c0") != nil || ComponentHub.FindComponent("c1") != nil || ctx0.ComponentHub.FindComponent("c1") != nil {
+		t.Error("error")
+		return
+	}
+}
+
+func TestNetworkPublish(t *testing.T) {
+	server := NewNetworkContext()
+	server.Network.IsServer = true
+	server.ComponentHub.RegisterAuthority("test", "x", true)
+	client := NewNetworkContext()
+	client.Network.IsClient = true
+	client.Network.SetUser("u1")
+	client.ComponentHub.RegisterAuthority("test", "x", true)
+	newComponent := func(ctx *NetworkContext, owner, cid string) *NetworkComponent {
+		c := NewNetworkComponentByContext(ctx, "test", "", owner, cid)
+		c.SetValue("x", 0)
+		c.SetValue("y", 0)
+		c.RegisterNetworkProp()
+		return c
+	}
+	s1, s2 := newComponent(server, "u1", "c1"), newComponent(server, "u2", "c2")
+	c1, c2 := newComponent(client, "u1", "c1"), newComponent(client, "u2", "c2")
+	triggered := make(chan int, 8)
+	s1.RegisterNetworkTrigger("t0", func(v int) { triggered <- v })
+	s1.RegisterNetworkTrigger("t1", func(v int) { triggered <- v })
+	c1.RegisterNetworkTrigger("t0", func(v int) {})
+	c1.RegisterNetworkTrigger("t1", func(v int) {})
+	c1.RegisterNetworkAuthority("t0", true)
+	c1.RegisterNetworkAuthority("t1", true)
+	s1.RegisterNetworkAuthority("t0", true)
+	if !c1.NetworkAuthority("x") || c1.NetworkAuthority("y") || !c1.NetworkAuthority("t0") {
+		t.Error("error")
+		return
+	}
+	client.ComponentHub.SyncSendOwned("", "u1") //clear initial
+	//send owned authority only
+	c1.SetValue("x", 1)
+	c1.SetValue("y", 1)
+	c2.SetValue("x", 1)
+	c1.NetworkTrigger("t0", 10)
+	c1.NetworkTrigger("t1", 11)
+	components := client.ComponentHub.SyncSendOwned("", "u1")
+	if len(components) != 1 || len(components[0].Props) != 1 || len(components[0].Triggers) != 2 {
+		t.Errorf("%v", converter.JSON(components))
+		return
+	}
+	if len(client.ComponentHub.SyncSendOwned("", "")) != 0 || len(client.ComponentHub.SyncSendOwned("", "u1")) != 0 {
+		t.Error("error")
+		return
+	}
+	//publish
+	data := (&NetworkSyncData{UUID: "1", Components: components}).Encode(client.Network.NetworkSession)
+	data.Components = append(data.Components, &NetworkSyncDataComponent{Factory: "test", CID: "c2", Props: xmap.M{"x": "2"}})
+	data.Components = append(data.Components, &NetworkSyncDataComponent{Factory: "test", CID: "c3", Props: xmap.M{"x": "2"}})
+	conn := &TestNetworkConnection{session: NewDefaultNetworkSessionBySafeM()}
+	conn.session.SetUser("u1")
+	accepted := server.ComponentHub.SyncPublish(conn, data)
+	if accepted == nil || len(accepted.Components) != 1 || accepted.Components[0].Props["x"] != int64(1) || len(accepted.Components[0].Triggers) != 1 {
+		t.Errorf("%v", converter.JSON(accepted))
+		return
+	}
+	if s1.Int("x") != 1 || s1.Int("y") != 0 || s2.Int("x") != 0 || <-triggered != 10 || len(triggered) != 0 {
+		t.Errorf("%v,%v", s1.Int("x"), s2.Int("x"))
+		return
+	}
+	//not owner
+	conn.session.SetUser("")
+	if accepted = server.ComponentHub.SyncPublish(conn, data); accepted != nil {
+		t.Errorf("%v", converter.JSON(accepted))
+		return
+	}
+	s1.UnregisterNetworkAuthority("t0")
+	client.ComponentHub.UnregisterAuthority("test", "x")
+	if c1.NetworkAuthority("x") || s1.NetworkAuthority("t0") {
+		t.Error("error")
+		return
+	}
+}
+
+type testDecodePropUnmarshaler struct {
+	Value string
+}
+
+func (t *testDecodePropUnmarshaler) UnmarshalJSON(data []byte) error {
+	t.Value = string(data)
+	return nil
+}
+
+type testNetworkCallback struct {
+	NetworkEvent
+	synced chan *NetworkSyncData
+}
+
+func (t *testNetworkCallback) OnNetworkCall(conn NetworkConnection, arg *NetworkCallArg) (ret *NetworkCallResult, err error) {
+	err = fmt.Errorf("not supported")
+	return
+}
+
+func (t *testNetworkCallback) OnNetworkSync(conn NetworkConnection, data *NetworkSyncData) {
+	t.synced <- data
+}
+
+func TestNetworkCompatible(t *testing.T) {
+	if NewNetworkManager().Context != DefaultContext || NewNetworkServerGRPC(Network).Context != DefaultContext || NewNetworkClientGRPC(nil, Network).ctx != DefaultContext {
+		t.Error("error")
+		return
+	}
+	//connection and callback without optional interface
+	var conn NetworkConnection = &TestNetworkConnection{session: NewDefaultNetworkSessionBySafeM()}
+	if _, ok := conn.(NetworkPingConnection); ok {
+		t.Error("error")
+		return
+	}
+	callback := &testNetworkCallback{NetworkEvent: Network, synced: make(chan *NetworkSyncData, 1)}
+	if _, ok := interface{}(callback).(NetworkPublishCallback); ok {
+		t.Error("error")
+		return
+	}
+	server := NewNetworkServerGRPCByContext(NewNetworkContext(), callback)
+	server.publish(conn, &grpc.SyncData{Id: &grpc.RequestID{Uuid: "1"}})
+}
+
+func TestDecodePropValue(t *testing.T) {
+	if v := DecodePropValue(0, "12"); v != 12 {
+		t.Errorf("%v", v)
+		return
+	}
+	if v := DecodePropValue(0.0, "12.00"); v != 12.0 {
+		t.Errorf("%v", v)
+		return
+	}
+	if v := DecodePropValue("", `"abc"`); v != "abc" {
+		t.Errorf("%v", v)
+		return
+	}
+	if v, ok := DecodePropValue([]float64{}, "[1.5,2.5]").([]float64); !ok || len(v) != 2 || v[1] != 2.5 {
+		t.Errorf("%v", v)
+		return
+	}
+	having := &testDecodePropUnmarshaler{}
+	if v, ok := DecodePropValue(having, "[1]").(*testDecodePropUnmarshaler); !ok || v == having || v.Value != "[1]" || having.Value != "" {
+		t.Errorf("%v", v)
+		return
+	}
+	if v := DecodePropValue(nil, "12.00"); v != 12.0 {
+		t.Errorf("%v", v)
+		return
+	}
+	if v := DecodePropValue(0, "abc"); v != "abc" {
+		t.Errorf("%v", v)
+		return
+	}
+	if v := DecodePropValue(0, 12); v != 12 {
+		t.Errorf("%v", v)
+		return
+	}
+}
@@ -0,0 +1,62 @@
+package network
+
+import (
+	"sync"
+	"time"
+)
+
+// NetworkPingStats is the round trip, jitter and clock offset measured by NTP-style ping on one connection
+type NetworkPingStats struct {
+	RTT     time.Duration // the smoothed round trip which is excluded the process time on remote
+	Jitter  time.Duration // the smoothed variation of round trip
+	Offset  time.Duration // the smoothed offset from local clock to remote clock
+	Samples int64         // the number of measured samples
+}
+
+// NetworkPingMeter will measure the ping stats by NTP-style timestamps, the RTT and Offset is smoothed by 1/8 and Jitter by 1/4
+type NetworkPingMeter struct {
+	stats NetworkPingStats
+	lock  sync.RWMutex
+}
+
+// Add will add one sample by t0 local send, t1 remote receive, t2 remote send and t3 local receive, it return the round trip of sample
+func (n *NetworkPingMeter) Add(t0, t1, t2, t3 time.Time) (rtt time.Duration) {
+	rtt = t3.Sub(t0) - t2.Sub(t1)
+	if rtt < 0 {
+		rtt = 0
+	}
+	offset := (t1.Sub(t0) + t2.Sub(t3)) / 2
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	if n.stats.Samples < 1 {
+		n.stats.RTT, n.stats.Jitter, n.stats.Offset = rtt, 0, offset
+	} else {
+		variation := rtt - n.stats.RTT
+		if variation < 0 {
+			variation = -variation
+		}
+		n.stats.Jitter += (variation - n.stats.Jitter) / 4
+		n.stats.RTT += (rtt - n.stats.RTT) / 8
+		n.stats.Offset += (offset - n.stats.Offset) / 8
+	}
+	n.stats.Samples++
+	return
+}
+
+// Stats will return the current ping stats
+func (n *NetworkPingMeter) Stats() NetworkPingStats {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+	return n.stats
+}
+
+// Reset will clear the measured samples
+func (n *NetworkPingMeter) Reset() {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.stats = NetworkPingStats{}
+}
+
+func pingTimeUnix(nano int64) time.Time {
+	return time.Unix(0, nano)
+}
@@ -0,0 +1,45 @@
+package network
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPingMeter(t *testing.T) {
+	meter := &NetworkPingMeter{}
+	offset := 3 * time.Second
+	t0 := time.Now()
+	t1 := t0.Add(40*time.Millisecond + offset)
+	t2 := t1.Add(10 * time.Millisecond)
+	t3 := t2.Add(40*time.Millisecond - offset)
+	if rtt := meter.Add(t0, t1, t2, t3); rtt != 80*time.Millisecond {
+		t.Error(rtt)
+		return
+	}
+	if stats := meter.Stats(); stats.RTT != 80*time.Millisecond || stats.Offset != offset || stats.Jitter != 0 || stats.Samples != 1 {
+		t.Error(stats)
+		return
+	}
+	t0 = t3
+	t1 = t0.Add(80*time.Millisecond + offset)
+	t2 = t1.Add(10 * time.Millisecond)
+	t3 = t2.Add(80*time.Millisecond - offset)
+	if rtt := meter.Add(t0, t1, t2, t3); rtt != 160*time.Millisecond {
+		t.Error(rtt)
+		return
+	}
+	if stats := meter.Stats(); stats.RTT != 90*time.Millisecond || stats.Offset != offset || stats.Jitter != 20*time.Millisecond || stats.Samples != 2 {
+		t.Error(stats)
+		return
+	}
+	//negative
+	if rtt := meter.Add(t3, t3, t3.Add(time.Second), t3); rtt != 0 {
+		t.Error(rtt)
+		return
+	}
+	meter.Reset()
+	if stats := meter.Stats(); stats.Samples != 0 {
+		t.Error(stats)
+		return
+	}
+}
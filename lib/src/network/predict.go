@@ -0,0 +1,216 @@
+package network
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/codingeasygo/util/converter"
+	"github.com/codingeasygo/util/xmap"
+)
+
+// NetworkInputQueueMax is the max inputs waiting to send, the oldest is dropped when over and it is corrected by server state
+var NetworkInputQueueMax = 1024
+
+const (
+	NetworkInputCall  = "_input"       // the built-in network call to send owner inputs to server by batch
+	NetworkInputAcked = "_input_acked" // the prop of last input sequence which is simulated by server
+)
+
+// NetworkInput is one owner input, it is predicted on client and simulated on server by the same simulate step
+type NetworkInput struct {
+	Sequence int64  `json:"sequence"`
+	Name     string `json:"name"`
+	Arg      string `json:"arg"`
+}
+
+// Decode will decode the input arg to v
+func (n *NetworkInput) Decode(v interface{}) (err error) {
+	err = json.Unmarshal([]byte(n.Arg), v)
+	return
+}
+
+func (n *NetworkInput) String() string {
+	return fmt.Sprintf("NetworkInput(sequence:%v,Name:%v,Arg:%v)", n.Sequence, n.Name, n.Arg)
+}
+
+// NetworkSimulate is the user simulate step to apply input to component state
+type NetworkSimulate func(input *NetworkInput)
+
+type networkPredictor struct {
+	simulate NetworkSimulate
+	sequence int64
+	acked    int64
+	session  string // the owner session key which acked is scoped to on server
+	pending  []*NetworkInput
+	unsent   []*NetworkInput
+	server   xmap.M
+	sending  chan int
+	lock     sync.Mutex
+}
+
+//------ NetworkInput -------//
+
+// RegisterNetworkInput will enable the client prediction for owner, the input is simulated on client immediately and sent to server,
+// the client will rollback to server state and replay the inputs not acked by server when props is synced
+func (n *NetworkComponent) RegisterNetworkInput(simulate NetworkSimulate) (err error) {
+	err = n.RegisterNetworkCall(NetworkInputCall, n.onNetworkInput)
+	if err != nil {
+		return
+	}
+	n.Lock()
+	defer n.Unlock()
+	n.predictor = &networkPredictor{
+		simulate: simulate,
+		server:   xmap.M{},
+	}
+	return
+}
+
+func (n *NetworkComponent) UnregisterNetworkInput() {
+	n.Lock()
+	predictor := n.predictor
+	n.predictor = nil
+	n.Unlock()
+	if predictor != nil {
+		predictor.lock.Lock()
+		if predictor.sending != nil {
+			close(predictor.sending)
+			predictor.sending = nil
+		}
+		predictor.lock.Unlock()
+	}
+	n.UnregisterNetworkCall(NetworkInputCall)
+}
+
+func (n *NetworkComponent) findPredictor() *networkPredictor {
+	n.RLock()
+	defer n.RUnlock()
+	return n.predictor
+}
+
+// NetworkInput will simulate input on local and send it to server if not server
+func (n *NetworkComponent) NetworkInput(name string, arg interface{}) (input *NetworkInput, err error) {
+	predictor := n.findPredictor()
+	if predictor == nil {
+		err = fmt.Errorf("NetworkInput is not registered")
+		return
+	}
+	if !n.IsServer() && !n.IsOwner() {
+		err = fmt.Errorf("NetworkInput is only supported on owner")
+		return
+	}
+	predictor.lock.Lock()
+	predictor.sequence++
+	input = &NetworkInput{
+		Sequence: predictor.sequence,
+		Name:     name,
+		Arg:      converter.JSON(arg),
+	}
+	if !n.IsServer() {
+		predictor.pending = append(predictor.pending, input)
+		predictor.unsent = append(predictor.unsent, input)
+		if over := len(predictor.unsent) - NetworkInputQueueMax; over > 0 {
+			Warnf("NetworkComponent(%v/%v) input queue is full, %v input is dropped", n.Factory, n.CID, over)
+			predictor.unsent = predictor.unsent[over:]
+		}
+		if predictor.sending == nil {
+			predictor.sending = make(chan int, 1)
+			go n.loopSendNetworkInput(predictor, predictor.sending)
+		}
+		select { //notify sender without blocking, the unsent is sent by next batch when sender is busy
+		case predictor.sending <- 1:
+		default:
+		}
+	}
+	predictor.lock.Unlock()
+	//simulate is out of lock, so the simulate step can call NetworkInput, the input is queued before simulate to keep sequence order
+	predictor.simulate(input)
+	return
+}
+
+// loopSendNetworkInput will send all unsent inputs by one call in order when notified
+func (n *NetworkComponent) loopSendNetworkInput(predictor *networkPredictor, sending chan int) {
+	for range sending {
+		predictor.lock.Lock()
+		inputs := predictor.unsent
+		predictor.unsent = nil
+		predictor.lock.Unlock()
+		if len(inputs) < 1 {
+			continue
+		}
+		err := n.NetworkCall(NetworkInputCall, inputs, nil)
+		if err != nil {
+			Warnf("NetworkComponent(%v/%v) send %v input from %v error %v", n.Factory, n.CID, len(inputs), inputs[0], err)
+		}
+	}
+}
+
+func (n *NetworkComponent) onNetworkInput(ctx NetworkSession, uuid string, inputs []*NetworkInput) (err error) {
+	if ctx.User() != n.Owner {
+		err = fmt.Errorf("NetworkComponent(%v) input is only supported on owner", n.CID)
+		return
+	}
+	predictor := n.findPredictor()
+	if predictor == nil {
+		err = fmt.Errorf("NetworkInput is not registered")
+		return
+	}
+	for _, input := range inputs {
+		predictor.lock.Lock()
+		if predictor.session != ctx.Key() { //owner is connected by new session, the input sequence is restarted
+			predictor.session = ctx.Key()
+			predictor.acked = 0
+		}
+		if input.Sequence <= predictor.acked {
+			predictor.lock.Unlock()
+			continue
+		}
+		predictor.acked = input.Sequence
+		predictor.lock.Unlock()
+		predictor.simulate(input)
+		n.SetValue(NetworkInputAcked, input.Sequence)
+	}
+	return
+}
+
+// reconcileNetworkInput will rollback props to server state and replay the inputs which is not acked by server
+func (n *NetworkComponent) reconcileNetworkInput(updated xmap.M) {
+	predictor := n.findPredictor()
+	if predictor == nil || n.IsServer() || !n.IsOwner() {
+		return
+	}
+	predictor.lock.Lock()
+	for k, v := range updated {
+		predictor.server[k] = v
+	}
+	predictor.acked = predictor.server.Int64Def(predictor.acked, NetworkInputAcked)
+	pending := []*NetworkInput{}
+	for _, input := range predictor.pending {
+		if input.Sequence > predictor.acked {
+			pending = append(pending, input)
+		}
+	}
+	predictor.pending = pending
+	n.Lock()
+	n.propAll.Sync(predictor.server)
+	n.Unlock()
+	replay := append([]*NetworkInput{}, pending...)
+	predictor.lock.Unlock()
+	//replay is out of lock, so the simulate step can call NetworkInput
+	for _, input := range replay {
+		predictor.simulate(input)
+	}
+}
+
+// NetworkInputPending will return the inputs which is not acked by server
+func (n *NetworkComponent) NetworkInputPending() (pending []*NetworkInput) {
+	predictor := n.findPredictor()
+	if predictor == nil {
+		return
+	}
+	predictor.lock.Lock()
+	defer predictor.lock.Unlock()
+	pending = append(pending, predictor.pending...)
+	return
+}
@@ -0,0 +1,259 @@
+package network
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/codingeasygo/util/xdebug"
+	"github.com/codingeasygo/util/xmap"
+)
+
+type TestPredictTransport struct {
+	TestNetworkTransport
+	called chan *NetworkCallArg
+}
+
+func (t *TestPredictTransport) NetworkCall(arg *NetworkCallArg) (ret *NetworkCallResult, err error) {
+	t.called <- arg
+	ret = &NetworkCallResult{UUID: arg.UUID, CID: arg.CID, Name: arg.Name}
+	return
+}
+
+func testPredictSimulate(c *NetworkComponent) NetworkSimulate {
+	return func(input *NetworkInput) {
+		var step float64
+		if err := input.Decode(&step); err != nil {
+			panic(err)
+		}
+		c.SetValue("x", c.Float64Def(0, "x")+step)
+	}
+}
+
+func TestPredict(t *testing.T) {
+	tester := xdebug.CaseTester{
+		0: 1,
+	}
+	if tester.Run() { //reconcile
+		transport := &TestPredictTransport{called: make(chan *NetworkCallArg, 8)}
+		client := NewNetworkContext()
+		client.Network.IsClient = true
+		client.Network.SetUser("u1")
+		client.SetTransport(transport)
+		c := NewNetworkComponentByContext(client, "test", "", "u1", "123")
+		c.SetValue("x", 0.0)
+		if _, err := c.NetworkInput("move", 1); err == nil {
+			t.Error("error")
+			return
+		}
+		c.RegisterNetworkInput(testPredictSimulate(c))
+		defer c.UnregisterNetworkInput()
+		for i := 0; i < 3; i++ {
+			if _, err := c.NetworkInput("move", 1); err != nil {
+				t.Error(err)
+				return
+			}
+		}
+		if c.Float64("x") != 3 || len(c.NetworkInputPending()) != 3 {
+			t.Errorf("%v", c.Float64("x"))
+			return
+		}
+		for sent := 0; sent < 3; {
+			arg := <-transport.called
+			inputs := []*NetworkInput{}
+			if err := json.Unmarshal([]byte(arg.Arg), &inputs); err != nil || arg.Name != NetworkInputCall || len(inputs) < 1 {
+				t.Errorf("%v,%v", err, arg)
+				return
+			}
+			for _, input := range inputs {
+				sent++
+				if input.Sequence != int64(sent) || input.Name != "move" {
+					t.Errorf("%v", arg)
+					return
+				}
+			}
+		}
+		//server acked first input
+		c.RecvNetworkProp(xmap.M{"x": "1", NetworkInputAcked: "1"})
+		if c.Float64("x") != 3 || len(c.NetworkInputPending()) != 2 {
+			t.Errorf("%v", c.Float64("x"))
+			return
+		}
+		//server corrected state
+		c.RecvNetworkProp(xmap.M{"x": "1.5", NetworkInputAcked: "2"})
+		if c.Float64("x") != 2.5 || len(c.NetworkInputPending()) != 1 {
+			t.Errorf("%v", c.Float64("x"))
+			return
+		}
+		//other prop synced, rollback to last server state and replay
+		c.RecvNetworkProp(xmap.M{"y": "1"})
+		if c.Float64("x") != 2.5 || len(c.NetworkInputPending()) != 1 {
+			t.Errorf("%v", c.Float64("x"))
+			return
+		}
+		c.RecvNetworkProp(xmap.M{"x": "2.5", NetworkInputAcked: "3"})
+		if c.Float64("x") != 2.5 || len(c.NetworkInputPending()) != 0 {
+			t.Errorf("%v", c.Float64("x"))
+			return
+		}
+		//simulate step call NetworkInput
+		combo := NewNetworkComponentByContext(client, "test", "", "u1", "125")
+		combo.SetValue("x", 0.0)
+		combo.RegisterNetworkInput(func(input *NetworkInput) {
+			combo.SetValue("x", combo.Float64("x")+1)
+			if input.Name == "combo" && len(combo.NetworkInputPending()) < 3 {
+				combo.NetworkInput("move", 1)
+			}
+		})
+		defer combo.UnregisterNetworkInput()
+		if _, err := combo.NetworkInput("combo", 1); err != nil || combo.Float64("x") != 2 || len(combo.NetworkInputPending()) != 2 {
+			t.Errorf("%v,%v", err, combo.Float64("x"))
+			return
+		}
+		combo.RecvNetworkProp(xmap.M{"x": "0"})
+		if combo.Float64("x") != 3 || len(combo.NetworkInputPending()) != 3 {
+			t.Errorf("%v", combo.Float64("x"))
+			return
+		}
+		for sent := 0; sent < 3; {
+			inputs := []*NetworkInput{}
+			json.Unmarshal([]byte((<-transport.called).Arg), &inputs)
+			sent += len(inputs)
+		}
+		//not owner
+		other := NewNetworkComponentByContext(client, "test", "", "u2", "124")
+		other.RegisterNetworkInput(testPredictSimulate(other))
+		if _, err := other.NetworkInput("move", 1); err == nil {
+			t.Error("error")
+			return
+		}
+		other.UnregisterNetworkInput()
+		if len(other.NetworkInputPending()) != 0 {
+			t.Error("error")
+			return
+		}
+	}
+	if tester.Run() { //server
+		server := NewNetworkContext()
+		server.Network.IsServer = true
+		c := NewNetworkComponentByContext(server, "test", "", "u1", "123")
+		c.SetValue("x", 0.0)
+		c.RegisterNetworkInput(testPredictSimulate(c))
+		if _, err := c.NetworkInput("move", 1); err != nil || c.Float64("x") != 1 {
+			t.Errorf("%v,%v", err, c.Float64("x"))
+			return
+		}
+		session := NewDefaultNetworkSessionBySafeM()
+		call := func(sequence int64) (err error) {
+			_, err = c.CallNetworkCall(session, &NetworkCallArg{UUID: "1", CID: "123", Name: NetworkInputCall, Arg: JsonEncode([]*NetworkInput{{Sequence: sequence, Name: "move", Arg: "2"}})})
+			return
+		}
+		if err := call(1); err == nil {
+			t.Error("error")
+			return
+		}
+		session.SetUser("u1")
+		if err := call(1); err != nil || c.Float64("x") != 3 || c.Int64(NetworkInputAcked) != 1 {
+			t.Errorf("%v,%v", err, c.Float64("x"))
+			return
+		}
+		if err := call(1); err != nil || c.Float64("x") != 3 {
+			t.Errorf("%v,%v", err, c.Float64("x"))
+			return
+		}
+		//owner reconnected by new session
+		session = NewDefaultNetworkSessionBySafeM()
+		session.SetUser("u1")
+		session.SetKey("s2")
+		if err := call(1); err != nil || c.Float64("x") != 5 || c.Int64(NetworkInputAcked) != 1 {
+			t.Errorf("%v,%v", err, c.Float64("x"))
+			return
+		}
+		//batch inputs
+		batch := []*NetworkInput{{Sequence: 1, Name: "move", Arg: "2"}, {Sequence: 2, Name: "move", Arg: "2"}, {Sequence: 3, Name: "move", Arg: "2"}}
+		if _, err := c.CallNetworkCall(session, &NetworkCallArg{UUID: "1", CID: "123", Name: NetworkInputCall, Arg: JsonEncode(batch)}); err != nil || c.Float64("x") != 9 || c.Int64(NetworkInputAcked) != 3 {
+			t.Errorf("%v,%v", err, c.Float64("x"))
+			return
+		}
+		c.UnregisterNetworkInput()
+		if err := call(2); err == nil {
+			t.Error("error")
+			return
+		}
+		if err := c.onNetworkInput(session, "1", []*NetworkInput{{Sequence: 3}}); err == nil {
+			t.Error("error")
+			return
+		}
+	}
+	if tester.Run() { //grpc
+		server := NewNetworkContext()
+		server.Network.IsServer = true
+		transport := NewNetworkTransportGRPCByContext(server)
+		transport.GrpcAddress, _ = url.Parse("grpc://127.0.0.1:50068")
+		transport.WebAddress, _ = url.Parse("ws://127.0.0.1:50069")
+		server.SetTransport(transport)
+		sc := NewNetworkComponentByContext(server, "test", "", "u1", "123")
+		sc.SetValue("x", 0.0)
+		sc.RegisterNetworkProp()
+		sc.RegisterNetworkInput(testPredictSimulate(sc))
+		sc.RegisterNetworkCall("join", func(ctx NetworkSession, uuid string, user string) (err error) {
+			ctx.SetUser(user)
+			return
+		})
+		if err := server.Network.Start(); err != nil {
+			t.Error(err)
+			return
+		}
+		defer server.Network.Stop()
+
+		client := NewNetworkContext()
+		client.Network.IsClient = true
+		client.Network.SetUser("u1")
+		client.Network.SetKey("predict")
+		transport = NewNetworkTransportGRPCByContext(client)
+		transport.GrpcAddress, _ = url.Parse("grpc://127.0.0.1:50068")
+		client.SetTransport(transport)
+		synced := make(chan int, 8)
+		var cc *NetworkComponent
+		client.ComponentHub.RegisterFactory("test", "", func(key, group, owner, cid string) (c *NetworkComponent, err error) {
+			c = NewNetworkComponentByContext(client, key, group, owner, cid)
+			c.RegisterNetworkInput(testPredictSimulate(c))
+			c.OnNetworkSynced = func() { synced <- 1 }
+			cc = c
+			return
+		})
+		if err := client.Network.Start(); err != nil {
+			t.Error(err)
+			return
+		}
+		defer client.Network.Stop()
+		if err := client.Network.Ready(); err != nil {
+			t.Error(err)
+			return
+		}
+		<-synced
+		if err := cc.NetworkCall("join", "u1", nil); err != nil {
+			t.Error(err)
+			return
+		}
+		for i := 0; i < 3; i++ {
+			cc.NetworkInput("move", 1)
+		}
+		if cc.Float64("x") != 3 {
+			t.Errorf("%v", cc.Float64("x"))
+			return
+		}
+		for sc.Int64Def(0, NetworkInputAcked) < 3 {
+			time.Sleep(10 * time.Millisecond)
+		}
+		time.Sleep(server.Network.MinSync)
+		server.Network.Sync("", nil)
+		<-synced
+		if cc.Float64("x") != 3 || len(cc.NetworkInputPending()) != 0 {
+			t.Errorf("%v,%v", cc.Float64("x"), cc.NetworkInputPending())
+			return
+		}
+		cc.UnregisterNetworkInput()
+	}
+}
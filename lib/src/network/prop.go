@@ -0,0 +1,99 @@
+package network
+
+import (
+	"encoding/json"
+	"reflect"
+	"sync"
+)
+
+// NetworkProp is the typed prop bound to the key of component, the value received from remote is decoded to T
+// and the typed callback is called when value is updated by local or remote
+type NetworkProp[T any] struct {
+	Component *NetworkComponent
+	Name      string
+	Default   T
+	value     T
+	updateAll []func(value T)
+	lock      sync.RWMutex
+}
+
+// NewNetworkProp will declare the typed prop on component by name, the value is set to def when it is not exists,
+// the OnPropUpdate on name is replaced by typed prop
+func NewNetworkProp[T any](c *NetworkComponent, name string, def T) (prop *NetworkProp[T]) {
+	prop = &NetworkProp[T]{
+		Component: c,
+		Name:      name,
+		Default:   def,
+		value:     def,
+	}
+	c.OnPropUpdate[name] = prop.onPropUpdate
+	if v, err := c.ValueVal(name); err == nil {
+		prop.onPropUpdate(name, v)
+	} else {
+		c.SetValue(name, def)
+	}
+	return
+}
+
+// Get will return the current value
+func (p *NetworkProp[T]) Get() T {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	return p.value
+}
+
+// Set will set the value to component, it is synced to remote as normal prop
+func (p *NetworkProp[T]) Set(value T) {
+	p.Component.SetValue(p.Name, value)
+}
+
+// OnUpdate will add the typed callback which is called when value is updated, it is called in component lock when updated by local
+func (p *NetworkProp[T]) OnUpdate(call func(value T)) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.updateAll = append(p.updateAll, call)
+}
+
+func (p *NetworkProp[T]) onPropUpdate(key string, val interface{}) {
+	value, err := DecodeNetworkProp[T](val)
+	if err != nil {
+		Warnf("NetworkComponent(%v) decode prop %v=%v error %v", p.Component.CID, key, val, err)
+		return
+	}
+	p.lock.Lock()
+	p.value = value
+	updateAll := p.updateAll
+	p.lock.Unlock()
+	for _, call := range updateAll {
+		call(value)
+	}
+}
+
+// DecodeNetworkProp will decode the prop value to T, the value is raw value on local or loopback and json string on grpc client
+func DecodeNetworkProp[T any](v interface{}) (value T, err error) {
+	valueType := reflect.TypeOf(&value).Elem()
+	if valueType.Kind() == reflect.Interface {
+		value, _ = v.(T)
+		return
+	}
+	if s, ok := v.(string); ok {
+		if valueType.Kind() != reflect.String {
+			err = json.Unmarshal([]byte(s), &value)
+			return
+		}
+		if len(s) > 1 && s[0] == '"' && s[len(s)-1] == '"' && json.Unmarshal([]byte(s), &value) == nil {
+			return
+		}
+		reflect.ValueOf(&value).Elem().Set(reflect.ValueOf(s).Convert(valueType))
+		return
+	}
+	if typed, ok := v.(T); ok || v == nil {
+		value = typed
+		return
+	}
+	data, err := json.Marshal(v)
+	if err == nil {
+		err = json.Unmarshal(data, &value)
+	}
+	return
+}
@@ -0,0 +1,95 @@
+package network
+
+import (
+	"testing"
+)
+
+type TestPropVec [2]float64
+
+type TestPropName string
+
+func TestDecodeNetworkProp(t *testing.T) {
+	if v, err := DecodeNetworkProp[int]("1"); err != nil || v != 1 {
+		t.Errorf("%v,%v", err, v)
+		return
+	}
+	if v, err := DecodeNetworkProp[int](int64(2)); err != nil || v != 2 {
+		t.Errorf("%v,%v", err, v)
+		return
+	}
+	if v, err := DecodeNetworkProp[float64](1.5); err != nil || v != 1.5 {
+		t.Errorf("%v,%v", err, v)
+		return
+	}
+	if v, err := DecodeNetworkProp[string](`"abc"`); err != nil || v != "abc" {
+		t.Errorf("%v,%v", err, v)
+		return
+	}
+	if v, err := DecodeNetworkProp[string]("abc"); err != nil || v != "abc" {
+		t.Errorf("%v,%v", err, v)
+		return
+	}
+	if v, err := DecodeNetworkProp[TestPropName]("abc"); err != nil || v != "abc" {
+		t.Errorf("%v,%v", err, v)
+		return
+	}
+	if v, err := DecodeNetworkProp[TestPropVec]("[1.5,2.50]"); err != nil || v[0] != 1.5 || v[1] != 2.5 {
+		t.Errorf("%v,%v", err, v)
+		return
+	}
+	if v, err := DecodeNetworkProp[TestPropVec]([]interface{}{1.5, 2.5}); err != nil || v[1] != 2.5 {
+		t.Errorf("%v,%v", err, v)
+		return
+	}
+	if v, err := DecodeNetworkProp[*TestCallSum](nil); err != nil || v != nil {
+		t.Errorf("%v,%v", err, v)
+		return
+	}
+	if v, err := DecodeNetworkProp[interface{}]("abc"); err != nil || v != "abc" {
+		t.Errorf("%v,%v", err, v)
+		return
+	}
+	if _, err := DecodeNetworkProp[int]("abc"); err == nil {
+		t.Error(err)
+		return
+	}
+	if _, err := DecodeNetworkProp[int](func() {}); err == nil {
+		t.Error(err)
+		return
+	}
+}
+
+func TestNetworkProp(t *testing.T) {
+	c := NewNetworkComponentByContext(NewNetworkContext(), "test", "", "", "c0")
+	c.SetValue("name", "n0")
+	name := NewNetworkProp(c, "name", "")
+	using := NewNetworkProp(c, "weapon.using", 1)
+	direct := NewNetworkProp(c, "weapon.direct", TestPropVec{0, 1})
+	if name.Get() != "n0" || using.Get() != 1 || direct.Get() != (TestPropVec{0, 1}) || c.IntDef(0, "weapon.using") != 1 {
+		t.Errorf("%v,%v,%v", name.Get(), using.Get(), direct.Get())
+		return
+	}
+	updated := []int{}
+	using.OnUpdate(func(value int) { updated = append(updated, value) })
+	using.Set(2)
+	if using.Get() != 2 || c.IntDef(0, "weapon.using") != 2 {
+		t.Errorf("%v", using.Get())
+		return
+	}
+	//json string by grpc
+	c.RecvNetworkProp(map[string]interface{}{"weapon.using": "3", "weapon.direct": "[1.00,0.00]", "name": `"n1"`})
+	if using.Get() != 3 || direct.Get() != (TestPropVec{1, 0}) || name.Get() != "n1" {
+		t.Errorf("%v,%v,%v", name.Get(), using.Get(), direct.Get())
+		return
+	}
+	//decode fail
+	c.RecvNetworkProp(map[string]interface{}{"weapon.using": "x"})
+	if using.Get() != 3 {
+		t.Errorf("%v", using.Get())
+		return
+	}
+	if len(updated) != 2 || updated[0] != 2 || updated[1] != 3 {
+		t.Errorf("%v", updated)
+		return
+	}
+}
@@ -0,0 +1,201 @@
+package network
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/codingeasygo/util/xmap"
+)
+
+// QuantumDecimals return the decimal places which can present the value quantized by quantum, at least 1 to keep float type in json
+func QuantumDecimals(quantum float64) (decimals int) {
+	decimals = 1
+	if quantum > 0 && quantum < 1 {
+		decimals = int(math.Ceil(-math.Log10(quantum) - 1e-9))
+	}
+	if decimals < 1 {
+		decimals = 1
+	}
+	return
+}
+
+// QuantizeValue will round all float in v to the multiple of quantum, v is normalized by NormalizeBinaryValue,
+// so the float slice/array, json.Marshaler and struct is supported
+func QuantizeValue(v interface{}, quantum float64) (value interface{}, err error) {
+	value, err = NormalizeBinaryValue(v)
+	if err == nil && quantum > 0 {
+		value = quantizeNormalized(value, quantum)
+	}
+	return
+}
+
+func quantizeNormalized(v interface{}, quantum float64) (value interface{}) {
+	switch v := v.(type) {
+	case float64:
+		value = math.Round(v/quantum) * quantum
+	case []float64:
+		vals := make([]float64, len(v))
+		for i, f := range v {
+			vals[i] = math.Round(f/quantum) * quantum
+		}
+		value = vals
+	case []interface{}:
+		vals := make([]interface{}, len(v))
+		for i, item := range v {
+			vals[i] = quantizeNormalized(item, quantum)
+		}
+		value = vals
+	case map[string]interface{}:
+		vals := map[string]interface{}{}
+		for k, item := range v {
+			vals[k] = quantizeNormalized(item, quantum)
+		}
+		value = vals
+	default:
+		value = v
+	}
+	return
+}
+
+// QuantizeProp will quantize the props which having quantum
+func QuantizeProp(props xmap.M, quantum map[string]float64) xmap.M {
+	propAll := xmap.M{}
+	for k, v := range props {
+		if q := quantum[k]; q > 0 {
+			if val, err := QuantizeValue(v, q); err == nil {
+				v = val
+			}
+		}
+		propAll[k] = v
+	}
+	return propAll
+}
+
+// JsonEncodeByQuantum will quantize v by quantum and encode it to json by the decimal places of QuantumDecimals
+func JsonEncodeByQuantum(v interface{}, quantum float64) string {
+	if quantum <= 0 {
+		return JsonEncode(v)
+	}
+	value, err := QuantizeValue(v, quantum)
+	if err != nil {
+		return JsonEncode(v)
+	}
+	return string(appendQuantumJSON(nil, value, QuantumDecimals(quantum)))
+}
+
+// JsonEncodeDecoded will encode the value decoded by NetworkBinaryDecoder to json, the float is encoded by full precision and keep float type
+func JsonEncodeDecoded(v interface{}) string {
+	return string(appendQuantumJSON(nil, v, -1))
+}
+
+// appendFloatJSON will append f by decimals, the decimals < 0 is the shortest presentation and ".0" is appended to keep float type
+func appendFloatJSON(buf []byte, f float64, decimals int) []byte {
+	start := len(buf)
+	buf = strconv.AppendFloat(buf, f, 'f', decimals, 64)
+	if decimals < 0 && !bytes.ContainsRune(buf[start:], '.') {
+		buf = append(buf, ".0"...)
+	}
+	return buf
+}
+
+func appendQuantumJSON(buf []byte, v interface{}, decimals int) []byte {
+	switch v := v.(type) {
+	case nil:
+		buf = append(buf, "null"...)
+	case bool:
+		buf = strconv.AppendBool(buf, v)
+	case int64:
+		buf = strconv.AppendInt(buf, v, 10)
+	case float64:
+		buf = appendFloatJSON(buf, v, decimals)
+	case string:
+		data, _ := json.Marshal(v)
+		buf = append(buf, data...)
+	case []float64:
+		buf = append(buf, '[')
+		for i, f := range v {
+			if i > 0 {
+				buf = append(buf, ',')
+			}
+			buf = appendFloatJSON(buf, f, decimals)
+		}
+		buf = append(buf, ']')
+	case []interface{}:
+		buf = append(buf, '[')
+		for i, item := range v {
+			if i > 0 {
+				buf = append(buf, ',')
+			}
+			buf = appendQuantumJSON(buf, item, decimals)
+		}
+		buf = append(buf, ']')
+	case map[string]interface{}:
+		keys := []string{}
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		buf = append(buf, '{')
+		for i, k := range keys {
+			if i > 0 {
+				buf = append(buf, ',')
+			}
+			buf = appendQuantumJSON(buf, k, decimals)
+			buf = append(buf, ':')
+			buf = appendQuantumJSON(buf, v[k], decimals)
+		}
+		buf = append(buf, '}')
+	}
+	return buf
+}
+
+// QuantumChanged return true if having is changed from last by not less than quantum, both value must be normalized,
+// the not float value is changed when it is not equal.
+func QuantumChanged(last, having interface{}, quantum float64) bool {
+	switch last := last.(type) {
+	case float64, int64:
+		l, _ := interpolationFloat(last)
+		f, ok := interpolationFloat(having)
+		return !ok || math.Abs(f-l) >= quantum
+	case []float64:
+		vals, ok := having.([]float64)
+		if !ok || len(vals) != len(last) {
+			return true
+		}
+		for i, f := range last {
+			if math.Abs(vals[i]-f) >= quantum {
+				return true
+			}
+		}
+		return false
+	case []interface{}:
+		vals, ok := having.([]interface{})
+		if !ok || len(vals) != len(last) {
+			return true
+		}
+		for i, item := range last {
+			if QuantumChanged(item, vals[i], quantum) {
+				return true
+			}
+		}
+		return false
+	case map[string]interface{}:
+		vals, ok := having.(map[string]interface{})
+		if !ok || len(vals) != len(last) {
+			return true
+		}
+		for k, item := range last {
+			val, ok := vals[k]
+			if !ok || QuantumChanged(item, val, quantum) {
+				return true
+			}
+		}
+		return false
+	default:
+		return !reflect.DeepEqual(last, having)
+	}
+}
@@ -0,0 +1,127 @@
+package network
+
+import (
+	"math"
+	"testing"
+
+	"github.com/codingeasygo/util/xdebug"
+	"github.com/codingeasygo/util/xmap"
+)
+
+func TestQuantum(t *testing.T) {
+	tester := xdebug.CaseTester{
+		0: 1,
+	}
+	if tester.Run() { //value
+		for quantum, decimals := range map[float64]int{0: 1, 2: 1, 1: 1, 0.5: 1, 0.1: 1, 0.05: 2, 0.01: 2, 1.0 / 256: 3} {
+			if v := QuantumDecimals(quantum); v != decimals {
+				t.Errorf("%v,%v,%v", quantum, decimals, v)
+				return
+			}
+		}
+		for _, c := range []struct {
+			Value   interface{}
+			Quantum float64
+			Except  string
+		}{
+			{1.26, 0.1, "1.3"},
+			{1.24, 0.1, "1.2"},
+			{3, 0.1, "3"},
+			{1.0, 1, "1.0"},
+			{math.Pi, 1.0 / 256, "3.141"},
+			{TestBinaryVec{1.26, 2.24}, 0.1, "[1.3,2.2]"},
+			{[]interface{}{1, "a", 1.26, nil, true, []float64{1.26}}, 0.1, `[1,"a",1.3,null,true,[1.3]]`},
+			{map[string]interface{}{"b": 1.26, "a": 1}, 0.1, `{"a":1,"b":1.3}`},
+			{1.256, 0, "1.26"},
+			{&TestBinaryErrValue{}, 0.1, JsonEncode(&TestBinaryErrValue{})},
+		} {
+			if v := JsonEncodeByQuantum(c.Value, c.Quantum); v != c.Except {
+				t.Errorf("%v,%v,%v", c.Value, c.Except, v)
+				return
+			}
+		}
+		if QuantumChanged(1.0, 1.05, 0.1) || !QuantumChanged(1.0, 1.1, 0.1) || !QuantumChanged(1.0, "a", 0.1) || QuantumChanged(int64(1), 1.05, 0.1) || !QuantumChanged(int64(1), int64(2), 0.1) {
+			t.Error("error")
+			return
+		}
+		if QuantumChanged([]float64{1, 2}, []float64{1.05, 2}, 0.1) || !QuantumChanged([]float64{1, 2}, []float64{1, 2.1}, 0.1) || !QuantumChanged([]float64{1}, []float64{1, 2}, 0.1) {
+			t.Error("error")
+			return
+		}
+		if QuantumChanged([]interface{}{int64(1), 1.0}, []interface{}{int64(1), 1.05}, 0.1) || !QuantumChanged([]interface{}{int64(1), 1.0}, []interface{}{int64(2), 1.0}, 0.1) || !QuantumChanged([]interface{}{}, []float64{}, 0.1) {
+			t.Error("error")
+			return
+		}
+		if QuantumChanged(map[string]interface{}{"a": 1.0}, map[string]interface{}{"a": 1.05}, 0.1) || !QuantumChanged(map[string]interface{}{"a": 1.0}, map[string]interface{}{"b": 1.0}, 0.1) || !QuantumChanged(map[string]interface{}{}, 1, 0.1) {
+			t.Error("error")
+			return
+		}
+		props := QuantizeProp(xmap.M{"a": 1.26, "b": 1.26, "c": &TestBinaryErrValue{}}, map[string]float64{"a": 0.1, "c": 0.1})
+		if props["a"] != 1.3 || props["b"] != 1.26 {
+			t.Errorf("%v", props)
+			return
+		}
+	}
+	if tester.Run() { //component
+		ctx := NewNetworkContext()
+		ctx.ComponentHub.RegisterQuantum("test", "p0", 0.1)
+		ctx.ComponentHub.RegisterQuantum("test", "p1", 0.1)
+		c := NewNetworkComponentByContext(ctx, "test", "test", "", "123")
+		c.RegisterNetworkQuantum("p1", 1)
+		c.RegisterNetworkQuantum("p2", 0.1)
+		c.UnregisterNetworkQuantum("p2")
+		if c.NetworkQuantum("p0") != 0.1 || c.NetworkQuantum("p1") != 1 || c.NetworkQuantum("p2") != 0 {
+			t.Error("error")
+			return
+		}
+		c.SetValue("p0", 1.0)
+		c.SetValue("p1", TestBinaryVec{1, 1})
+		c.SetValue("p2", 1.0)
+		c.RegisterNetworkProp()
+		components := ctx.ComponentHub.SyncSend("test", false)
+		if len(components) != 1 || len(components[0].Props) != 3 || len(components[0].Quantum) != 2 {
+			t.Errorf("%v", components)
+			return
+		}
+		//change less than quantum
+		c.SetValue("p0", 1.05)
+		c.SetValue("p1", TestBinaryVec{1.5, 1})
+		c.SetValue("p2", 1.01)
+		components = ctx.ComponentHub.SyncSend("test", false)
+		if len(components) != 1 || len(components[0].Props) != 1 || components[0].Props["p2"] != 1.01 {
+			t.Errorf("%v", components)
+			return
+		}
+		//change is accumulated from last synced
+		c.SetValue("p0", 1.12)
+		c.SetValue("p1", TestBinaryVec{2, 1})
+		components = ctx.ComponentHub.SyncSend("test", false)
+		if len(components) != 1 || len(components[0].Props) != 2 {
+			t.Errorf("%v", components)
+			return
+		}
+		encoded := components[0].Encode(nil)
+		if encoded.Props["p0"] != "1.1" || encoded.Props["p1"] != "[2.0,1.0]" {
+			t.Errorf("%v", encoded.Props)
+			return
+		}
+		filtered := components[0].Filter(nil)
+		if math.Abs(filtered.Props.Float64("p0")-1.1) > 0.0001 {
+			t.Errorf("%v", filtered.Props)
+			return
+		}
+		//whole is not changed
+		components = ctx.ComponentHub.SyncSend("test", true)
+		if len(components) != 1 || len(components[0].Props) != 3 {
+			t.Errorf("%v", components)
+			return
+		}
+		c.Delete("p0")
+		c.Clear()
+		ctx.ComponentHub.UnregisterQuantum("test", "p0")
+		if c.NetworkQuantum("p0") != 0 {
+			t.Error("error")
+			return
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package network
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// NetworkBackoff is the exponential backoff policy with jitter to restart the broken client connection
+type NetworkBackoff struct {
+	Min         time.Duration // the delay before first attempt
+	Max         time.Duration // the max delay before attempt
+	Factor      float64       // the delay is multiplied by factor on each attempt
+	Jitter      float64       // the delay is randomized in [delay*(1-Jitter), delay*(1+Jitter)]
+	MaxAttempts int           // the max attempts before closed, zero is unlimited
+	random      *rand.Rand
+	lock        sync.Mutex
+}
+
+func NewNetworkBackoff() (backoff *NetworkBackoff) {
+	backoff = NewNetworkBackoffBySeed(time.Now().UnixNano())
+	return
+}
+
+func NewNetworkBackoffBySeed(seed int64) (backoff *NetworkBackoff) {
+	backoff = &NetworkBackoff{
+		Min:    100 * time.Millisecond,
+		Max:    10 * time.Second,
+		Factor: 2,
+		Jitter: 0.2,
+		random: rand.New(rand.NewSource(seed)),
+	}
+	return
+}
+
+// Next will return the delay before attempt which is start by 1, ok is false when attempts is exhausted
+func (n *NetworkBackoff) Next(attempt int) (delay time.Duration, ok bool) {
+	if attempt < 1 || (n.MaxAttempts > 0 && attempt > n.MaxAttempts) {
+		return
+	}
+	ok = true
+	value := float64(n.Min)
+	for i := 1; i < attempt && value < float64(n.Max); i++ {
+		value *= n.Factor
+	}
+	if n.Max > 0 && value > float64(n.Max) {
+		value = float64(n.Max)
+	}
+	if n.Jitter > 0 {
+		n.lock.Lock()
+		value *= 1 + n.Jitter*(2*n.random.Float64()-1)
+		n.lock.Unlock()
+	}
+	delay = time.Duration(value)
+	return
+}
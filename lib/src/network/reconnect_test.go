@@ -0,0 +1,169 @@
+package network
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	backoff := NewNetworkBackoffBySeed(1)
+	backoff.Jitter = 0
+	backoff.Max = time.Second
+	for i, expect := range []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second, time.Second} {
+		if delay, ok := backoff.Next(i + 1); !ok || delay != expect {
+			t.Errorf("%v,%v,%v", i, delay, ok)
+			return
+		}
+	}
+	backoff.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		if delay, _ := backoff.Next(1); delay < 50*time.Millisecond || delay > 150*time.Millisecond {
+			t.Error(delay)
+			return
+		}
+	}
+	backoff.MaxAttempts = 2
+	if _, ok := backoff.Next(3); ok {
+		t.Error("error")
+		return
+	}
+	if _, ok := backoff.Next(0); ok {
+		t.Error("error")
+		return
+	}
+	NewNetworkBackoff()
+}
+
+func waitSessionState(event *TestSessionEvent, state NetworkState) (err error) {
+	timer := time.NewTimer(5 * time.Second)
+	defer timer.Stop()
+	for {
+		select {
+		case having := <-event.states:
+			if strings.HasPrefix(having, fmt.Sprintf("%v,", state)) {
+				return
+			}
+		case <-timer.C:
+			err = fmt.Errorf("wait state %v timeout", state)
+			return
+		}
+	}
+}
+
+func TestGRPCReconnect(t *testing.T) {
+	auth := NewNetworkTokenAuthenticator([]byte("123"))
+	token, _ := auth.SignUser("u1", "", time.Hour)
+	server := NewNetworkContext()
+	server.Network.IsServer = true
+	server.Network.Authenticator = auth
+	transport := NewNetworkTransportGRPCByContext(server)
+	transport.GrpcAddress, _ = url.Parse("grpc://127.0.0.1:50085")
+	transport.WebAddress, _ = url.Parse("ws://127.0.0.1:50086")
+	server.SetTransport(transport)
+	sc := NewNetworkComponentByContext(server, "test", "", "", "c1")
+	sc.SetValue("x", 1)
+	sc.RegisterNetworkProp()
+	if err := server.Network.Start(); err != nil {
+		t.Error(err)
+		return
+	}
+	defer server.Network.Stop()
+	for _, streamOn := range []bool{true, false} {
+		client := NewNetworkContext()
+		client.Network.IsClient = true
+		client.Network.SetToken(token)
+		client.Network.Reconnect = NewNetworkBackoffBySeed(1)
+		client.Network.Reconnect.Min = 50 * time.Millisecond
+		client.Network.Reconnect.Max = 100 * time.Millisecond
+		event := &TestSessionEvent{states: make(chan string, 1024)}
+		client.EventHub.RegisterNetworkEvent("*", event)
+		clientTransport := NewNetworkTransportGRPCByContext(client)
+		clientTransport.StreamOn = streamOn
+		clientTransport.GrpcAddress, _ = url.Parse("grpc://127.0.0.1:50085")
+		client.SetTransport(clientTransport)
+		synced := make(chan string, 64)
+		client.ComponentHub.RegisterFactory("test", "", func(key, group, owner, cid string) (c *NetworkComponent, err error) {
+			c = NewNetworkComponentByContext(client, key, group, owner, cid)
+			c.RegisterNetworkProp()
+			c.OnNetworkSynced = func() { synced <- c.Str("x") }
+			return
+		})
+		if err := client.Network.Start(); err != nil {
+			t.Error(err)
+			return
+		}
+		if err := client.Network.Ready(); err != nil {
+			t.Error(err)
+			return
+		}
+		if state := <-event.states; state != "100,<nil>" {
+			t.Errorf("%v,%v", streamOn, state)
+			return
+		}
+		if state := <-event.states; state != "200,<nil>" || <-synced != "1" {
+			t.Errorf("%v,%v", streamOn, state)
+			return
+		}
+
+		//restart after sync is broken
+		transport.Server.Close()
+		if err := waitSessionState(event, NetworkStateError); err != nil {
+			t.Errorf("%v,%v", streamOn, err)
+			return
+		}
+		if state := <-event.states; state != "100,1" {
+			t.Errorf("%v,%v", streamOn, state)
+			return
+		}
+		if err := waitSessionState(event, NetworkStateReady); err != nil || <-synced != "1" || clientTransport.Client.State() != NetworkStateReady {
+			t.Errorf("%v,%v", streamOn, err)
+			return
+		}
+
+		//closed after attempts is exhausted
+		client.Network.Reconnect.MaxAttempts = 2
+		client.Network.SetToken("invalid")
+		transport.Server.Close()
+		for _, expect := range []string{"500,", "100,1", "500,", "100,2", "500,", "400,"} {
+			if state := <-event.states; !strings.HasPrefix(state, expect) {
+				t.Errorf("%v,%v,%v", streamOn, expect, state)
+				return
+			}
+		}
+		if clientTransport.Client.State() != NetworkStateClosed {
+			t.Errorf("%v,%v", streamOn, clientTransport.Client.State())
+			return
+		}
+
+		//ready again after closed
+		client.Network.SetToken(token)
+		client.Network.Reconnect = nil
+		if err := client.Network.Ready(); err != nil {
+			t.Errorf("%v,%v", streamOn, err)
+			return
+		}
+		if err := waitSessionState(event, NetworkStateReady); err != nil || <-synced != "1" {
+			t.Errorf("%v,%v", streamOn, err)
+			return
+		}
+		transport.Server.Close()
+		if err := waitSessionState(event, NetworkStateClosed); err != nil {
+			t.Errorf("%v,%v", streamOn, err)
+			return
+		}
+
+		//closing by stop
+		client.Network.Stop()
+		if state := <-event.states; state != "300,<nil>" {
+			t.Errorf("%v,%v", streamOn, state)
+			return
+		}
+		if state := <-event.states; state != "400,<nil>" {
+			t.Errorf("%v,%v", streamOn, state)
+			return
+		}
+	}
+}
@@ -0,0 +1,339 @@
+package network
+
+import (
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/codingeasygo/util/xmap"
+)
+
+type reliablePending struct {
+	Sequence int64
+	Group    string
+	Time     time.Time                         // the time sync data is built
+	Sent     map[string]map[string]bool        // the prop key sent by cid, nil is removed
+	State    map[string]map[string]interface{} // the normalized prop sent by cid
+}
+
+// NetworkReliableTracker will keep the state acked by one connection and build the delta sync data against it,
+// the prop which is different from acked state is resent in every sync until it is acked, so the lost update is recovered
+// by next sync and the stale sync data is skipped by client by sequence. The sync without change should only resend the unacked state
+// when it is Resendable, so the lost update is recovered when nothing is changed after it.
+type NetworkReliableTracker struct {
+	MaxPending    int           // the max sync data waiting ack, the oldest is dropped when over
+	ResendTimeout time.Duration // the duration to wait ack before resend the unacked state on sync without change
+	sequence      int64
+	ackedAll      map[string]map[string]map[string]interface{}
+	dirtyAll      map[string]map[string]map[string]int64 // the last sequence of prop sent and not acked by group and cid
+	pendingAll    []*reliablePending
+	lock          sync.Mutex
+}
+
+func NewNetworkReliableTracker() (tracker *NetworkReliableTracker) {
+	tracker = &NetworkReliableTracker{
+		MaxPending:    256,
+		ResendTimeout: 300 * time.Millisecond,
+		ackedAll:      map[string]map[string]map[string]interface{}{},
+		dirtyAll:      map[string]map[string]map[string]int64{},
+	}
+	return
+}
+
+// Sequence return the last sequence of sync data built by tracker
+func (n *NetworkReliableTracker) Sequence() int64 {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	return n.sequence
+}
+
+// Acked return the normalized props acked by connection in group
+func (n *NetworkReliableTracker) Acked(group string) (acked map[string]xmap.M) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	acked = map[string]xmap.M{}
+	for cid, props := range n.ackedAll[group] {
+		acked[cid] = xmap.M{}
+		for k, v := range props {
+			acked[cid][k] = v
+		}
+	}
+	return
+}
+
+// Pending return the count of sync data waiting ack
+func (n *NetworkReliableTracker) Pending() int {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	return len(n.pendingAll)
+}
+
+func (n *NetworkReliableTracker) groupPending(group string) (pendingAll []*reliablePending) {
+	for _, pending := range n.pendingAll {
+		if pending.Group == group {
+			pendingAll = append(pendingAll, pending)
+		}
+	}
+	return
+}
+
+// Resendable return true if the last sync data of group is not acked in ResendTimeout, the sync without change should be filtered
+// only when it is true
+func (n *NetworkReliableTracker) Resendable(group string) bool {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	inflight := n.groupPending(group)
+	if len(inflight) < 1 {
+		return false
+	}
+	return time.Since(inflight[len(inflight)-1].Time) >= n.ResendTimeout
+}
+
+func (n *NetworkReliableTracker) removePending(group string, sequence int64) {
+	pendingAll := []*reliablePending{}
+	for _, pending := range n.pendingAll {
+		if pending.Group != group || pending.Sequence > sequence {
+			pendingAll = append(pendingAll, pending)
+		}
+	}
+	n.pendingAll = pendingAll
+}
+
+func reliableNormalize(v interface{}) interface{} {
+	value, err := NormalizeBinaryValue(v)
+	if err != nil {
+		value = JsonEncode(v)
+	}
+	return value
+}
+
+func reliableChanged(last, having interface{}, quantum float64) bool {
+	if quantum > 0 {
+		return QuantumChanged(last, having, quantum)
+	}
+	return !reflect.DeepEqual(last, having)
+}
+
+// reliableInflight return true if the prop is sent by sync data waiting ack with other value, the connection may having that value
+func reliableInflight(inflight []*reliablePending, cid, key string, having interface{}, quantum float64) bool {
+	for _, pending := range inflight {
+		if pending.Sent[cid][key] && reliableChanged(pending.State[cid][key], having, quantum) {
+			return true
+		}
+	}
+	return false
+}
+
+// networkProps will return the current value of changed and dirty props
+func (n *NetworkComponent) networkProps(changed xmap.M, dirty map[string]int64) (props xmap.M) {
+	n.RLock()
+	defer n.RUnlock()
+	props = xmap.M{}
+	for k := range changed {
+		if v, ok := n.propAll.value[k]; ok {
+			props[k] = v
+		}
+	}
+	for k := range dirty {
+		if v, ok := n.propAll.value[k]; ok {
+			props[k] = v
+		}
+	}
+	return
+}
+
+// Filter will build the sync data for connection by current component state in hub, the props in data is used as changed keys,
+// so only the changed and not acked props is compared against acked state, the component not acked is spawned by all props.
+// it return nil when nothing should be sent
+func (n *NetworkReliableTracker) Filter(ctx *NetworkContext, conn NetworkConnection, data *NetworkSyncData) *NetworkSyncData {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	visible := ctx.ComponentHub.ListGroupComponent(data.Group)
+	if policy := ctx.Network.Interest; policy != nil {
+		visible = policy.Interest(conn, visible)
+	}
+	acked := n.ackedAll[data.Group]
+	if acked == nil || data.Whole {
+		acked = map[string]map[string]interface{}{}
+		n.ackedAll[data.Group] = acked
+		n.removePending(data.Group, n.sequence)
+	}
+	triggerAll := map[string]xmap.M{}
+	for _, c := range data.Components {
+		if !c.Removed && len(c.Triggers) > 0 {
+			triggerAll[c.CID] = c.Triggers
+		}
+	}
+	inflight := n.groupPending(data.Group)
+	pending := &reliablePending{
+		Group: data.Group,
+		Time:  time.Now(),
+		Sent:  map[string]map[string]bool{},
+		State: map[string]map[string]interface{}{},
+	}
+	filtered := &NetworkSyncData{
+		UUID:  data.UUID,
+		Group: data.Group,
+		Whole: data.Whole,
+		Tick:  data.Tick,
+		Time:  data.Time,
+	}
+	changedAll := map[string]xmap.M{}
+	for _, c := range data.Components {
+		if !c.Removed && len(c.Props) > 0 {
+			changedAll[c.CID] = c.Props
+		}
+	}
+	dirtyAll := n.dirtyAll[data.Group]
+	if dirtyAll == nil || data.Whole {
+		dirtyAll = map[string]map[string]int64{}
+		n.dirtyAll[data.Group] = dirtyAll
+	}
+	for cid, c := range visible {
+		if c.Removed {
+			delete(visible, cid)
+			continue
+		}
+		base := acked[cid]
+		var values xmap.M
+		if base == nil {
+			values = c.networkSpawn().Props
+		} else {
+			values = c.networkProps(changedAll[cid], dirtyAll[cid])
+		}
+		props := xmap.M{}
+		sent := map[string]bool{}
+		state := map[string]interface{}{}
+		for k, v := range values {
+			having := reliableNormalize(v)
+			last, known := base[k]
+			quantum := c.NetworkQuantum(k)
+			if base == nil || !known || reliableChanged(last, having, quantum) || reliableInflight(inflight, cid, k, having, quantum) {
+				props[k] = v
+				sent[k] = true
+				state[k] = having
+			} else if quantum > 0 && !reflect.DeepEqual(last, having) {
+				dirty := dirtyAll[cid]
+				if dirty == nil {
+					dirty = map[string]int64{}
+					dirtyAll[cid] = dirty
+				}
+				if _, ok := dirty[k]; !ok {
+					dirty[k] = 0 //drift less than quantum, it is compared on next sync
+				}
+			} else if dirty := dirtyAll[cid]; dirty != nil {
+				delete(dirty, k) //connection having the acked value
+			}
+		}
+		pending.Sent[cid] = sent
+		pending.State[cid] = state
+		triggers := triggerAll[cid]
+		if base == nil || len(props) > 0 || len(triggers) > 0 {
+			filtered.Components = append(filtered.Components, &NetworkSyncDataComponent{
+				Factory:  c.Factory,
+				CID:      c.CID,
+				Owner:    c.Owner,
+				Props:    props,
+				Triggers: triggers,
+				Quantum:  c.networkQuantumAll(props),
+			})
+		}
+	}
+	removedAll := map[string]*NetworkSyncDataComponent{}
+	for _, c := range data.Components {
+		if c.Removed {
+			removedAll[c.CID] = c
+		}
+	}
+	knownAll := map[string]bool{}
+	for cid := range acked {
+		knownAll[cid] = true
+	}
+	for _, pending := range inflight {
+		for cid, sent := range pending.Sent {
+			if sent != nil {
+				knownAll[cid] = true
+			}
+		}
+	}
+	for cid := range knownAll {
+		if visible[cid] != nil {
+			continue
+		}
+		removed := removedAll[cid]
+		if removed == nil {
+			removed = &NetworkSyncDataComponent{CID: cid}
+			if c := ctx.ComponentHub.FindComponent(cid); c != nil {
+				removed.Factory, removed.Owner = c.Factory, c.Owner
+			}
+		}
+		filtered.Components = append(filtered.Components, &NetworkSyncDataComponent{
+			Factory: removed.Factory,
+			CID:     cid,
+			Owner:   removed.Owner,
+			Removed: true,
+		})
+		pending.Sent[cid] = nil
+	}
+	if !filtered.IsUpdated() {
+		return nil
+	}
+	n.sequence++
+	pending.Sequence = n.sequence
+	filtered.Sequence = n.sequence
+	for cid, sent := range pending.Sent {
+		if len(sent) < 1 {
+			continue
+		}
+		dirty := dirtyAll[cid]
+		if dirty == nil {
+			dirty = map[string]int64{}
+			dirtyAll[cid] = dirty
+		}
+		for k := range sent {
+			dirty[k] = n.sequence
+		}
+	}
+	n.pendingAll = append(n.pendingAll, pending)
+	if over := len(n.pendingAll) - n.MaxPending; n.MaxPending > 0 && over > 0 {
+		n.pendingAll = n.pendingAll[over:]
+	}
+	return filtered
+}
+
+// Ack will update the state acked by connection to the state after sync data of sequence received
+func (n *NetworkReliableTracker) Ack(sequence int64) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	var acked *reliablePending
+	for _, pending := range n.pendingAll {
+		if pending.Sequence == sequence {
+			acked = pending
+			break
+		}
+	}
+	if acked == nil {
+		return
+	}
+	ackedAll, dirtyAll := n.ackedAll[acked.Group], n.dirtyAll[acked.Group]
+	for cid, sent := range acked.Sent {
+		if sent == nil {
+			delete(ackedAll, cid)
+			delete(dirtyAll, cid)
+			continue
+		}
+		state := ackedAll[cid]
+		if state == nil {
+			state = map[string]interface{}{}
+			ackedAll[cid] = state
+		}
+		dirty := dirtyAll[cid]
+		for k := range sent {
+			state[k] = acked.State[cid][k]
+			if dirty[k] <= sequence {
+				delete(dirty, k)
+			}
+		}
+	}
+	n.removePending(acked.Group, sequence)
+}
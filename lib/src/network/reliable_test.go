@@ -0,0 +1,281 @@
+package network
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/codingeasygo/util/xdebug"
+	"github.com/codingeasygo/util/xmap"
+)
+
+func TestReliable(t *testing.T) {
+	tester := xdebug.CaseTester{
+		0: 1,
+	}
+	if tester.Run() { //tracker
+		ctx := NewNetworkContext()
+		ctx.Network.IsServer = true
+		conn := &TestNetworkConnection{session: NewDefaultNetworkSessionBySafeM()}
+		conn.session.SetUser("u1")
+		c1 := NewNetworkComponentByContext(ctx, "test", "", "u1", "c1")
+		c1.SetValue("x", 1)
+		c1.SetValue("y", "a")
+		c1.RegisterNetworkProp()
+		c2 := NewNetworkComponentByContext(ctx, "test", "", "u2", "c2")
+		c2.SetValue("x", 1)
+		c2.RegisterNetworkProp()
+		tracker := NewNetworkReliableTracker()
+		filter := func(whole bool) *NetworkSyncData {
+			return tracker.Filter(ctx, conn, NewNetworkSyncDataByHub(ctx.ComponentHub, "", whole))
+		}
+		findComponent := func(data *NetworkSyncData, cid string) *NetworkSyncDataComponent {
+			for _, c := range data.Components {
+				if c.CID == cid {
+					return c
+				}
+			}
+			return nil
+		}
+		//whole
+		data := filter(true)
+		if data.Sequence != 1 || !data.Whole || len(data.Components) != 2 || len(findComponent(data, "c1").Props) != 2 {
+			t.Errorf("%v", data)
+			return
+		}
+		//not acked is resent
+		c1.SetValue("x", 2)
+		data = filter(false)
+		if data.Sequence != 2 || len(data.Components) != 2 || len(findComponent(data, "c2").Props) != 1 {
+			t.Errorf("%v", data)
+			return
+		}
+		tracker.Ack(1)
+		if acked := tracker.Acked(""); len(acked) != 2 || acked["c1"]["x"] != int64(1) {
+			t.Errorf("%v", acked)
+			return
+		}
+		//delta against acked
+		data = filter(false)
+		if data.Sequence != 3 || len(data.Components) != 1 || findComponent(data, "c1").Props["x"] != 2 || len(findComponent(data, "c1").Props) != 1 {
+			t.Errorf("%v", data)
+			return
+		}
+		//2 is lost and 3 is acked
+		tracker.Ack(3)
+		tracker.Ack(2)
+		tracker.Ack(100)
+		if data = filter(false); data != nil || tracker.Pending() != 0 || tracker.Sequence() != 3 {
+			t.Errorf("%v", data)
+			return
+		}
+		//changed and changed back before acked
+		c1.SetValue("x", 3)
+		data = filter(false)
+		if data.Sequence != 4 || findComponent(data, "c1").Props["x"] != 3 {
+			t.Errorf("%v", data)
+			return
+		}
+		c1.SetValue("x", 2)
+		data = filter(false)
+		if data.Sequence != 5 || findComponent(data, "c1").Props["x"] != 2 {
+			t.Errorf("%v", data)
+			return
+		}
+		tracker.Ack(5)
+		if data = filter(false); data != nil {
+			t.Errorf("%v", data)
+			return
+		}
+		//quantum
+		c1.RegisterNetworkQuantum("x", 0.5)
+		c1.SetValue("x", 2.2)
+		if data = filter(false); data != nil {
+			t.Errorf("%v", data)
+			return
+		}
+		c1.SetValue("x", 2.6)
+		if data = filter(false); data == nil || findComponent(data, "c1").Props["x"] != 2.6 {
+			t.Errorf("%v", data)
+			return
+		}
+		tracker.Ack(data.Sequence)
+		//trigger
+		c1.NetworkTrigger("t0", 1)
+		data = tracker.Filter(ctx, conn, &NetworkSyncData{Components: []*NetworkSyncDataComponent{{CID: "c1", Triggers: xmap.M{"t0": []interface{}{1}}}}})
+		if data == nil || len(findComponent(data, "c1").Triggers) != 1 || len(findComponent(data, "c1").Props) != 0 {
+			t.Errorf("%v", data)
+			return
+		}
+		tracker.Ack(data.Sequence)
+		//removed is resent until acked
+		c2.Removed = true
+		data = filter(false)
+		if data == nil || !findComponent(data, "c2").Removed || findComponent(data, "c2").Factory != "test" {
+			t.Errorf("%v", data)
+			return
+		}
+		data = filter(false)
+		if data == nil || !findComponent(data, "c2").Removed || findComponent(data, "c2").Factory != "" {
+			t.Errorf("%v", data)
+			return
+		}
+		tracker.Ack(data.Sequence)
+		if data = filter(false); data != nil {
+			t.Errorf("%v", data)
+			return
+		}
+		//lost update is resent on sync without change after ack timeout
+		c1.SetValue("x", 5)
+		if data = filter(false); data == nil || findComponent(data, "c1").Props["x"] != 5 {
+			t.Errorf("%v", data)
+			return
+		}
+		if tracker.Resendable("") {
+			t.Error("error")
+			return
+		}
+		tracker.ResendTimeout = 0
+		if !tracker.Resendable("") || tracker.Resendable("other") {
+			t.Error("error")
+			return
+		}
+		if data = tracker.Filter(ctx, conn, &NetworkSyncData{}); data == nil || len(data.Components) != 1 || findComponent(data, "c1").Props["x"] != 5 {
+			t.Errorf("%v", data)
+			return
+		}
+		tracker.Ack(data.Sequence)
+		if tracker.Resendable("") {
+			t.Error("error")
+			return
+		}
+		tracker.ResendTimeout = time.Second
+		//only changed and not acked props is compared
+		if dirty := tracker.dirtyAll[""]["c1"]; len(dirty) != 0 {
+			t.Errorf("%v", dirty)
+			return
+		}
+		c1.SetValue("y", "b")
+		if data = tracker.Filter(ctx, conn, &NetworkSyncData{}); data != nil {
+			t.Errorf("%v", data)
+			return
+		}
+		if data = filter(false); data == nil || len(data.Components) != 1 || findComponent(data, "c1").Props["y"] != "b" || tracker.dirtyAll[""]["c1"]["y"] != data.Sequence {
+			t.Errorf("%v", data)
+			return
+		}
+		tracker.Ack(data.Sequence)
+		if dirty := tracker.dirtyAll[""]["c1"]; len(dirty) != 0 {
+			t.Errorf("%v", dirty)
+			return
+		}
+		//interest
+		c3 := NewNetworkComponentByContext(ctx, "test", "", "u2", "c3")
+		c3.SetValue("pos", TestBinaryVec{100, 0})
+		c3.RegisterNetworkProp()
+		c1.SetValue("pos", TestBinaryVec{0, 0})
+		ctx.Network.Interest = NewNetworkRadiusInterest("pos", 10)
+		data = filter(false)
+		if data == nil || len(data.Components) != 1 || findComponent(data, "c1") == nil {
+			t.Errorf("%v", data)
+			return
+		}
+		tracker.Ack(data.Sequence)
+		c3.SetValue("pos", TestBinaryVec{1, 0})
+		data = filter(false)
+		if data == nil || len(data.Components) != 1 || len(findComponent(data, "c3").Props) != 1 {
+			t.Errorf("%v", data)
+			return
+		}
+		tracker.Ack(data.Sequence)
+		c3.SetValue("pos", TestBinaryVec{100, 0})
+		data = filter(false)
+		if data == nil || len(data.Components) != 1 || !findComponent(data, "c3").Removed {
+			t.Errorf("%v", data)
+			return
+		}
+		//max pending
+		tracker.MaxPending = 2
+		for i := 0; i < 3; i++ {
+			filter(false)
+		}
+		if tracker.Pending() != 2 {
+			t.Errorf("%v", tracker.Pending())
+			return
+		}
+	}
+	if tester.Run() { //grpc
+		server := NewNetworkContext()
+		server.Network.IsServer = true
+		transport := NewNetworkTransportGRPCByContext(server)
+		transport.GrpcAddress, _ = url.Parse("grpc://127.0.0.1:50072")
+		transport.WebAddress, _ = url.Parse("ws://127.0.0.1:50073")
+		server.SetTransport(transport)
+		sc := NewNetworkComponentByContext(server, "test", "", "", "c1")
+		sc.SetValue("x", 1)
+		sc.RegisterNetworkProp()
+		if err := server.Network.Start(); err != nil {
+			t.Error(err)
+			return
+		}
+		defer server.Network.Stop()
+
+		client := NewNetworkContext()
+		client.Network.IsClient = true
+		client.Network.SetKey("reliable")
+		client.Network.SyncReliable = true
+		clientTransport := NewNetworkTransportGRPCByContext(client)
+		clientTransport.GrpcAddress, _ = url.Parse("grpc://127.0.0.1:50072")
+		client.SetTransport(clientTransport)
+		synced := make(chan string, 8)
+		client.ComponentHub.RegisterFactory("test", "", func(key, group, owner, cid string) (c *NetworkComponent, err error) {
+			c = NewNetworkComponentByContext(client, key, group, owner, cid)
+			c.RegisterNetworkProp()
+			c.OnNetworkSynced = func() { synced <- c.Str("x") }
+			return
+		})
+		if err := client.Network.Start(); err != nil {
+			t.Error(err)
+			return
+		}
+		defer client.Network.Stop()
+		if err := client.Network.Ready(); err != nil {
+			t.Error(err)
+			return
+		}
+		if x := <-synced; x != "1" {
+			t.Error(x)
+			return
+		}
+		var stream *NetworkSyncStreamGRPC
+		for _, c := range transport.Server.groupConnCopy("*") {
+			stream = c
+		}
+		if stream == nil || stream.Reliable() == nil {
+			t.Error("error")
+			return
+		}
+		for i := 0; i < 100 && stream.Reliable().Pending() > 0; i++ {
+			time.Sleep(10 * time.Millisecond)
+		}
+		if stream.Reliable().Pending() != 0 || stream.Reliable().Sequence() != 1 {
+			t.Error("not acked")
+			return
+		}
+		time.Sleep(server.Network.MinSync)
+		sc.SetValue("x", 2)
+		server.Network.Sync("", nil)
+		if x := <-synced; x != "2" {
+			t.Error(x)
+			return
+		}
+		for i := 0; i < 100 && stream.Reliable().Pending() > 0; i++ {
+			time.Sleep(10 * time.Millisecond)
+		}
+		if acked := stream.Reliable().Acked(""); acked["c1"]["x"] != int64(2) {
+			t.Errorf("%v", acked)
+			return
+		}
+		stream.SetReliable(false)
+	}
+}
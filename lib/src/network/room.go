@@ -0,0 +1,412 @@
+package network
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/codingeasygo/util/uuid"
+	"github.com/codingeasygo/util/xmap"
+)
+
+// NetworkRoomCID is the cid of built-in component which provides the room calls, it is in own group so it is not synced to player
+const NetworkRoomCID = "room"
+
+// NetworkRoomOption is the option to create room
+type NetworkRoomOption struct {
+	Name       string `json:"name"`
+	MaxPlayers int    `json:"max_players"` // zero is NetworkRoomManager.MaxPlayers
+	Meta       xmap.M `json:"meta"`
+}
+
+// NetworkRoom is the room which players are synced in same group
+type NetworkRoom struct {
+	ID         string      `json:"id"`
+	Name       string      `json:"name"`
+	Group      string      `json:"group"` // the session group of player in room
+	Owner      string      `json:"owner"`
+	MaxPlayers int         `json:"max_players"` // zero is unlimited
+	Meta       xmap.M      `json:"meta"`
+	Players    []string    `json:"players"`
+	Created    int64       `json:"created"`
+	Game       interface{} `json:"-"` // the game instance of room, it is created by OnRoomCreate
+	sessionAll map[string]NetworkSession
+}
+
+func (n *NetworkRoom) copy() (room *NetworkRoom) {
+	room = &NetworkRoom{
+		ID:         n.ID,
+		Name:       n.Name,
+		Group:      n.Group,
+		Owner:      n.Owner,
+		MaxPlayers: n.MaxPlayers,
+		Meta:       n.Meta,
+		Players:    append([]string{}, n.Players...),
+		Created:    n.Created,
+		Game:       n.Game,
+	}
+	return
+}
+
+// updatePlayers will update the sorted players by joined session
+func (n *NetworkRoom) updatePlayers() {
+	n.Players = []string{}
+	for player := range n.sessionAll {
+		n.Players = append(n.Players, player)
+	}
+	sort.Strings(n.Players)
+}
+
+// IsFull will return if players is reached max players
+func (n *NetworkRoom) IsFull() bool {
+	return n.MaxPlayers > 0 && len(n.Players) >= n.MaxPlayers
+}
+
+// roomPlayer will return the player of session in room, it is session user or session key when user is empty
+func roomPlayer(session NetworkSession) string {
+	if user := session.User(); len(user) > 0 {
+		return user
+	}
+	return session.Key()
+}
+
+// NetworkRoomManager will manage the rooms on server, each room is one group, the player join room by session group is changed to room group.
+// The game instance of room should be created on OnRoomCreate, and the factory of room should be registered by ComponentHub.RegisterFactory(..., room.Group, ...).
+// The client reach it by calls "create", "list", "join", "leave" and "close" on component NetworkRoomCID, see NetworkRoomClient.
+// The player is leaved from room when the session is closed or expired
+type NetworkRoomManager struct {
+	*NetworkComponent
+	MaxPlayers   int                                                         // the default max players of room, zero is unlimited
+	CloseEmpty   bool                                                        // if close room when last player is leaved
+	OnRoomCreate func(room *NetworkRoom) (err error)                         // create the game instance of room, the room is not created when error
+	OnRoomClose  func(room *NetworkRoom)                                     // release the game instance of room
+	OnRoomJoin   func(room *NetworkRoom, session NetworkSession) (err error) // the player is not joined when error
+	OnRoomLeave  func(room *NetworkRoom, session NetworkSession)
+	roomAll      map[string]*NetworkRoom
+	roomLck      sync.RWMutex
+}
+
+func NewNetworkRoomManager() (manager *NetworkRoomManager) {
+	manager = NewNetworkRoomManagerByContext(DefaultContext)
+	return
+}
+
+// NewNetworkRoomManagerByContext will create room manager and register the room calls, it panics when call is not valid like ComponentHub.RegisterFactory
+func NewNetworkRoomManagerByContext(ctx *NetworkContext) (manager *NetworkRoomManager) {
+	manager = &NetworkRoomManager{
+		NetworkComponent: NewNetworkComponentByContext(ctx, "", NetworkRoomCID, "", NetworkRoomCID),
+		CloseEmpty:       true,
+		roomAll:          map[string]*NetworkRoom{},
+		roomLck:          sync.RWMutex{},
+	}
+	callAll := map[string]NetworkCall{
+		"create": manager.onCreate,
+		"list":   manager.onList,
+		"join":   manager.onJoin,
+		"leave":  manager.onLeave,
+		"close":  manager.onClose,
+	}
+	for name, call := range callAll {
+		if err := manager.RegisterNetworkCall(name, call); err != nil {
+			panic(fmt.Sprintf("NetworkRoomManager register call %v error %v", name, err))
+		}
+	}
+	manager.RegisterNetworkEvent("*", manager)
+	return
+}
+
+// CreateRoom will create room by option and call OnRoomCreate to create game instance
+func (n *NetworkRoomManager) CreateRoom(owner string, option *NetworkRoomOption) (room *NetworkRoom, err error) {
+	id := uuid.New()
+	room = &NetworkRoom{
+		ID:         id,
+		Name:       option.Name,
+		Group:      "room-" + id,
+		Owner:      owner,
+		MaxPlayers: option.MaxPlayers,
+		Meta:       option.Meta,
+		Players:    []string{},
+		Created:    time.Now().UnixMilli(),
+		sessionAll: map[string]NetworkSession{},
+	}
+	if room.MaxPlayers < 1 {
+		room.MaxPlayers = n.MaxPlayers
+	}
+	if n.OnRoomCreate != nil {
+		err = n.OnRoomCreate(room)
+		if err != nil {
+			return
+		}
+	}
+	n.roomLck.Lock()
+	n.roomAll[room.ID] = room
+	n.roomLck.Unlock()
+	Infof("NetworkRoomManager create room %v/%v by %v", room.ID, room.Name, owner)
+	room = room.copy()
+	return
+}
+
+// FindRoom will return the copy of room by id, nil is not exists
+func (n *NetworkRoomManager) FindRoom(id string) (room *NetworkRoom) {
+	n.roomLck.RLock()
+	defer n.roomLck.RUnlock()
+	if having := n.roomAll[id]; having != nil {
+		room = having.copy()
+	}
+	return
+}
+
+// ListRoom will return the copy of all rooms sorted by created time
+func (n *NetworkRoomManager) ListRoom() (rooms []*NetworkRoom) {
+	n.roomLck.RLock()
+	defer n.roomLck.RUnlock()
+	rooms = []*NetworkRoom{}
+	for _, room := range n.roomAll {
+		rooms = append(rooms, room.copy())
+	}
+	sort.Slice(rooms, func(i, j int) bool {
+		return rooms[i].Created < rooms[j].Created || (rooms[i].Created == rooms[j].Created && rooms[i].ID < rooms[j].ID)
+	})
+	return
+}
+
+func (n *NetworkRoomManager) findPlayerRoom(player string) *NetworkRoom {
+	for _, room := range n.roomAll {
+		if room.sessionAll[player] != nil {
+			return room
+		}
+	}
+	return nil
+}
+
+// JoinRoom will join session to room by changing session group to room group, the session is leaved from other room first,
+// and the session of player is replaced when player is rejoined by other session
+func (n *NetworkRoomManager) JoinRoom(session NetworkSession, id string) (room *NetworkRoom, err error) {
+	player := roomPlayer(session)
+	n.roomLck.RLock()
+	joined := n.findPlayerRoom(player)
+	n.roomLck.RUnlock()
+	if joined != nil && joined.ID != id {
+		n.LeaveRoom(session)
+	}
+	n.roomLck.Lock()
+	having := n.roomAll[id]
+	if having == nil {
+		n.roomLck.Unlock()
+		err = fmt.Errorf("room %v is not exists", id)
+		return
+	}
+	if having.sessionAll[player] != nil { //rejoin
+		having.sessionAll[player] = session
+		n.roomLck.Unlock()
+		session.SetGroup(having.Group)
+		room = n.FindRoom(id)
+		return
+	}
+	if having.MaxPlayers > 0 && len(having.sessionAll) >= having.MaxPlayers {
+		n.roomLck.Unlock()
+		err = fmt.Errorf("room %v is full", id)
+		return
+	}
+	having.sessionAll[player] = session
+	having.updatePlayers()
+	n.roomLck.Unlock()
+	if n.OnRoomJoin != nil {
+		if err = n.OnRoomJoin(having, session); err != nil {
+			n.roomLck.Lock()
+			delete(having.sessionAll, player)
+			having.updatePlayers()
+			n.roomLck.Unlock()
+			return
+		}
+	}
+	session.SetGroup(having.Group)
+	Infof("NetworkRoomManager player %v join room %v/%v", player, having.ID, having.Name)
+	room = n.FindRoom(id)
+	return
+}
+
+// LeaveRoom will leave the player of session from the room which is joined, the session group is reset to empty, the room is closed when it is empty and CloseEmpty is true
+func (n *NetworkRoomManager) LeaveRoom(session NetworkSession) (room *NetworkRoom) {
+	player := roomPlayer(session)
+	n.roomLck.Lock()
+	having := n.findPlayerRoom(player)
+	if having == nil {
+		n.roomLck.Unlock()
+		return
+	}
+	delete(having.sessionAll, player)
+	having.updatePlayers()
+	empty := len(having.sessionAll) < 1
+	room = having.copy()
+	n.roomLck.Unlock()
+	session.SetGroup("")
+	if n.OnRoomLeave != nil {
+		n.OnRoomLeave(having, session)
+	}
+	Infof("NetworkRoomManager player %v leave room %v/%v", player, having.ID, having.Name)
+	if empty && n.CloseEmpty {
+		n.CloseRoom(having.ID)
+	}
+	return
+}
+
+// CloseRoom will remove room and call OnRoomClose to release game instance, the players in room is reset to empty group and
+// regrouped by NetworkManager.Regroup, and the components and factory of room group is removed
+func (n *NetworkRoomManager) CloseRoom(id string) (err error) {
+	n.roomLck.Lock()
+	room := n.roomAll[id]
+	if room == nil {
+		n.roomLck.Unlock()
+		err = fmt.Errorf("room %v is not exists", id)
+		return
+	}
+	delete(n.roomAll, id)
+	sessionAll := room.sessionAll
+	room.sessionAll = map[string]NetworkSession{}
+	room.updatePlayers()
+	n.roomLck.Unlock()
+	for _, session := range sessionAll {
+		if session.Group() == room.Group {
+			session.SetGroup("")
+			n.Context.Network.Regroup(session)
+		}
+	}
+	if n.OnRoomClose != nil {
+		n.OnRoomClose(room)
+	}
+	hub := n.Context.ComponentHub
+	hub.UnregisterFactory("", room.Group)
+	for _, c := range hub.ListGroupComponent(room.Group) {
+		hub.removeComponent(c)
+	}
+	Infof("NetworkRoomManager close room %v/%v", room.ID, room.Name)
+	return
+}
+
+// OnNetworkState will leave the player from room when the last connection of session is closed, the player rejoined by other session is kept
+func (n *NetworkRoomManager) OnNetworkState(all NetworkConnectionSet, conn NetworkConnection, state NetworkState, info interface{}) {
+	if state != NetworkStateClosed || !conn.IsServer() || len(all) > 0 {
+		return
+	}
+	session := conn.Session()
+	player := roomPlayer(session)
+	n.roomLck.RLock()
+	having := n.findPlayerRoom(player)
+	joined := having != nil && having.sessionAll[player].Key() == session.Key()
+	n.roomLck.RUnlock()
+	if joined {
+		n.LeaveRoom(session)
+	}
+}
+
+func (n *NetworkRoomManager) OnNetworkPing(conn NetworkConnection, ping time.Duration) {
+}
+
+func (n *NetworkRoomManager) OnNetworkDataSynced(conn NetworkConnection, data *NetworkSyncData) {
+}
+
+func (n *NetworkRoomManager) onCreate(ctx NetworkSession, uuid string, option *NetworkRoomOption) (room *NetworkRoom, err error) {
+	created, err := n.CreateRoom(roomPlayer(ctx), option)
+	if err == nil {
+		room, err = n.JoinRoom(ctx, created.ID)
+	}
+	return
+}
+
+func (n *NetworkRoomManager) onList(ctx NetworkSession, uuid string) (rooms []*NetworkRoom, err error) {
+	rooms = n.ListRoom()
+	return
+}
+
+func (n *NetworkRoomManager) onJoin(ctx NetworkSession, uuid string, id string) (room *NetworkRoom, err error) {
+	room, err = n.JoinRoom(ctx, id)
+	return
+}
+
+func (n *NetworkRoomManager) onLeave(ctx NetworkSession, uuid string) (room *NetworkRoom, err error) {
+	room = n.LeaveRoom(ctx)
+	return
+}
+
+func (n *NetworkRoomManager) onClose(ctx NetworkSession, uuid string, id string) (result string, err error) {
+	room := n.FindRoom(id)
+	if room == nil {
+		err = fmt.Errorf("room %v is not exists", id)
+		return
+	}
+	if room.Owner != roomPlayer(ctx) {
+		err = fmt.Errorf("only owner can close room")
+		return
+	}
+	err = n.CloseRoom(id)
+	result = "OK"
+	return
+}
+
+// NetworkRoomClient is the client to call NetworkRoomManager on server, the client group is changed to room group after joined
+type NetworkRoomClient struct {
+	*NetworkComponent
+	Room *NetworkRoom // the room which is joined
+}
+
+func NewNetworkRoomClient() (client *NetworkRoomClient) {
+	client = NewNetworkRoomClientByContext(DefaultContext)
+	return
+}
+
+func NewNetworkRoomClientByContext(ctx *NetworkContext) (client *NetworkRoomClient) {
+	client = &NetworkRoomClient{
+		NetworkComponent: NewNetworkComponentByContext(ctx, "", NetworkRoomCID, "", NetworkRoomCID),
+	}
+	return
+}
+
+func (n *NetworkRoomClient) enter(room *NetworkRoom) {
+	n.Room = room
+	if room == nil {
+		n.Context.Network.SetGroup("")
+	} else {
+		n.Context.Network.SetGroup(room.Group)
+	}
+}
+
+// Create will create room and join it
+func (n *NetworkRoomClient) Create(option *NetworkRoomOption) (room *NetworkRoom, err error) {
+	err = n.NetworkCall("create", option, &room)
+	if err == nil {
+		n.enter(room)
+	}
+	return
+}
+
+func (n *NetworkRoomClient) List() (rooms []*NetworkRoom, err error) {
+	err = n.NetworkCall("list", nil, &rooms)
+	return
+}
+
+func (n *NetworkRoomClient) Join(id string) (room *NetworkRoom, err error) {
+	err = n.NetworkCall("join", id, &room)
+	if err == nil {
+		n.enter(room)
+	}
+	return
+}
+
+func (n *NetworkRoomClient) Leave() (err error) {
+	err = n.NetworkCall("leave", nil, nil)
+	if err == nil {
+		n.enter(nil)
+	}
+	return
+}
+
+// Close will close the room which is created by client
+func (n *NetworkRoomClient) Close(id string) (err error) {
+	err = n.NetworkCall("close", id, nil)
+	if err == nil && n.Room != nil && n.Room.ID == id {
+		n.enter(nil)
+	}
+	return
+}
@@ -0,0 +1,209 @@
+package network
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestRoom(t *testing.T) {
+	loopback := NewNetworkLoopback()
+	newTransport := func(ctx *NetworkContext, grpcOn bool) NetworkTransport {
+		if !grpcOn {
+			return NewNetworkTransportLoopbackByContext(ctx, loopback)
+		}
+		transport := NewNetworkTransportGRPCByContext(ctx)
+		transport.GrpcAddress, _ = url.Parse("grpc://127.0.0.1:50087")
+		transport.WebAddress, _ = url.Parse("ws://127.0.0.1:50088")
+		return transport
+	}
+	for _, grpcOn := range []bool{false, true} {
+		server := NewNetworkContext()
+		server.Network.IsServer = true
+		server.SetTransport(newTransport(server, grpcOn))
+		closed := make(chan string, 8)
+		manager := NewNetworkRoomManagerByContext(server)
+		manager.MaxPlayers = 2
+		manager.OnRoomCreate = func(room *NetworkRoom) (err error) {
+			game := NewNetworkComponentByContext(server, "test", room.Group, "", "game-"+room.ID)
+			game.SetValue("x", room.MaxPlayers)
+			game.RegisterNetworkProp()
+			room.Game = game
+			return
+		}
+		manager.OnRoomClose = func(room *NetworkRoom) { closed <- room.Name }
+		event := &TestSessionEvent{states: make(chan string, 256)}
+		server.EventHub.RegisterNetworkEvent("*", event)
+		if err := server.Network.Start(); err != nil {
+			t.Error(err)
+			return
+		}
+		newClient := func(user string) (client *NetworkContext, rooms *NetworkRoomClient, synced chan string, err error) {
+			client = NewNetworkContext()
+			client.Network.IsClient = true
+			client.Network.SetKey(user)
+			client.Network.SetUser(user)
+			client.SetTransport(newTransport(client, grpcOn))
+			synced = make(chan string, 64)
+			client.ComponentHub.RegisterFactory("test", "", func(key, group, owner, cid string) (c *NetworkComponent, err error) {
+				c = NewNetworkComponentByContext(client, key, group, owner, cid)
+				c.RegisterNetworkProp()
+				c.OnNetworkSynced = func() { synced <- c.Str("x") }
+				return
+			})
+			if err = client.Network.Start(); err == nil {
+				err = client.Network.Ready()
+			}
+			rooms = NewNetworkRoomClientByContext(client)
+			return
+		}
+		clientA, roomsA, syncedA, err := newClient("a")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		clientB, roomsB, syncedB, err := newClient("b")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		//create and synced by room group
+		room, err := roomsA.Create(&NetworkRoomOption{Name: "r1", MaxPlayers: 1})
+		if err != nil || room.Group != clientA.Network.Group() || len(room.Players) != 1 || room.Owner != clientA.Network.Key() {
+			t.Errorf("%v,%v,%v", grpcOn, err, room)
+			return
+		}
+		if x := <-syncedA; x != "1" {
+			t.Errorf("%v,%v", grpcOn, x)
+			return
+		}
+		for state := <-event.states; state != "group,,"+room.Group; state = <-event.states {
+			if state == "200,regroup" {
+				t.Errorf("%v,%v", grpcOn, state)
+				return
+			}
+		}
+		if len(syncedB) != 0 {
+			t.Errorf("%v,%v", grpcOn, <-syncedB)
+			return
+		}
+		rooms, err := roomsB.List()
+		if err != nil || len(rooms) != 1 || rooms[0].ID != room.ID || rooms[0].Players[0] != clientA.Network.Key() || !rooms[0].IsFull() {
+			t.Errorf("%v,%v,%v", grpcOn, err, rooms)
+			return
+		}
+		if _, err := roomsB.Join(room.ID); err == nil {
+			t.Errorf("%v,full", grpcOn)
+			return
+		}
+		if _, err := roomsB.Join("none"); err == nil {
+			t.Errorf("%v,none", grpcOn)
+			return
+		}
+		if err := roomsB.Close(room.ID); err == nil {
+			t.Errorf("%v,owner", grpcOn)
+			return
+		}
+		if err := roomsB.Close("none"); err == nil {
+			t.Errorf("%v,none", grpcOn)
+			return
+		}
+		if having, err := roomsA.Join(room.ID); err != nil || having.ID != room.ID { //rejoin
+			t.Errorf("%v,%v", grpcOn, err)
+			return
+		}
+
+		//join other room will leave the joined and closed by empty
+		other, err := roomsB.Create(&NetworkRoomOption{Name: "r2"})
+		if err != nil || other.MaxPlayers != 2 {
+			t.Errorf("%v,%v,%v", grpcOn, err, other)
+			return
+		}
+		if x := <-syncedB; x != "2" {
+			t.Errorf("%v,%v", grpcOn, x)
+			return
+		}
+		if _, err := roomsA.Join(other.ID); err != nil || clientA.Network.Group() != other.Group {
+			t.Errorf("%v,%v", grpcOn, err)
+			return
+		}
+		if x := <-syncedA; x != "2" {
+			t.Errorf("%v,%v", grpcOn, x)
+			return
+		}
+		if name := <-closed; name != "r1" || manager.FindRoom(room.ID) != nil || server.ComponentHub.FindComponent("game-"+room.ID) != nil {
+			t.Errorf("%v,%v", grpcOn, name)
+			return
+		}
+		if rooms := manager.ListRoom(); len(rooms) != 1 || len(rooms[0].Players) != 2 {
+			t.Errorf("%v,%v", grpcOn, rooms)
+			return
+		}
+
+		//close by owner, the other player is moved to empty group
+		lobby := NewNetworkComponentByContext(server, "test", "", "", "lobby")
+		lobby.SetValue("x", 0)
+		lobby.RegisterNetworkProp()
+		if err := roomsB.Close(other.ID); err != nil || clientB.Network.Group() != "" {
+			t.Errorf("%v,%v", grpcOn, err)
+			return
+		}
+		if name := <-closed; name != "r2" || len(manager.ListRoom()) != 0 {
+			t.Errorf("%v,%v", grpcOn, name)
+			return
+		}
+		for _, synced := range []chan string{syncedA, syncedB} {
+			if x := <-synced; x != "0" {
+				t.Errorf("%v,%v", grpcOn, x)
+				return
+			}
+		}
+		for regrouped := 0; regrouped < 2; {
+			if state := <-event.states; state == "group,"+other.Group+"," {
+				regrouped++
+			}
+		}
+		lobby.UnregisterNetworkProp()
+		if err := roomsA.Leave(); err != nil || clientA.Network.Group() != "" || roomsA.Room != nil {
+			t.Errorf("%v,%v", grpcOn, err)
+			return
+		}
+		if err := manager.CloseRoom(other.ID); err == nil {
+			t.Errorf("%v,closed", grpcOn)
+			return
+		}
+
+		//leaved and closed by empty when session is closed
+		if _, err := roomsA.Create(&NetworkRoomOption{Name: "r3"}); err != nil {
+			t.Errorf("%v,%v", grpcOn, err)
+			return
+		}
+		clientA.Network.Stop()
+		if name := <-closed; name != "r3" || len(manager.ListRoom()) != 0 {
+			t.Errorf("%v,%v", grpcOn, name)
+			return
+		}
+		clientB.Network.Stop()
+		if transport, ok := server.Transport().(*NetworkTransportGRPC); ok { //leaved when session without stream is expired
+			clientC := NewNetworkContext()
+			clientC.Network.IsClient = true
+			clientC.SetTransport(newTransport(clientC, grpcOn))
+			if err := clientC.Network.Start(); err != nil {
+				t.Error(err)
+				return
+			}
+			if _, err := NewNetworkRoomClientByContext(clientC).Create(&NetworkRoomOption{Name: "r4"}); err != nil {
+				t.Error(err)
+				return
+			}
+			transport.Server.timeout(0)
+			if name := <-closed; name != "r4" || len(manager.ListRoom()) != 0 {
+				t.Errorf("%v,%v", grpcOn, name)
+				return
+			}
+			clientC.Network.Stop()
+		}
+		server.Network.Stop()
+	}
+	NewNetworkRoomClient()
+}
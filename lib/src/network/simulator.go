@@ -0,0 +1,278 @@
+package network
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/codingeasygo/util/converter"
+)
+
+// NetworkCondition is the simulated network condition, the zero value is the perfect network
+type NetworkCondition struct {
+	Latency   time.Duration // the one way delay
+	Jitter    time.Duration // the max random delay added to Latency
+	Bandwidth int64         // the max bytes per second, zero is unlimited
+	Reorder   float64       // the probability of sync data is delivered after the later sync data
+	Loss      float64       // the probability of sync data or call is dropped
+}
+
+// NetworkSimulator will simulate the network condition by delay and drop, the condition can be changed at runtime
+// and the random is same on same seed.
+type NetworkSimulator struct {
+	condition NetworkCondition
+	random    *rand.Rand
+	busy      time.Time // the time of bandwidth is free
+	lock      sync.Mutex
+}
+
+func NewNetworkSimulator() (simulator *NetworkSimulator) {
+	simulator = NewNetworkSimulatorBySeed(time.Now().UnixNano())
+	return
+}
+
+func NewNetworkSimulatorBySeed(seed int64) (simulator *NetworkSimulator) {
+	simulator = &NetworkSimulator{
+		random: rand.New(rand.NewSource(seed)),
+	}
+	return
+}
+
+func (n *NetworkSimulator) Condition() NetworkCondition {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	return n.condition
+}
+
+func (n *NetworkSimulator) SetCondition(condition NetworkCondition) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.condition = condition
+}
+
+// delay will return the delay of sending size bytes, the bandwidth is shared by all data through simulator
+func (n *NetworkSimulator) delay(size int) (delay time.Duration) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	delay = n.condition.Latency
+	if n.condition.Jitter > 0 {
+		delay += time.Duration(n.random.Int63n(int64(n.condition.Jitter)))
+	}
+	if n.condition.Bandwidth > 0 {
+		now := time.Now()
+		if n.busy.Before(now) {
+			n.busy = now
+		}
+		n.busy = n.busy.Add(time.Duration(int64(size) * int64(time.Second) / n.condition.Bandwidth))
+		delay += n.busy.Sub(now)
+	}
+	return
+}
+
+func (n *NetworkSimulator) lost() bool {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	return n.condition.Loss > 0 && n.random.Float64() < n.condition.Loss
+}
+
+func (n *NetworkSimulator) reorder() bool {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	return n.condition.Reorder > 0 && n.random.Float64() < n.condition.Reorder
+}
+
+// NetworkTransportSimulator will wrap transport to delay, reorder and drop the sync data and call sent by transport
+type NetworkTransportSimulator struct {
+	NetworkTransport
+	Simulator *NetworkSimulator
+	pipe      *networkPipeLoopback
+	lock      sync.RWMutex
+}
+
+func NewNetworkTransportSimulator(transport NetworkTransport, simulator *NetworkSimulator) (wrapper *NetworkTransportSimulator) {
+	wrapper = &NetworkTransportSimulator{
+		NetworkTransport: transport,
+		Simulator:        simulator,
+	}
+	return
+}
+
+func (n *NetworkTransportSimulator) Start() (err error) {
+	n.lock.Lock()
+	if n.pipe == nil {
+		n.pipe = newNetworkPipeLoopback()
+	}
+	n.lock.Unlock()
+	err = n.NetworkTransport.Start()
+	return
+}
+
+func (n *NetworkTransportSimulator) Stop() (err error) {
+	n.lock.Lock()
+	if n.pipe != nil {
+		n.pipe.Close()
+		n.pipe = nil
+	}
+	n.lock.Unlock()
+	err = n.NetworkTransport.Stop()
+	return
+}
+
+// NetworkSync will drop data by loss, the reordered data is delivered after the later data, others are delivered by order
+func (n *NetworkTransportSimulator) NetworkSync(data *NetworkSyncData, excluded []NetworkConnection) {
+	n.lock.RLock()
+	pipe := n.pipe
+	n.lock.RUnlock()
+	if pipe == nil || n.Simulator.lost() {
+		return
+	}
+	delay := n.Simulator.delay(len(converter.JSON(data)))
+	deliver := func() { n.NetworkTransport.NetworkSync(data, excluded) }
+	if n.Simulator.reorder() {
+		condition := n.Simulator.Condition()
+		time.AfterFunc(delay+condition.Latency+condition.Jitter, deliver)
+		return
+	}
+	pipe.Deliver(delay, deliver)
+}
+
+// NetworkCall will delay the call and result, the dropped call is returned error
+func (n *NetworkTransportSimulator) NetworkCall(arg *NetworkCallArg) (ret *NetworkCallResult, err error) {
+	if n.Simulator.lost() {
+		err = fmt.Errorf("network call %v is dropped", arg.Name)
+		return
+	}
+	time.Sleep(n.Simulator.delay(len(arg.Arg)))
+	ret, err = n.NetworkTransport.NetworkCall(arg)
+	if ret != nil {
+		time.Sleep(n.Simulator.delay(len(ret.Result)))
+	} else {
+		time.Sleep(n.Simulator.delay(0))
+	}
+	return
+}
+
+// NetworkConnSimulator will wrap net.Conn to delay the read and write data by simulator, the stream is never reordered
+// and the loss is simulated as retransmit which the data is delayed by one more round trip.
+type NetworkConnSimulator struct {
+	net.Conn
+	Simulator *NetworkSimulator
+	writer    *networkPipeLoopback
+	reader    *networkPipeLoopback
+	readed    chan []byte
+	buffer    []byte
+	err       error
+	closed    chan int
+	closer    sync.Once
+	lock      sync.Mutex
+}
+
+func NewNetworkConnSimulator(conn net.Conn, simulator *NetworkSimulator) (wrapper *NetworkConnSimulator) {
+	wrapper = &NetworkConnSimulator{
+		Conn:      conn,
+		Simulator: simulator,
+		writer:    newNetworkPipeLoopback(),
+		reader:    newNetworkPipeLoopback(),
+		readed:    make(chan []byte, 64),
+		closed:    make(chan int),
+	}
+	go wrapper.loopRead()
+	return
+}
+
+func (n *NetworkConnSimulator) delay(size int) (delay time.Duration) {
+	delay = n.Simulator.delay(size)
+	if n.Simulator.lost() {
+		condition := n.Simulator.Condition()
+		delay += 2 * (condition.Latency + condition.Jitter)
+	}
+	return
+}
+
+func (n *NetworkConnSimulator) setError(err error) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	if n.err == nil {
+		n.err = err
+	}
+}
+
+func (n *NetworkConnSimulator) loadError() error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	return n.err
+}
+
+func (n *NetworkConnSimulator) loopRead() {
+	for {
+		buffer := make([]byte, 32*1024)
+		size, err := n.Conn.Read(buffer)
+		if size > 0 {
+			data := buffer[:size]
+			n.reader.Deliver(n.delay(size), func() {
+				select {
+				case n.readed <- data:
+				case <-n.closed:
+				}
+			})
+		}
+		if err != nil {
+			n.reader.Deliver(0, func() {
+				n.setError(err)
+				close(n.readed)
+			})
+			break
+		}
+	}
+}
+
+func (n *NetworkConnSimulator) Read(b []byte) (size int, err error) {
+	if len(n.buffer) < 1 {
+		var data []byte
+		var ok bool
+		select {
+		case data, ok = <-n.readed:
+		case <-n.closed:
+		}
+		if !ok {
+			err = n.loadError()
+			if err == nil {
+				err = io.EOF
+			}
+			return
+		}
+		n.buffer = data
+	}
+	size = copy(b, n.buffer)
+	n.buffer = n.buffer[size:]
+	return
+}
+
+// Write will send data after delay in background, the error of background write is returned by next write
+func (n *NetworkConnSimulator) Write(b []byte) (size int, err error) {
+	if err = n.loadError(); err != nil {
+		return
+	}
+	data := make([]byte, len(b))
+	copy(data, b)
+	n.writer.Deliver(n.delay(len(data)), func() {
+		if _, xerr := n.Conn.Write(data); xerr != nil {
+			n.setError(xerr)
+		}
+	})
+	size = len(b)
+	return
+}
+
+func (n *NetworkConnSimulator) Close() (err error) {
+	n.closer.Do(func() {
+		close(n.closed)
+		n.writer.Close()
+		n.reader.Close()
+	})
+	err = n.Conn.Close()
+	return
+}
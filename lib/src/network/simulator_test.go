@@ -0,0 +1,216 @@
+package network
+
+import (
+	"net"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestSimulator(t *testing.T) {
+	//deterministic
+	a, b := NewNetworkSimulatorBySeed(100), NewNetworkSimulatorBySeed(100)
+	condition := NetworkCondition{Latency: time.Millisecond, Jitter: time.Second, Loss: 0.5, Reorder: 0.5}
+	a.SetCondition(condition)
+	b.SetCondition(condition)
+	for i := 0; i < 10; i++ {
+		if a.delay(0) != b.delay(0) || a.lost() != b.lost() || a.reorder() != b.reorder() {
+			t.Error("error")
+			return
+		}
+	}
+	//bandwidth
+	a.SetCondition(NetworkCondition{Bandwidth: 1000})
+	if delay := a.delay(100); delay < 90*time.Millisecond || delay > 100*time.Millisecond {
+		t.Error(delay)
+		return
+	}
+	if delay := a.delay(100); delay < 190*time.Millisecond || delay > 200*time.Millisecond {
+		t.Error(delay)
+		return
+	}
+	NewNetworkSimulator()
+
+	//transport
+	loopback := NewNetworkLoopbackBySeed(1)
+	simulator := NewNetworkSimulatorBySeed(1)
+	server := NewNetworkContext()
+	server.Network.IsServer = true
+	server.SetTransport(NewNetworkTransportSimulator(NewNetworkTransportLoopbackByContext(server, loopback), simulator))
+	sc := NewNetworkComponentByContext(server, "test", "", "", "c1")
+	sc.SetValue("x", 1)
+	sc.RegisterNetworkProp()
+	sc.RegisterNetworkCall("add", func(ctx NetworkSession, uuid string, v int) (r int, err error) {
+		r = v + 1
+		return
+	})
+	if err := server.Network.Start(); err != nil {
+		t.Error(err)
+		return
+	}
+	defer server.Network.Stop()
+	client := NewNetworkContext()
+	client.Network.IsClient = true
+	client.Network.SyncReliable = false //the reordered stale data is skipped on reliable
+	client.SetTransport(NewNetworkTransportSimulator(NewNetworkTransportLoopbackByContext(client, loopback), simulator))
+	synced := make(chan string, 64)
+	client.ComponentHub.RegisterFactory("test", "", func(key, group, owner, cid string) (c *NetworkComponent, err error) {
+		c = NewNetworkComponentByContext(client, key, group, owner, cid)
+		c.RegisterNetworkProp()
+		c.OnNetworkSynced = func() { synced <- c.Str("x") }
+		return
+	})
+	if err := client.Network.Start(); err != nil {
+		t.Error(err)
+		return
+	}
+	defer client.Network.Stop()
+	if err := client.Network.Ready(); err != nil {
+		t.Error(err)
+		return
+	}
+	if x := <-synced; x != "1" {
+		t.Error(x)
+		return
+	}
+	//latency on call and sync
+	simulator.SetCondition(NetworkCondition{Latency: 20 * time.Millisecond})
+	startTime := time.Now()
+	if ret, err := client.Network.NetworkCall(&NetworkCallArg{CID: "c1", Name: "add", Arg: "1"}); err != nil || ret.Result != "2" || time.Since(startTime) < 40*time.Millisecond {
+		t.Errorf("%v,%v,%v", err, ret, time.Since(startTime))
+		return
+	}
+	time.Sleep(server.Network.MinSync)
+	sc.SetValue("x", 2)
+	startTime = time.Now()
+	server.Network.Sync("", nil)
+	if x := <-synced; x != "2" || time.Since(startTime) < 20*time.Millisecond {
+		t.Errorf("%v,%v", x, time.Since(startTime))
+		return
+	}
+	//reorder
+	simulator.SetCondition(NetworkCondition{Latency: 100 * time.Millisecond, Reorder: 1})
+	time.Sleep(server.Network.MinSync)
+	sc.SetValue("x", 3)
+	server.Network.Sync("", nil)
+	simulator.SetCondition(NetworkCondition{})
+	time.Sleep(server.Network.MinSync)
+	sc.SetValue("x", 4)
+	server.Network.Sync("", nil)
+	if x, y := <-synced, <-synced; x != "4" || y != "3" {
+		t.Errorf("%v,%v", x, y)
+		return
+	}
+	//drop
+	simulator.SetCondition(NetworkCondition{Loss: 1})
+	if _, err := client.Network.NetworkCall(&NetworkCallArg{CID: "c1", Name: "add", Arg: "1"}); err == nil {
+		t.Error("error")
+		return
+	}
+	time.Sleep(server.Network.MinSync)
+	sc.SetValue("x", 5)
+	server.Network.Sync("", nil)
+	time.Sleep(10 * time.Millisecond)
+	if len(synced) != 0 {
+		t.Error("error")
+		return
+	}
+	simulator.SetCondition(NetworkCondition{})
+	if _, err := client.Network.NetworkCall(&NetworkCallArg{CID: "c1", Name: "none", Arg: "1"}); err == nil {
+		t.Error("error")
+		return
+	}
+}
+
+func TestSimulatorConn(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				break
+			}
+			go func() {
+				buffer := make([]byte, 1024)
+				for {
+					n, err := conn.Read(buffer)
+					if err != nil {
+						break
+					}
+					conn.Write(buffer[:n])
+				}
+				conn.Close()
+			}()
+		}
+	}()
+	defer ln.Close()
+	raw, err := net.Dial("tcp", ln.Addr().String())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	simulator := NewNetworkSimulatorBySeed(1)
+	simulator.SetCondition(NetworkCondition{Latency: 10 * time.Millisecond, Loss: 0.5})
+	conn := NewNetworkConnSimulator(raw, simulator)
+	buffer := make([]byte, 1024)
+	for i := 0; i < 5; i++ {
+		startTime := time.Now()
+		if _, err := conn.Write([]byte("abc")); err != nil {
+			t.Error(err)
+			return
+		}
+		if n, err := conn.Read(buffer); err != nil || string(buffer[:n]) != "abc" || time.Since(startTime) < 20*time.Millisecond {
+			t.Errorf("%v,%v,%v", err, n, time.Since(startTime))
+			return
+		}
+	}
+	conn.Close()
+	if _, err := conn.Read(buffer); err == nil {
+		t.Error("error")
+		return
+	}
+}
+
+func TestSimulatorGRPC(t *testing.T) {
+	for _, grpcOn := range []bool{true, false} {
+		server := NewNetworkContext()
+		server.Network.IsServer = true
+		transport := NewNetworkTransportGRPCByContext(server)
+		transport.GrpcAddress, _ = url.Parse("grpc://127.0.0.1:50079")
+		transport.WebAddress, _ = url.Parse("ws://127.0.0.1:50080")
+		server.SetTransport(transport)
+		if err := server.Network.Start(); err != nil {
+			t.Error(err)
+			return
+		}
+		simulator := NewNetworkSimulatorBySeed(1)
+		client := NewNetworkContext()
+		client.Network.IsClient = true
+		client.Network.SetKey("simulator")
+		clientTransport := NewNetworkTransportGRPCByContext(client)
+		clientTransport.GrpcOn = grpcOn
+		clientTransport.GrpcAddress, _ = url.Parse("grpc://127.0.0.1:50079")
+		clientTransport.WebAddress, _ = url.Parse("ws://127.0.0.1:50080")
+		clientTransport.Simulator = simulator
+		client.SetTransport(clientTransport)
+		if err := client.Network.Start(); err != nil {
+			t.Error(err)
+			return
+		}
+		if _, _, err := clientTransport.Client.Ping(); err != nil {
+			t.Errorf("%v,%v", grpcOn, err)
+			return
+		}
+		simulator.SetCondition(NetworkCondition{Latency: 20 * time.Millisecond})
+		if speed, _, err := clientTransport.Client.Ping(); err != nil || speed < 40*time.Millisecond {
+			t.Errorf("%v,%v,%v", grpcOn, err, speed)
+			return
+		}
+		client.Network.Stop()
+		server.Network.Stop()
+	}
+}
@@ -0,0 +1,533 @@
+package network
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/centny/flame_network/lib/src/network/grpc"
+	"github.com/codingeasygo/util/xdebug"
+	"golang.org/x/net/websocket"
+	ggrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// NetworkStreamWS is the stream of grpc.StreamData on websocket frame, the binary frame is protobuf and the text frame is json,
+// it implements both grpc.Server_RemoteStreamServer and grpc.Server_RemoteStreamClient, so the stream processing is shared with grpc
+type NetworkStreamWS struct {
+	Conn    *websocket.Conn
+	ctx     context.Context
+	binary  int32
+	sending sync.Mutex
+	closed  chan int
+	closer  sync.Once
+}
+
+func NewNetworkStreamWS(conn *websocket.Conn, ctx context.Context, binary bool) (stream *NetworkStreamWS) {
+	stream = &NetworkStreamWS{
+		Conn:   conn,
+		ctx:    ctx,
+		closed: make(chan int),
+	}
+	stream.SetBinary(binary)
+	return
+}
+
+// Binary return if the stream send binary frame, it is changed to received frame type on server
+func (n *NetworkStreamWS) Binary() bool {
+	return atomic.LoadInt32(&n.binary) > 0
+}
+
+func (n *NetworkStreamWS) SetBinary(binary bool) {
+	if binary {
+		atomic.StoreInt32(&n.binary, 1)
+	} else {
+		atomic.StoreInt32(&n.binary, 0)
+	}
+}
+
+func (n *NetworkStreamWS) Send(data *grpc.StreamData) (err error) {
+	n.sending.Lock()
+	defer n.sending.Unlock()
+	codec := &websocket.Codec{
+		Marshal: func(v interface{}) (frame []byte, payloadType byte, err error) {
+			if n.Binary() {
+				frame, err = proto.Marshal(data)
+				payloadType = websocket.BinaryFrame
+			} else {
+				frame, err = protojson.Marshal(data)
+				payloadType = websocket.TextFrame
+			}
+			return
+		},
+	}
+	err = codec.Send(n.Conn, nil)
+	return
+}
+
+func (n *NetworkStreamWS) Recv() (data *grpc.StreamData, err error) {
+	data = &grpc.StreamData{}
+	codec := &websocket.Codec{
+		Unmarshal: func(frame []byte, payloadType byte, v interface{}) (err error) {
+			switch payloadType {
+			case websocket.BinaryFrame:
+				n.SetBinary(true)
+				err = proto.Unmarshal(frame, data)
+			default:
+				n.SetBinary(false)
+				err = protojson.Unmarshal(frame, data)
+			}
+			return
+		},
+	}
+	err = codec.Receive(n.Conn, nil)
+	if err != nil {
+		data = nil
+		n.Close()
+	}
+	return
+}
+
+func (n *NetworkStreamWS) Close() (err error) {
+	n.closer.Do(func() {
+		close(n.closed)
+		err = n.Conn.Close()
+	})
+	return
+}
+
+func (n *NetworkStreamWS) Context() context.Context {
+	return n.ctx
+}
+
+func (n *NetworkStreamWS) SendMsg(m interface{}) error {
+	return n.Send(m.(*grpc.StreamData))
+}
+
+func (n *NetworkStreamWS) RecvMsg(m interface{}) (err error) {
+	data, err := n.Recv()
+	if err == nil {
+		proto.Merge(m.(*grpc.StreamData), data)
+	}
+	return
+}
+
+func (n *NetworkStreamWS) SetHeader(metadata.MD) error {
+	return nil
+}
+
+func (n *NetworkStreamWS) SendHeader(metadata.MD) error {
+	return nil
+}
+
+func (n *NetworkStreamWS) SetTrailer(metadata.MD) {
+}
+
+func (n *NetworkStreamWS) Header() (metadata.MD, error) {
+	return metadata.MD{}, nil
+}
+
+func (n *NetworkStreamWS) Trailer() metadata.MD {
+	return metadata.MD{}
+}
+
+func (n *NetworkStreamWS) CloseSend() error {
+	return n.Close()
+}
+
+// NetworkServerWS is the server on websocket, the session and sync stream is managed same as NetworkServerGRPC
+type NetworkServerWS struct {
+	*NetworkServerGRPC
+	Websocket *websocket.Server
+}
+
+func NewNetworkServerWS(ctx *NetworkContext, callback NetworkCallback) (server *NetworkServerWS) {
+	server = &NetworkServerWS{
+		NetworkServerGRPC: NewNetworkServerGRPCByContext(ctx, callback),
+		Websocket:         &websocket.Server{},
+	}
+	server.Websocket.Handler = server.handleWS
+	return
+}
+
+func (n *NetworkServerWS) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	n.Websocket.ServeHTTP(res, req)
+}
+
+// handleWS will process stream on websocket conn, the session meta is received by first frame
+func (n *NetworkServerWS) handleWS(conn *websocket.Conn) {
+	defer func() {
+		if perr := recover(); perr != nil {
+			Errorf("[WS] handle websocket painc with %v, callstack is \n%v", perr, xdebug.CallStack())
+		}
+	}()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream := NewNetworkStreamWS(conn, ctx, false)
+	defer stream.Close()
+	conn.SetReadDeadline(time.Now().Add(n.Context.Network.Timeout))
+	first, err := stream.Recv()
+	if err != nil {
+		Debugf("[WS] websocket stream from %v recv meta fail with %v", conn.Request().RemoteAddr, err)
+		return
+	}
+	if first.Ping != nil || first.Sync != nil || first.SyncData != nil || first.Call != nil || first.Ack != nil {
+		Warnf("[WS] websocket stream from %v is not started by meta", conn.Request().RemoteAddr)
+		return
+	}
+	conn.SetReadDeadline(time.Time{})
+	md := metadata.MD{}
+	for _, meta := range first.Meta {
+		md.Append(meta.Key, meta.Value)
+	}
+	stream.ctx = metadata.NewIncomingContext(ctx, md)
+	err = n.RemoteStream(stream)
+	Debugf("[WS] websocket stream from %v is done by %v", conn.Request().RemoteAddr, err)
+}
+
+// NetworkServerClientWS is the grpc.ServerClient on websocket, the stream is on one websocket conn and
+// the unary call is on one websocket conn for each call
+type NetworkServerClientWS struct {
+	Config *websocket.Config
+	Binary bool
+}
+
+func NewNetworkServerClientWS(config *websocket.Config, binary bool) (client *NetworkServerClientWS) {
+	client = &NetworkServerClientWS{
+		Config: config,
+		Binary: binary,
+	}
+	return
+}
+
+// dial will connect to server and send session meta from outgoing context by first frame
+func (n *NetworkServerClientWS) dial(ctx context.Context) (stream *NetworkStreamWS, err error) {
+	config := *n.Config
+	if deadline, ok := ctx.Deadline(); ok {
+		config.Dialer = &net.Dialer{Deadline: deadline}
+	}
+	conn, err := websocket.DialConfig(&config)
+	if err != nil {
+		return
+	}
+	stream = NewNetworkStreamWS(conn, ctx, n.Binary)
+	first := &grpc.StreamData{}
+	md, _ := metadata.FromOutgoingContext(ctx)
+	for k, vals := range md {
+		for _, v := range vals {
+			first.Meta = append(first.Meta, &grpc.StreamMeta{Key: k, Value: v})
+		}
+	}
+	if err = stream.Send(first); err != nil {
+		stream.Close()
+		stream = nil
+		return
+	}
+	go func() {
+		select {
+		case <-ctx.Done():
+			stream.Close()
+		case <-stream.closed:
+		}
+	}()
+	return
+}
+
+// invoke will send data on new websocket conn and wait one result
+func (n *NetworkServerClientWS) invoke(ctx context.Context, data *grpc.StreamData) (result *grpc.StreamData, err error) {
+	stream, err := n.dial(ctx)
+	if err != nil {
+		return
+	}
+	defer stream.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		stream.Conn.SetDeadline(deadline)
+	}
+	err = stream.Send(data)
+	if err == nil {
+		result, err = stream.Recv()
+	}
+	return
+}
+
+func (n *NetworkServerClientWS) RemotePing(ctx context.Context, in *grpc.PingArg, opts ...ggrpc.CallOption) (result *grpc.PingResult, err error) {
+	data, err := n.invoke(ctx, &grpc.StreamData{Ping: in})
+	if err == nil {
+		result = data.PingResult
+	}
+	return
+}
+
+func (n *NetworkServerClientWS) RemoteSync(ctx context.Context, in *grpc.SyncArg, opts ...ggrpc.CallOption) (grpc.Server_RemoteSyncClient, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoteSync not implemented on websocket")
+}
+
+func (n *NetworkServerClientWS) RemoteCall(ctx context.Context, in *grpc.CallArg, opts ...ggrpc.CallOption) (result *grpc.CallResult, err error) {
+	data, err := n.invoke(ctx, &grpc.StreamData{Call: in})
+	if err == nil {
+		result = data.CallResult
+	}
+	return
+}
+
+func (n *NetworkServerClientWS) RemoteAck(ctx context.Context, opts ...ggrpc.CallOption) (grpc.Server_RemoteAckClient, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoteAck not implemented on websocket")
+}
+
+func (n *NetworkServerClientWS) RemoteStream(ctx context.Context, opts ...ggrpc.CallOption) (stream grpc.Server_RemoteStreamClient, err error) {
+	stream, err = n.dial(ctx)
+	return
+}
+
+func (n *NetworkServerClientWS) RemoteUpdate(ctx context.Context, in *grpc.SyncData, opts ...ggrpc.CallOption) (result *grpc.UpdateResult, err error) {
+	stream, err := n.dial(ctx)
+	if err != nil {
+		return
+	}
+	defer stream.Close()
+	err = stream.Send(&grpc.StreamData{SyncData: in})
+	if err == nil {
+		result = &grpc.UpdateResult{Id: in.Id}
+	}
+	return
+}
+
+// NetworkClientWS is the client on websocket, the call, ping and sync is processed same as NetworkClientGRPC on stream
+type NetworkClientWS struct {
+	*NetworkClientGRPC
+}
+
+func NewNetworkClientWS(ctx *NetworkContext, config *websocket.Config, binary bool, callback NetworkCallback) (client *NetworkClientWS) {
+	client = &NetworkClientWS{
+		NetworkClientGRPC: NewNetworkClientGRPCByContext(ctx, nil, callback),
+	}
+	client.ServerClient = NewNetworkServerClientWS(config, binary)
+	return
+}
+
+// NetworkTransportWS is the transport by simple message protocol on websocket frame without grpc, the dart client is NetworkManagerWS
+type NetworkTransportWS struct {
+	Context      *NetworkContext
+	Address      *url.URL
+	Binary       bool // if client send binary frame, the server reply by frame type received
+	Client       *NetworkClientWS
+	Server       *NetworkServerWS
+	WebServer    *http.Server
+	WebMux       *http.ServeMux
+	Listener     net.Listener
+	ServerConfig *tls.Config
+	ConnConfig   *tls.Config
+	initial      bool
+	running      bool
+	ready        bool
+	exiter       chan int
+	waiter       sync.WaitGroup
+}
+
+func NewNetworkTransportWS() (transport *NetworkTransportWS) {
+	transport = NewNetworkTransportWSByContext(DefaultContext)
+	return
+}
+
+func NewNetworkTransportWSByContext(ctx *NetworkContext) (transport *NetworkTransportWS) {
+	transport = &NetworkTransportWS{
+		Context: ctx,
+		Binary:  true,
+		exiter:  make(chan int, 8),
+		waiter:  sync.WaitGroup{},
+	}
+	transport.Address, _ = url.Parse("ws://127.0.0.1:50053")
+	transport.Server = NewNetworkServerWS(ctx, ctx.Network)
+	transport.WebMux = http.NewServeMux()
+	transport.WebServer = &http.Server{Handler: transport.WebMux}
+	return
+}
+
+func (n *NetworkTransportWS) serveWeb(ln net.Listener) {
+	defer n.waiter.Done()
+	Infof("[WS] start websocket server on %v", ln.Addr())
+	err := n.WebServer.Serve(ln)
+	Infof("[WS] websocket server on %v is stopped by %v", ln.Addr(), err)
+}
+
+func (n *NetworkTransportWS) connect() (err error) {
+	if n.Client != nil {
+		n.Client.Close()
+	}
+	originURL := n.Address.String()
+	originURL = strings.ReplaceAll(originURL, "ws://", "http://")
+	originURL = strings.ReplaceAll(originURL, "wss://", "https://")
+	origin, _ := url.Parse(originURL)
+	config := &websocket.Config{
+		Location:  n.Address,
+		Origin:    origin,
+		TlsConfig: n.ConnConfig,
+		Version:   websocket.ProtocolVersionHybi13,
+	}
+	n.Client = NewNetworkClientWS(n.Context, config, n.Binary, n.Context.Network)
+	n.Client.contact()
+	if n.ready {
+		err = n.Client.Start()
+	}
+	return
+}
+
+func (n *NetworkTransportWS) loopKeep() {
+	defer n.waiter.Done()
+	Infof("[WS] keepalive task is starting by %v", n.Context.Network.Keepalive)
+	ticker := time.NewTicker(n.Context.Network.Keepalive)
+	running := true
+	for running {
+		select {
+		case <-ticker.C:
+			n.procKeep()
+		case <-n.exiter:
+			running = false
+		}
+	}
+	Infof("[WS] keepalive task is stopped")
+}
+
+// ClientConn will return the current client connection
+func (n *NetworkTransportWS) ClientConn() NetworkClientConn {
+	if n.Client == nil {
+		return nil
+	}
+	return n.Client
+}
+
+func (n *NetworkTransportWS) procKeep() {
+	defer func() {
+		if perr := recover(); perr != nil {
+			Errorf("[WS] proc keep painc with %v, callstack is \n%v", perr, xdebug.CallStack())
+		}
+	}()
+	network := n.Context.Network
+	if network.IsServer && n.running {
+		n.Server.timeout(network.Keepalive*2 + network.SessionGrace)
+	}
+	if network.IsClient && n.running {
+		speed, serverTime, err := n.Client.Ping()
+		if err != nil {
+			Warnf("[WS] ping to server error %v", err)
+			n.Client.breakSync()
+		} else {
+			network.PingSpeed = speed
+			network.SetServerTime(serverTime, speed)
+			network.OnNetworkPing(n.Client, speed)
+		}
+	}
+}
+
+func (n *NetworkTransportWS) Start() (err error) {
+	if !n.initial {
+		path := n.Address.Path
+		if len(path) < 1 {
+			path = "/"
+		}
+		n.WebMux.Handle(path, n.Server)
+		n.initial = true
+	}
+	network := n.Context.Network
+	if network.IsServer {
+		if n.ServerConfig == nil {
+			n.Listener, err = net.Listen("tcp", n.Address.Host)
+		} else {
+			n.Listener, err = tls.Listen("tcp", n.Address.Host, n.ServerConfig)
+		}
+		if err != nil {
+			return
+		}
+		n.waiter.Add(1)
+		go n.serveWeb(n.Listener)
+		n.running = true
+	}
+	if network.IsClient {
+		err = n.connect()
+		n.running = err == nil
+		if err != nil {
+			return
+		}
+	}
+	if network.Keepalive > 0 {
+		n.waiter.Add(1)
+		go n.loopKeep()
+	}
+	if network.IsServer && !network.IsClient {
+		err = n.Ready()
+	}
+	return
+}
+
+func (n *NetworkTransportWS) Stop() (err error) {
+	n.exiter <- 1
+	if n.Listener != nil {
+		n.Listener.Close()
+		n.Listener = nil
+	}
+	if n.Client != nil {
+		n.Client.Stop()
+		n.Client = nil
+	}
+	n.Server.Close()
+	n.waiter.Wait()
+	n.ready = false
+	return
+}
+
+func (n *NetworkTransportWS) IsReady() (ready bool) {
+	ready = n.ready
+	return
+}
+
+func (n *NetworkTransportWS) Ready() (err error) {
+	if n.Context.Network.IsClient {
+		if n.Client == nil {
+			err = fmt.Errorf("not started")
+			return
+		}
+		err = n.Client.Start()
+	}
+	n.ready = err == nil
+	return
+}
+
+func (n *NetworkTransportWS) Pause() (err error) {
+	if n.Context.Network.IsClient {
+		if n.Client == nil {
+			err = fmt.Errorf("not started")
+			return
+		}
+		err = n.Client.Stop()
+	}
+	n.ready = err == nil
+	return
+}
+
+func (n *NetworkTransportWS) NetworkSync(data *NetworkSyncData, excluded []NetworkConnection) {
+	if n.Context.Network.IsServer {
+		n.Server.NetworkSync(data, excluded)
+	} else if n.Context.Network.IsClient && n.Client != nil {
+		n.Client.NetworkSync(data)
+	}
+}
+
+func (n *NetworkTransportWS) NetworkCall(arg *NetworkCallArg) (ret *NetworkCallResult, err error) {
+	if !n.Context.Network.IsClient || n.Client == nil {
+		err = fmt.Errorf("not client or not connect")
+		return
+	}
+	ret, err = n.Client.NetworkCall(arg)
+	return
+}
@@ -0,0 +1,204 @@
+package network
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/centny/flame_network/lib/src/network/grpc"
+	"golang.org/x/net/websocket"
+)
+
+func TestWebsocket(t *testing.T) {
+	server := NewNetworkContext()
+	server.Network.IsServer = true
+	server.ComponentHub.RegisterAuthority("test", "x", true)
+	transport := NewNetworkTransportWSByContext(server)
+	transport.Address, _ = url.Parse("ws://127.0.0.1:50078/ws")
+	server.SetTransport(transport)
+	sc := NewNetworkComponentByContext(server, "test", "", "u1", "c1")
+	sc.SetValue("x", 1)
+	sc.RegisterNetworkProp()
+	sc.RegisterNetworkCall("add", func(ctx NetworkSession, uuid string, v int) (r int, err error) {
+		r = v + 1
+		return
+	})
+	sc.RegisterNetworkCall("join", func(ctx NetworkSession, uuid string, user string) (err error) {
+		ctx.SetUser(user)
+		return
+	})
+	if err := server.Network.Start(); err != nil {
+		t.Error(err)
+		return
+	}
+	defer server.Network.Stop()
+	for _, binary := range []bool{true, false} {
+		client := NewNetworkContext()
+		client.Network.IsClient = true
+		client.Network.SetKey("u1")
+		client.Network.SetUser("u1")
+		client.ComponentHub.RegisterAuthority("test", "x", true)
+		clientTransport := NewNetworkTransportWSByContext(client)
+		clientTransport.Address, _ = url.Parse("ws://127.0.0.1:50078/ws")
+		clientTransport.Binary = binary
+		client.SetTransport(clientTransport)
+		synced := make(chan string, 64)
+		client.ComponentHub.RegisterFactory("test", "", func(key, group, owner, cid string) (c *NetworkComponent, err error) {
+			c = NewNetworkComponentByContext(client, key, group, owner, cid)
+			c.RegisterNetworkProp()
+			c.OnNetworkSynced = func() { synced <- c.Str("x") }
+			return
+		})
+		if err := client.Network.Start(); err != nil {
+			t.Error(err)
+			return
+		}
+		//call and ping before ready
+		cc := NewNetworkComponentByContext(client, "test", "", "u1", "c1")
+		var r int
+		if err := cc.NetworkCall("add", 1, &r); err != nil || r != 2 {
+			t.Errorf("%v,%v,%v", binary, err, r)
+			return
+		}
+		if err := cc.NetworkCall("join", "u1", nil); err != nil {
+			t.Errorf("%v,%v", binary, err)
+			return
+		}
+		if _, _, err := clientTransport.Client.Ping(); err != nil {
+			t.Errorf("%v,%v", binary, err)
+			return
+		}
+		//sync
+		if err := client.Network.Ready(); err != nil {
+			t.Error(err)
+			return
+		}
+		if x := <-synced; x != "1" {
+			t.Errorf("%v,%v", binary, x)
+			return
+		}
+		if clientTransport.Client.stream == nil {
+			t.Errorf("%v", binary)
+			return
+		}
+		cc = client.ComponentHub.FindComponent("c1")
+		for i := 0; i < 10; i++ {
+			if err := cc.NetworkCall("add", i, &r); err != nil || r != i+1 {
+				t.Errorf("%v,%v,%v", binary, err, r)
+				return
+			}
+		}
+		if _, serverTime, err := clientTransport.Client.Ping(); err != nil || serverTime.IsZero() {
+			t.Errorf("%v,%v", binary, err)
+			return
+		}
+		time.Sleep(server.Network.MinSync)
+		sc.SetValue("x", 2)
+		server.Network.Sync("", nil)
+		for x := <-synced; x != "2"; x = <-synced {
+		}
+		//publish
+		time.Sleep(client.Network.MinSync)
+		cc.SetValue("x", 3)
+		client.Network.Sync("", nil)
+		for i := 0; i < 100 && sc.Int("x") != 3; i++ {
+			time.Sleep(10 * time.Millisecond)
+		}
+		if sc.Int("x") != 3 {
+			t.Errorf("%v,%v", binary, sc.Int("x"))
+			return
+		}
+		client.Network.Stop()
+		sc.SetValue("x", 1)
+	}
+	//not started by meta
+	config, _ := websocket.NewConfig("ws://127.0.0.1:50078/ws", "http://127.0.0.1:50078")
+	conn, err := websocket.DialConfig(config)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	stream := NewNetworkStreamWS(conn, context.Background(), true)
+	stream.Send(&grpc.StreamData{Ping: &grpc.PingArg{Id: &grpc.RequestID{Uuid: "1"}}})
+	if _, err := stream.Recv(); err == nil {
+		t.Error("error")
+		return
+	}
+	stream.Close()
+	//unimplemented
+	client := NewNetworkServerClientWS(&websocket.Config{}, true)
+	if _, err := client.RemoteSync(context.Background(), &grpc.SyncArg{}); err == nil {
+		t.Error("error")
+		return
+	}
+	if _, err := client.RemoteAck(context.Background()); err == nil {
+		t.Error("error")
+		return
+	}
+}
+
+func TestWebsocketReconnect(t *testing.T) {
+	server := NewNetworkContext()
+	server.Network.IsServer = true
+	transport := NewNetworkTransportWSByContext(server)
+	transport.Address, _ = url.Parse("ws://127.0.0.1:50097/ws")
+	server.SetTransport(transport)
+	sc := NewNetworkComponentByContext(server, "test", "", "", "c1")
+	sc.SetValue("x", 1)
+	sc.RegisterNetworkProp()
+	if err := server.Network.Start(); err != nil {
+		t.Error(err)
+		return
+	}
+	defer server.Network.Stop()
+
+	client := NewNetworkContext()
+	client.Network.IsClient = true
+	client.Network.Reconnect = NewNetworkBackoffBySeed(1)
+	client.Network.Reconnect.Min = 50 * time.Millisecond
+	client.Network.Reconnect.Max = 100 * time.Millisecond
+	event := &TestSessionEvent{states: make(chan string, 1024)}
+	client.EventHub.RegisterNetworkEvent("*", event)
+	clientTransport := NewNetworkTransportWSByContext(client)
+	clientTransport.Address, _ = url.Parse("ws://127.0.0.1:50097/ws")
+	client.SetTransport(clientTransport)
+	synced := make(chan string, 64)
+	client.ComponentHub.RegisterFactory("test", "", func(key, group, owner, cid string) (c *NetworkComponent, err error) {
+		c = NewNetworkComponentByContext(client, key, group, owner, cid)
+		c.RegisterNetworkProp()
+		c.OnNetworkSynced = func() { synced <- c.Str("x") }
+		return
+	})
+	if err := client.Network.Start(); err != nil {
+		t.Error(err)
+		return
+	}
+	defer client.Network.Stop()
+	if err := client.Network.Ready(); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := waitSessionState(event, NetworkStateReady); err != nil || <-synced != "1" {
+		t.Error(err)
+		return
+	}
+
+	//ping fail is restarted by backoff on same client
+	connected := clientTransport.Client
+	client.Network.Timeout = time.Nanosecond
+	clientTransport.procKeep()
+	client.Network.Timeout = time.Second
+	if err := waitSessionState(event, NetworkStateError); err != nil {
+		t.Error(err)
+		return
+	}
+	if state := <-event.states; state != "100,1" {
+		t.Error(state)
+		return
+	}
+	if err := waitSessionState(event, NetworkStateReady); err != nil || <-synced != "1" || clientTransport.Client != connected {
+		t.Error(err)
+		return
+	}
+}
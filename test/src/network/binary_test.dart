@@ -0,0 +1,33 @@
+import 'package:flutter_test/flutter_test.dart';
+import 'package:flame_network/flame_network.dart';
+
+void main() {
+  test('NetworkBinaryDecoder.decode', () async {
+    //encoded by go NetworkBinaryEncoder
+    var data0 = [
+      6, 0, 3, 105, 110, 116, 3, 5, 0, 4, 110, 97, 109, 101, 5, 3, 97, 98, 99, 0, 3, 111, 98, 106, 8, 1, 1, 97, 0, 0, 2, 111, 107, 2, 0, 3, 112, //
+      111, 115, 6, 2, 0, 0, 192, 63, 0, 0, 0, 192, 0, 3, 115, 101, 113, 7, 2, 3, 4, 4, 0, 0, 0, 63, 1, 0, 4, 102, 105, 114, 101, 1, 3, 216, 4,
+    ];
+    var data1 = [1, 1, 3, 2, 0];
+    var decoder = NetworkBinaryDecoder();
+    var (props, triggers) = decoder.decodeComponent("test", data0);
+    assert(props["int"] == -3);
+    assert(props["name"] == "abc");
+    assert(props["obj"]["a"] == null);
+    assert(props["ok"] == true);
+    assert(props["pos"][0] == 1.5 && props["pos"][1] == -2.0);
+    assert(props["seq"][0] == 2 && props["seq"][1] == 0.5);
+    assert(triggers["fire"]![0] == 300);
+    (props, triggers) = decoder.decodeComponent("test", data1);
+    assert(props["int"] == 1);
+    assert(triggers.isEmpty);
+    var vector = NetworkVector2.zero()..decode(NetworkBinaryDecoder().decodeComponent("test", data0).$1["pos"]);
+    assert(vector.x == 1.5 && vector.y == -2.0);
+    //key not exists
+    expect(() => NetworkBinaryDecoder().decodeComponent("test", data1), throwsFormatException);
+    //invalid data
+    expect(() => NetworkBinaryDecoder().decodeComponent("test", [1, 0, 3, 105]), throwsFormatException);
+    expect(() => NetworkBinaryDecoder().decodeComponent("test", [1, 0, 1, 105, 100]), throwsFormatException);
+    expect(() => NetworkBinaryDecoder().decodeComponent("test", [1, 0, 1, 105, 4, 0]), throwsFormatException);
+  });
+}